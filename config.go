@@ -26,6 +26,192 @@ const (
 	WithQueryInterface
 )
 
+// BinaryDefaultMode how to generate default tag for binary(bytea/blob) column
+type BinaryDefaultMode = model.BinaryDefaultMode
+
+const (
+	// BinaryDefaultAsIs keep default value as database reported
+	BinaryDefaultAsIs = model.BinaryDefaultAsIs
+	// BinaryDefaultDrop drop default tag
+	BinaryDefaultDrop = model.BinaryDefaultDrop
+	// BinaryDefaultHex generate default tag as hex literal, empty bytes default generate no tag
+	BinaryDefaultHex = model.BinaryDefaultHex
+	// BinaryDefaultComment generate default tag as BinaryDefaultHex and note default as Go byte literal in field comment
+	BinaryDefaultComment = model.BinaryDefaultComment
+)
+
+// NullableStrategy Go type of nullable field
+type NullableStrategy = model.NullableStrategy
+
+const (
+	// NullablePointer generate pointer, e.g. *string
+	NullablePointer = model.NullablePointer
+	// NullableSQLNull generate sql.Null* type, e.g. sql.NullString, type without sql.Null* equivalent falls back to pointer
+	NullableSQLNull = model.NullableSQLNull
+)
+
+// NullableBoolMode how to generate nullable bool column
+type NullableBoolMode = model.NullableBoolMode
+
+const (
+	// NullableBoolAsIs type and default tag depend on FieldNullable, FieldCoverable and FieldNullableStrategy
+	NullableBoolAsIs = model.NullableBoolAsIs
+	// NullableBoolDefault generate *bool with default tag, nil means database default(NULL if no default)
+	NullableBoolDefault = model.NullableBoolDefault
+	// NullableBoolNoDefault generate *bool without default tag, nil means NULL
+	NullableBoolNoDefault = model.NullableBoolNoDefault
+)
+
+// EmptyDefaultMode how to generate default tag for empty or whitespace-only string default
+type EmptyDefaultMode = model.EmptyDefaultMode
+
+const (
+	// EmptyDefaultQuoted generate quoted default, e.g. default:''
+	EmptyDefaultQuoted = model.EmptyDefaultQuoted
+	// EmptyDefaultUnquoted generate default value as database reported without quotes
+	EmptyDefaultUnquoted = model.EmptyDefaultUnquoted
+	// EmptyDefaultDrop drop default tag
+	EmptyDefaultDrop = model.EmptyDefaultDrop
+)
+
+// UnixTimePrecision precision of integer unix timestamp column
+type UnixTimePrecision = model.UnixTimePrecision
+
+const (
+	// UnixTimeSecond unix seconds, gorm built-in unixtime serializer
+	UnixTimeSecond = model.UnixTimeSecond
+	// UnixTimeMilli unix milliseconds, field.UnixTimeSerializer{Unit: time.Millisecond}
+	UnixTimeMilli = model.UnixTimeMilli
+	// UnixTimeNano unix nanoseconds, field.UnixTimeSerializer{Unit: time.Nanosecond}
+	UnixTimeNano = model.UnixTimeNano
+)
+
+// IndexPrecedence precedence between config declared composite index and database reported index on the same columns
+type IndexPrecedence = model.IndexPrecedence
+
+const (
+	// IndexPrecedenceDB database reported index wins, config declared index only supplements missing ones
+	IndexPrecedenceDB = model.IndexPrecedenceDB
+	// IndexPrecedenceConfig config declared index wins, conflicting database reported index is dropped
+	IndexPrecedenceConfig = model.IndexPrecedenceConfig
+)
+
+// XMLType Go type of xml column without data type mapping
+type XMLType = model.XMLType
+
+const (
+	// XMLTypeAsIs keep data type as is(string)
+	XMLTypeAsIs = model.XMLTypeAsIs
+	// XMLTypeBytes []byte, preferred for large documents
+	XMLTypeBytes = model.XMLTypeBytes
+)
+
+// ArrayType Go type of postgres array column without data type mapping
+type ArrayType = model.ArrayType
+
+const (
+	// ArrayTypeAsIs keep data type as is(string)
+	ArrayTypeAsIs = model.ArrayTypeAsIs
+	// ArrayTypePQ array types of github.com/lib/pq, e.g. pq.StringArray, pq.Int64Array, NULL is nil slice
+	ArrayTypePQ = model.ArrayTypePQ
+	// ArrayTypeJSON slice of element type with json serializer, e.g. []string, driver-agnostic, NULL is nil slice
+	ArrayTypeJSON = model.ArrayTypeJSON
+)
+
+// PointerPolicy whether generate pointer for field of Go type or type family, see WithPointerPolicy
+type PointerPolicy = model.PointerPolicy
+
+const (
+	// PointerPolicyDefault pointer decided by FieldNullable and FieldCoverable
+	PointerPolicyDefault = model.PointerPolicyDefault
+	// PointerPolicyValue never pointer, NULL is scanned as zero value
+	PointerPolicyValue = model.PointerPolicyValue
+	// PointerPolicyNullable pointer when column is nullable
+	PointerPolicyNullable = model.PointerPolicyNullable
+	// PointerPolicyAlways always pointer
+	PointerPolicyAlways = model.PointerPolicyAlways
+)
+
+const (
+	// TypeFamilyNumber type family of integer and float types
+	TypeFamilyNumber = model.TypeFamilyNumber
+	// TypeFamilyCustom type family of types other than builtin types and time.Time, e.g. decimal.Decimal
+	TypeFamilyCustom = model.TypeFamilyCustom
+)
+
+// EmbeddedConflictMode how to handle table column field colliding with embedded struct field
+type EmbeddedConflictMode = model.EmbeddedConflictMode
+
+const (
+	// EmbeddedConflictAsIs keep fields as is without detection
+	EmbeddedConflictAsIs = model.EmbeddedConflictAsIs
+	// EmbeddedConflictPreferEmbedded drop table column field, embedded struct provides the column
+	EmbeddedConflictPreferEmbedded = model.EmbeddedConflictPreferEmbedded
+	// EmbeddedConflictRename keep table column field renamed with Column suffix, e.g. ID => IDColumn
+	EmbeddedConflictRename = model.EmbeddedConflictRename
+	// EmbeddedConflictError fail generation with colliding table, column and embedded type
+	EmbeddedConflictError = model.EmbeddedConflictError
+)
+
+// SharedPKMode how to generate primary key column which is also foreign key
+type SharedPKMode = model.SharedPKMode
+
+const (
+	// SharedPKAsIs keep primary key as is, foreign keys are not read
+	SharedPKAsIs = model.SharedPKAsIs
+	// SharedPKNoAutoIncrement generate autoIncrement:false, key value comes from referenced row
+	SharedPKNoAutoIncrement = model.SharedPKNoAutoIncrement
+	// SharedPKComment generate as SharedPKNoAutoIncrement and note referenced column in field comment, e.g. shared primary key references users(id)
+	SharedPKComment = model.SharedPKComment
+)
+
+// OmitemptyPolicy decide whether append omitempty option to tag of column, see WithTagOmitempty
+type OmitemptyPolicy = model.OmitemptyPolicy
+
+var (
+	// OmitemptyAlways append omitempty to tag of every field
+	OmitemptyAlways OmitemptyPolicy = model.OmitemptyAlways
+	// OmitemptyNullable append omitempty to tag of nullable column's field
+	OmitemptyNullable OmitemptyPolicy = model.OmitemptyNullable
+)
+
+// PointerDefaultMode how to generate default tag for pointer field with default value
+type PointerDefaultMode = model.PointerDefaultMode
+
+const (
+	// PointerDefaultAsIs generate default:<value>
+	PointerDefaultAsIs = model.PointerDefaultAsIs
+	// PointerDefaultComment generate default:<value> and note that nil means database default in field comment
+	PointerDefaultComment = model.PointerDefaultComment
+	// PointerDefaultDBManaged generate default:(-), nil means database default and value is not declared in tag
+	PointerDefaultDBManaged = model.PointerDefaultDBManaged
+)
+
+// JSONType Go type of json column without data type mapping
+type JSONType = model.JSONType
+
+const (
+	// JSONTypeAsIs keep data type as is(string)
+	JSONTypeAsIs = model.JSONTypeAsIs
+	// JSONTypeRaw datatypes.JSON
+	JSONTypeRaw = model.JSONTypeRaw
+	// JSONTypeMap map[string]interface{} with json serializer, []interface{} for json array column
+	JSONTypeMap = model.JSONTypeMap
+)
+
+// ProtoNullableMode how to declare protobuf message field of nullable model field
+type ProtoNullableMode = model.ProtoNullableMode
+
+const (
+	// ProtoNullableOptional proto3 optional scalar, e.g. optional string name = 2
+	ProtoNullableOptional = model.ProtoNullableOptional
+	// ProtoNullableWrapper well-known wrapper type, e.g. google.protobuf.StringValue name = 2
+	ProtoNullableWrapper = model.ProtoNullableWrapper
+)
+
+// EntityInterface interface implemented by model with ID getter, e.g. repo.Entity declaring GetID() int64
+type EntityInterface = model.EntityInterface
+
 // Config generator's basic configuration
 type Config struct {
 	db *gorm.DB // db connection
@@ -34,13 +220,95 @@ type Config struct {
 	OutFile      string // query code file name, default: gen.go
 	ModelPkgPath string // generated model code's package name
 	WithUnitTest bool   // generate unit test for query code
+	DDLDialect   string // dialect of DDL files read by NewGeneratorFromDDL(mysql, postgres, sqlite, sqlserver), default: mysql
+	Concurrency  int    // number of tables read concurrently by GenerateAllTable, default 1(sequential), when greater than 1 user callbacks(data type map funcs, ModelOpt, tag generators etc.) are called concurrently and must be safe for concurrent use
+	Incremental  bool   // skip rendering and rewriting model and query files of tables whose schema(column/index metadata) and generated struct are unchanged since last run, hashes are kept in .gen.hash.json of OutPath, file with unchanged content is never rewritten
+
+	// protobuf messages of models generated by GenerateProto, one .proto file per table
+	ProtoOutPath        string            // protobuf file path, default: proto directory beside query code path
+	ProtoPackage        string            // protobuf package name, default: base name of ProtoOutPath
+	ProtoGoPackage      string            // go_package option of protobuf files, required by WithProtoConverters
+	ProtoNullable       ProtoNullableMode // how to declare message field of nullable model field, default proto3 optional
+	WithProtoConverters bool              // generate {Model}.ToProto() and {Model}FromProto() between model and message generated by protoc-gen-go into model package
 
 	// generate model global configuration
 	FieldNullable     bool // generate pointer when field is nullable
 	FieldCoverable    bool // generate pointer when field has default value, to fix problem zero value cannot be assign: https://gorm.io/docs/create.html#Default-Values
 	FieldSignable     bool // detect integer field's unsigned type, adjust generated data type
+	FieldSignMapped   bool // detect unsigned type for data type from WithDataTypeMap too, by default mapped data type is kept as is
+	FieldUnsignedPK   bool // generate unsigned Go type for auto increment primary key like gorm.Model, composite primary key is exempt
+	FieldBoolScanType bool // generate bool for tinyint/bit column reported with bool scan type by driver, by default mapped by type name
+	FieldViewNullable bool // infer nullable of view column from the only same named base table column(mysql 8.0.13+ and postgres supported), computed column stays nullable, outer joined column should be overridden, see WithViewNullableOverride
+	FieldNetType      bool // generate postgres network address column(inet, cidr, macaddr) as string with type tag kept, cast default normalized, e.g. '0.0.0.0/0'::cidr -> '0.0.0.0/0'
 	FieldWithIndexTag bool // generate with gorm index tag
 	FieldWithTypeTag  bool // generate with gorm column type tag
+	FieldWithCheckTag bool // generate with gorm check tag, read check constraints from db(mysql and postgres supported)
+	FieldOrdinalOrder bool // sort fields by column ordinal position for stable generation(mysql, postgres and sqlite supported)
+	FieldExampleTag   bool // generate OpenAPI example tag from {{example:value}} directive in column comment or literal default value, expression default skipped, directive is stripped from comment
+	FieldProtobufTag  bool // generate protobuf tag numbered by column ordinal position, e.g. protobuf:"bytes,2,opt,name=name", see WithProtobufFieldNumbers
+
+	FieldTimePrecisionTag  bool // generate fractional seconds precision of time column as precision tag, e.g. datetime(6) -> type:datetime;precision:6
+	FieldFloatPrecisionTag bool // generate precision and scale of float/double column as tags, e.g. double(16,4) -> type:double;precision:16;scale:4
+
+	FieldUniqueAsIndex bool // generate unique index as index:name,unique,priority:N instead of uniqueIndex:name,priority:N
+	FieldIndexDBOrder  bool // generate index tags of column in database reported index order instead of unique first then by name, unique index emitted as index:name,unique to keep order in one tag key
+
+	FieldIndexPrecedence IndexPrecedence // precedence between WithCompositeIndex declared and database reported index, default database wins
+	FieldIndexNameLimit  bool            // shorten index name exceeding identifier length limit to prefix + hash suffix
+	FieldIndexNameMaxLen int             // identifier length limit of index name, 0 means dialect default(mysql 64, postgres 63, oracle 30)
+	FieldIndexNormalize  bool            // normalize priorities of each index to start at 1, for drivers reporting 0-based sequence
+
+	FieldUUIDBinding string // binding rule(uuid, uuid4 etc.) of string field mapped from uuid/char(36) column, uuid rule declared in comment takes precedence
+
+	FieldBindingOmitempty  bool // generate binding omitempty for pointer field, binding declared required in comment is kept
+	FieldJSONDirective     bool // generate json tag from {{json:name}} directive in column comment, {{json:-}} means json:"-", directive is stripped from comment
+	FieldCheckDirective    bool // generate check tag from {{check:expr}} directive in column comment(postgres and mysql), {{check:~ 'pattern'}} matches column with regex, directive is stripped from comment
+	FieldDetectIdentity    bool // detect identity column(sqlserver) not reported as auto increment by driver, increment > 1 generates autoIncrementIncrement
+	FieldFullTextReadOnly  bool // generate read-only(->) permission tag for postgres full text search(tsvector) column
+	FieldReadOnlyGenerated bool // generate read-only(->) permission tag for generated column(mysql, postgres and sqlite supported)
+	FieldSkipZeroDefault   bool // skip default tag equal to the Go zero value of field type, e.g. default:0, default:'', default:false
+	FieldEnumDefault       bool // normalize enum default(member string or 1-based index) to quoted member string, e.g. default:'pending'
+
+	FieldBinaryDefault  BinaryDefaultMode  // how to generate default tag for binary(bytea/blob) column, default keep as is
+	FieldPointerDefault PointerDefaultMode // how to generate default tag for pointer field with default value, default keep as is
+	FieldJSONType       JSONType           // Go type of json column without data type mapping, default keep as is
+	FieldXMLType        XMLType            // Go type of xml column(sqlserver, postgres, oracle) without data type mapping, default keep as is, see WithXMLStructType
+	FieldArrayType      ArrayType          // Go type of postgres array column(e.g. text[]) without data type mapping, pq array types or []T with json serializer, default keep as is
+
+	FieldNullableStrategy NullableStrategy // Go type of nullable field when FieldNullable is on, default pointer
+	FieldNullableBool     NullableBoolMode // how to generate nullable bool column, *bool with or without default tag, default as other columns
+
+	FieldEmbeddedConflict EmbeddedConflictMode // how to handle table column field colliding with struct embedded by FieldEmbed(e.g. id of gorm.Model), default keep as is
+	FieldSharedPrimaryKey SharedPKMode         // how to generate primary key column which is also foreign key(one-to-one tables sharing primary key), read foreign keys from db(mysql, postgres and sqlite supported), default keep as is
+
+	WithAfterFindHook bool // generate empty AfterFind hook for model with transient(gorm:"-") fields
+	WithGoDefaultHook bool // generate BeforeCreate hook assigning Go-managed defaults(see WithGoDefault) to zero fields, all columns of model share one hook, user declared BeforeCreate is kept
+	WithModelColumns  bool // generate typed columns(gorm.io/gen/field) for each model, e.g. UserColumns.Name.Eq("x")
+	WithParamStructs  bool // generate create/update param structs for each model, e.g. UserCreateParam, UserUpdateParam
+	WithZeroValues    bool // generate zero value of each model field, typed constant for basic types and var for others, e.g. UserZeroName
+	WithModelRegistry bool // generate AllModels() returning pointers to all generated models in models.gen.go, e.g. db.AutoMigrate(model.AllModels()...)
+
+	WithForeignKeyRelations bool // generate association fields inferred from foreign keys between generated tables(mysql, postgres and sqlite supported), belongs-to on referencing model, has-one(unique foreign key) or has-many on referenced model
+
+	WithUpdatableColumns bool // generate updatable column names of each model for partial update, e.g. db.Select(UserUpdatableColumns).Updates(&user), primary key, read-only, generated and auto-managed columns excluded
+
+	WithFunctionalIndexes bool // generate FunctionalIndexes() returning DDL of functional(expression) indexes lost in field tags, e.g. CREATE INDEX ON users (lower(email)), only mysql(8.0.13+) and postgres are supported
+
+	WithTableCommentDoc bool // generate model doc comment from full table comment(multiline supported, {{...}} directives stripped)
+	WithDocCommentName  bool // prefix model doc comment generated by WithTableCommentDoc with struct name(godoc convention) unless it already starts with it
+	WithSchemaTableName bool // generate TableName() returning schema qualified table name, e.g. sales.orders, default schema(public, dbo, main) omitted
+
+	WithValidateMethod bool // generate Validate method(go-playground/validator) for model with validate or binding tag, validators are shared in validators.gen.go
+	WithIndexesMethod  bool // generate Indexes() []field.IndexDef returning name, ordered columns, uniqueness and type of each index, structured alternative to parsing gorm tags
+	WithCloneMethod    bool // generate Clone() deep copy method, pointer, slice and map fields are copied one level deep, custom types are shallow copied
+	WithSoftDeletable  bool // generate IsSoftDeletable() bool marker method for model with soft delete field(gorm.DeletedAt or soft_delete.DeletedAt, custom named column included)
+	WithModelInterface bool // generate getter interface and getters of each model for mocking, e.g. UserGetter with GetName() string
+
+	WithEnumType    bool // generate named type and value consts for enum(set) columns, e.g. users.status => UsersStatus with UsersStatusActive, declared in enums.gen.go, integer column annotated by [[enum:1=Active,2=Disabled]] in comment is int backed, see WithFieldEnumType for table model
+	WithEnumScanner bool // generate sql.Scanner/driver.Valuer for enum columns mapped to named types, declared once in enums.gen.go
+	WithEnumDefault bool // generate New{Model}() initializing enum fields with typed consts of column defaults, e.g. Status: UsersStatusActive, requires WithEnumType, default matching no member is warned
+	WithEnumInteger bool // generate int backed enum type for enum with all integer values(e.g. enum('0','1','2')), converted to/from string in database
+	WithEnumText    bool // generate encoding.TextMarshaler/TextUnmarshaler for enum types generated by WithEnumScanner
 
 	Mode GenerateMode // generate mode
 
@@ -54,8 +322,58 @@ type Config struct {
 	modelNameNS func(tableName string) (modelName string)
 	fileNameNS  func(tableName string) (fileName string)
 
-	dataTypeMap    map[string]func(columnType gorm.ColumnType) (dataType string)
-	fieldJSONTagNS func(columnName string) (tagContent string)
+	modelFileGroupNS func(tableName string) (fileName string)
+	tableSchemaNS    func(tableName string) (schemaName string)
+
+	modelBuildTags map[string]string
+	fieldTemplate  string
+
+	templateOverrides map[string]string
+
+	dataTypeMap     map[string]func(columnType gorm.ColumnType) (dataType string)
+	typeTagOverride map[string]string
+	columnName      map[string]string
+	autoIncrement   map[string]bool
+	viewNullable    map[string]bool
+	goDefaults      map[string]string
+	typeTagDialect  string
+	emptyDefault    map[string]EmptyDefaultMode
+	gormTagOrder    []string
+	fieldJSONTagNS  func(columnName string) (tagContent string)
+	fieldTagNS      map[string]func(columnName string) (tagContent string)
+	tagOmitempty    map[string]model.OmitemptyPolicy
+	tagGenerators   []model.TagGenerator
+	commentSource   func(table, column string) (comment string, ok bool)
+	commentFallback func(table, column, fieldName string) (comment string)
+
+	nullableSelector model.NullableStrategySelector
+	protobufNumbers  func(table, column string) (number int, ok bool)
+
+	typePointerPolicy   map[string]PointerPolicy
+	columnPointerPolicy map[string]PointerPolicy
+
+	utcTimeSerializer string
+	utcTimeDialects   []string
+	timestampType     string
+	timestampColumns  []string
+	unixTimeRules     []model.UnixTimeRule
+	readOnlyColumns   []string
+	triggerColumns    []string
+	jsonArrayColumns  []string
+	jsonStructTypes   map[string]string
+	xmlStructType     string
+	yesNoColumns      []string
+	numericBoolNames  []string
+
+	compositeIndexes []*model.CompositeIndex
+
+	unsignedDecimal     bool
+	unsignedDecimalType string
+
+	bigIntDecimal     bool
+	bigIntDecimalType string
+
+	entityInterface *model.EntityInterface
 
 	modelOpts []ModelOpt
 }
@@ -93,16 +411,298 @@ func (cfg *Config) WithFileNameStrategy(ns func(tableName string) (fileName stri
 	cfg.fileNameNS = ns
 }
 
+// WithTableSchemaNS specify schema of table used by WithSchemaTableName, by default current schema(postgres, sqlserver)
+// or database(WithDbNameOpts or current database) is used
+func (cfg *Config) WithTableSchemaNS(ns func(tableName string) (schemaName string)) {
+	cfg.tableSchemaNS = ns
+}
+
+// WithModelFileGroup specify file name of model group, models in the same group are generated into one file,
+// empty file name means model has its own file, e.g. group by table name prefix
+func (cfg *Config) WithModelFileGroup(ns func(tableName string) (fileName string)) {
+	cfg.modelFileGroupNS = ns
+}
+
+// WithModelFileBuildTags specify build constraint of model files, key is file name returned by WithModelFileGroup
+// (or model file name when not grouped), value is constraint expression, e.g. {"fixture": "integration"}
+func (cfg *Config) WithModelFileBuildTags(tags map[string]string) {
+	cfg.modelBuildTags = tags
+}
+
+// WithFieldTemplate specify text/template to render each field line of model struct, template is executed with
+// *model.Field (Name, Type, Tags, ColumnComment...), empty output falls back to built-in rendering
+func (cfg *Config) WithFieldTemplate(text string) {
+	cfg.fieldTemplate = text
+}
+
+// WithTemplateOverride replace built-in template of generated code by name, e.g. Header(query file header),
+// ModelHeader(model file header), ModelStruct, CRUDMethod, see Overridable of internal/template for all names,
+// override is text/template executed with the same data as built-in one, unknown name fails generation
+func (cfg *Config) WithTemplateOverride(name string, text string) {
+	if cfg.templateOverrides == nil {
+		cfg.templateOverrides = make(map[string]string)
+	}
+	cfg.templateOverrides[name] = text
+}
+
+// WithEntityInterface generate ID getter of models implementing interface(e.g. repo.Entity with GetID() int64) and
+// compile-time assertion var _ repo.Entity = (*User)(nil), ID field is single primary key or column specified by IDColumns,
+// model without detectable ID field(e.g. composite primary key) is skipped with warning.
+// Getter of same name generated by WithModelInterface is replaced by the ID getter
+func (cfg *Config) WithEntityInterface(spec EntityInterface, importPath string) {
+	cfg.entityInterface = &spec
+	if importPath != "" {
+		cfg.WithImportPkgPath(importPath)
+	}
+}
+
 // WithDataTypeMap specify data type mapping relationship, only work when syncing table from db
 func (cfg *Config) WithDataTypeMap(newMap map[string]func(columnType gorm.ColumnType) (dataType string)) {
 	cfg.dataTypeMap = newMap
 }
 
+// WithColumnTypeTagOverride specify gorm type tag of column(table.column), e.g. "users.bio": "text",
+// empty value means omit type tag. Go type mapping is not affected, see WithDataTypeMap
+func (cfg *Config) WithColumnTypeTagOverride(overrides map[string]string) {
+	cfg.typeTagOverride = overrides
+}
+
+// WithEmptyDefaultMode specify how to generate empty or whitespace-only string default of column(table.column),
+// e.g. "users.nickname": EmptyDefaultDrop. Column not specified keeps quoted default:”
+func (cfg *Config) WithEmptyDefaultMode(modes map[string]EmptyDefaultMode) {
+	cfg.emptyDefault = modes
+}
+
+// WithPointerPolicy specify whether generate pointer per Go type(e.g. "string", "time.Time") or type family
+// (TypeFamilyNumber, TypeFamilyCustom), overriding FieldNullable and FieldCoverable, Go type takes precedence over its family,
+// e.g. {"string": PointerPolicyValue, "time.Time": PointerPolicyNullable, TypeFamilyCustom: PointerPolicyAlways}
+func (cfg *Config) WithPointerPolicy(types map[string]PointerPolicy) {
+	cfg.typePointerPolicy = types
+}
+
+// WithColumnPointerPolicy specify whether generate pointer per column(table.column), taking precedence over WithPointerPolicy
+func (cfg *Config) WithColumnPointerPolicy(columns map[string]PointerPolicy) {
+	cfg.columnPointerPolicy = columns
+}
+
+// WithGormTagOrder specify gorm tag key order, e.g. field.GormTagOrderV2 to match tags declared in GORM documented order,
+// keys not specified follow default order
+func (cfg *Config) WithGormTagOrder(order []string) {
+	cfg.gormTagOrder = order
+}
+
+// WithAutoIncrementOverride override autoIncrement of column(table.column) reported by database, e.g.
+// "orders.id": false for application assigned(snowflake) id, "orders.seq_no": true for sequence backed column,
+// default tag of column overridden to true is dropped since value is generated by database
+func (cfg *Config) WithAutoIncrementOverride(overrides map[string]bool) {
+	cfg.autoIncrement = overrides
+}
+
+// WithViewNullableOverride override nullable of view column(view.column) inferred or reported by database, e.g.
+// "active_users.email": false for column not null in base table, "order_details.paid_at": true for outer joined column
+func (cfg *Config) WithViewNullableOverride(overrides map[string]bool) {
+	cfg.viewNullable = overrides
+}
+
+// WithGoDefault declare default of columns(table.column) managed by app instead of database, default tag is suppressed,
+// e.g. "users.uuid": "uuid.NewString()", expression is assigned in BeforeCreate hook generated by WithGoDefaultHook,
+// empty expression only suppresses default tag, import expression's package by WithImportPkgPath
+func (cfg *Config) WithGoDefault(defaults map[string]string) {
+	cfg.goDefaults = defaults
+}
+
+// WithColumnNameOverride specify gorm column tag of column(table.column), e.g. "users.user_name": "UserName"
+// to keep physical name mapped by legacy ORM, field name and json tag still follow database column name
+func (cfg *Config) WithColumnNameOverride(overrides map[string]string) {
+	cfg.columnName = overrides
+}
+
+// WithTypeTagDialect translate gorm type tag from source database's dialect to target dialect(e.g. mysql -> postgres),
+// type without equivalent is passed through with warning, see model.TypeTagTranslations for built-in translations
+func (cfg *Config) WithTypeTagDialect(dialect string) {
+	cfg.typeTagDialect = dialect
+}
+
+// WithCommentSource specify column comment source used when driver's comment is empty,
+// e.g. read comments by your own query for drivers omitting them. Binding and directives in comment are extracted as well
+func (cfg *Config) WithCommentSource(source func(table, column string) (comment string, ok bool)) {
+	cfg.commentSource = source
+}
+
+// WithCommentFallback specify gorm comment tag of column without comment, e.g. derived from field name,
+// so AutoMigrate always writes column comment. Empty fallback means no comment tag, real comment is never replaced
+func (cfg *Config) WithCommentFallback(fallback func(table, column, fieldName string) (comment string)) {
+	cfg.commentFallback = fallback
+}
+
+// WithNullableStrategySelector specify nullable strategy per column overriding FieldNullableStrategy,
+// e.g. pointer for json column while others are sql.Null*. Return false to use FieldNullableStrategy
+func (cfg *Config) WithNullableStrategySelector(selector func(table string, columnType gorm.ColumnType, goType string) (strategy NullableStrategy, ok bool)) {
+	cfg.nullableSelector = selector
+}
+
+// WithProtobufFieldNumbers specify protobuf field numbers used by FieldProtobufTag, e.g. read from persisted mapping,
+// return false to number by column ordinal position
+func (cfg *Config) WithProtobufFieldNumbers(numbers func(table, column string) (number int, ok bool)) {
+	cfg.protobufNumbers = numbers
+}
+
+// WithUTCTimeSerializer specify serializer for time column without zone info(timestamptz etc. is exempt),
+// only work for specified dialects when dialects is not empty, only work when syncing table from db
+// eg: cfg.WithUTCTimeSerializer(field.UTCTimeSerializerName, "mysql")
+func (cfg *Config) WithUTCTimeSerializer(serializer string, dialects ...string) {
+	cfg.utcTimeSerializer, cfg.utcTimeDialects = serializer, dialects
+}
+
+// WithTimestampType map audit time columns(table.column, path.Match syntax) to custom time type, e.g.
+// cfg.WithTimestampType("types.Timestamp", "example.com/app/types"), default columns are *.created_at and *.updated_at,
+// autoCreateTime/autoUpdateTime tags are generated for created_at/updated_at, deleted_at keeps gorm.DeletedAt unless specified
+func (cfg *Config) WithTimestampType(goType string, importPath string, columns ...string) {
+	if len(columns) == 0 {
+		columns = []string{"*.created_at", "*.updated_at"}
+	}
+	cfg.timestampType, cfg.timestampColumns = goType, columns
+	if importPath != "" {
+		cfg.WithImportPkgPath(importPath)
+	}
+}
+
+// WithXMLStructType map xml columns to struct type marshaled by encoding/xml, e.g.
+// cfg.WithXMLStructType("types.Invoice", "example.com/app/types"), gorm type tag is kept,
+// register field.XMLSerializer before use: schema.RegisterSerializer(field.XMLSerializerName, field.XMLSerializer{})
+func (cfg *Config) WithXMLStructType(goType string, importPath string) {
+	cfg.xmlStructType = goType
+	if importPath != "" {
+		cfg.WithImportPkgPath(importPath)
+	}
+}
+
+// WithYesNoColumns map single character columns(char(1)/nchar(1)) storing 'Y'/'N' to bool with yesno serializer,
+// columns(table.column, path.Match syntax) default all single character columns, NULL maps to *bool when FieldNullable is on,
+// register field.YesNoSerializer before use: schema.RegisterSerializer(field.YesNoSerializerName, field.YesNoSerializer{})
+func (cfg *Config) WithYesNoColumns(columns ...string) {
+	if len(columns) == 0 {
+		columns = []string{"*.*"}
+	}
+	cfg.yesNoColumns = columns
+}
+
+// WithNumericBool map zero scale single digit numeric columns(numeric(1,0), Oracle NUMBER(1,0)) used as boolean to bool,
+// names(column name, path.Match syntax, e.g. "is_*", "has_*") tell booleans from small counters, default all such columns,
+// NULL maps to *bool when FieldNullable is on
+func (cfg *Config) WithNumericBool(names ...string) {
+	if len(names) == 0 {
+		names = []string{"*"}
+	}
+	cfg.numericBoolNames = names
+}
+
+// WithUnixTimeColumns map integer columns matching name pattern(e.g. "*_at", "*_time") to time.Time with unix serializer,
+// register field.UnixTimeSerializer for UnixTimeMilli and UnixTimeNano before use, only work when syncing table from db
+func (cfg *Config) WithUnixTimeColumns(pattern string, precision UnixTimePrecision) {
+	cfg.unixTimeRules = append(cfg.unixTimeRules, model.UnixTimeRule{Pattern: pattern, Precision: precision})
+}
+
+// WithReadOnlyColumns specify database managed columns(table.column, path.Match syntax, e.g. "*.search_vector"),
+// generated with read-only(->) permission tag, not null and default tags are dropped
+func (cfg *Config) WithReadOnlyColumns(columns ...string) {
+	cfg.readOnlyColumns = append(cfg.readOnlyColumns, columns...)
+}
+
+// WithTriggerColumns specify columns(table.column, path.Match syntax) written only by database triggers,
+// generated with ->;<-:false permission tags, readable but never written by app
+func (cfg *Config) WithTriggerColumns(columns ...string) {
+	cfg.triggerColumns = append(cfg.triggerColumns, columns...)
+}
+
+// WithCompositeIndex declare composite index on ordered columns, generated as index:,composite:name,priority:N,
+// tables specify tables the index applied to, empty means all tables containing every column
+func (cfg *Config) WithCompositeIndex(name string, columns []string, tables ...string) {
+	cfg.compositeIndexes = append(cfg.compositeIndexes, &model.CompositeIndex{IndexName: name, ColumnList: columns, TableNames: tables})
+}
+
+// WithUnsignedDecimal specify handling of unsigned decimal column(e.g. mysql decimal(10,2) unsigned),
+// empty typ adds gte=0 binding to numeric field, otherwise field type is replaced by typ, e.g. "types.UDecimal".
+// By default unsigned decimal is generated as signed and unsigned is kept in type tag only
+func (cfg *Config) WithUnsignedDecimal(typ string) {
+	cfg.unsignedDecimal, cfg.unsignedDecimalType = true, typ
+}
+
+// WithBigIntDecimal map zero scale decimal overflowing int64(precision > 18, e.g. decimal(38,0)) and postgres numeric
+// without precision to typ, empty typ means *big.Int with field.BigIntSerializer, e.g. "decimal.Decimal" with importPath
+// "github.com/shopspring/decimal", gorm type tag is kept. By default they are generated as other decimal columns,
+// register field.BigIntSerializer before use: schema.RegisterSerializer(field.BigIntSerializerName, field.BigIntSerializer{})
+func (cfg *Config) WithBigIntDecimal(typ string, importPath string) {
+	cfg.bigIntDecimal, cfg.bigIntDecimalType = true, typ
+	if importPath != "" {
+		cfg.WithImportPkgPath(importPath)
+	}
+}
+
+// WithJSONArrayColumns specify json array columns(table.column) mapped to []interface{} when FieldJSONType is JSONTypeMap,
+// json column with array default value(e.g. '[]') is detected automatically
+func (cfg *Config) WithJSONArrayColumns(columns ...string) {
+	cfg.jsonArrayColumns = append(cfg.jsonArrayColumns, columns...)
+}
+
+// WithJSONStructType map json column(table.column) to struct type with json serializer regardless of FieldJSONType,
+// e.g. cfg.WithJSONStructType("users.address", "types.Address", "example.com/app/types"),
+// NULL maps to *types.Address when FieldNullable is on, otherwise types.Address
+func (cfg *Config) WithJSONStructType(column string, goType string, importPath string) {
+	if cfg.jsonStructTypes == nil {
+		cfg.jsonStructTypes = make(map[string]string)
+	}
+	cfg.jsonStructTypes[column] = goType
+	if importPath != "" {
+		cfg.WithImportPkgPath(importPath)
+	}
+}
+
 // WithJSONTagNameStrategy specify json tag naming strategy
 func (cfg *Config) WithJSONTagNameStrategy(ns func(columnName string) (tagContent string)) {
 	cfg.fieldJSONTagNS = ns
 }
 
+// WithTagNameStrategy specify additional tag(e.g. redis, yaml, form) and its naming strategy,
+// tag content "-" excludes field, empty content means no tag
+// eg: cfg.WithTagNameStrategy("redis", gen.TagNameStrategy("snake"))
+func (cfg *Config) WithTagNameStrategy(tagKey string, ns func(columnName string) (tagContent string)) {
+	if cfg.fieldTagNS == nil {
+		cfg.fieldTagNS = make(map[string]func(columnName string) (tagContent string))
+	}
+	cfg.fieldTagNS[tagKey] = ns
+}
+
+// WithTagOmitempty specify when to append omitempty option to tag(e.g. json, form, binding), policy is called with
+// Go type of field, eg: cfg.WithTagOmitempty("json", gen.OmitemptyNullable), cfg.WithTagOmitempty("form", gen.OmitemptyAlways).
+// Tag content "-" never gets options, nil policy removes the tag's policy
+func (cfg *Config) WithTagOmitempty(tagKey string, policy func(table string, columnType gorm.ColumnType, goType string) bool) {
+	if cfg.tagOmitempty == nil {
+		cfg.tagOmitempty = make(map[string]model.OmitemptyPolicy)
+	}
+	if policy == nil {
+		delete(cfg.tagOmitempty, tagKey)
+		return
+	}
+	cfg.tagOmitempty[tagKey] = policy
+}
+
+// WithTagGenerators specify generators of additional tags(e.g. validate, xml, company tags) based on column metadata
+// like type, nullability, length and comment, called with generated field of each table column in order after built-in
+// tags(json, binding etc.) are built. Returned tags are merged into field tags, later generator wins, empty content
+// removes the tag, gorm tag is not affected(see FieldGORMTag). Tags keep the built-in order, unknown keys sorted by key
+// eg: cfg.WithTagGenerators(func(col gorm.ColumnType, f gen.Field) map[string]string { return map[string]string{"xml": f.ColumnName} })
+func (cfg *Config) WithTagGenerators(generators ...model.TagGenerator) {
+	cfg.tagGenerators = append(cfg.tagGenerators, generators...)
+}
+
+// TagNameStrategy get built-in tag name strategy by name: snake, camel, pascal, kebab,
+// return nil(use column name) if name is unknown
+// eg: cfg.WithJSONTagNameStrategy(gen.TagNameStrategy("camel"))
+func TagNameStrategy(name string) func(columnName string) (tagContent string) {
+	return model.TagNameStrategies[strings.ToLower(name)]
+}
+
 // WithImportPkgPath specify import package path
 func (cfg *Config) WithImportPkgPath(paths ...string) {
 	for i, path := range paths {
@@ -135,6 +735,16 @@ func (cfg *Config) Revise() (err error) {
 	}
 	cfg.queryPkgName = filepath.Base(cfg.OutPath)
 
+	if cfg.ProtoOutPath == "" {
+		cfg.ProtoOutPath = filepath.Join(filepath.Dir(cfg.OutPath), "proto")
+	}
+	if cfg.ProtoPackage == "" {
+		cfg.ProtoPackage = filepath.Base(cfg.ProtoOutPath)
+	}
+	if cfg.WithProtoConverters && cfg.ProtoGoPackage == "" {
+		return fmt.Errorf("proto go package is required by WithProtoConverters")
+	}
+
 	if cfg.db == nil {
 		cfg.db, _ = gorm.Open(tests.DummyDialector{})
 	}
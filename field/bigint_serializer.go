@@ -0,0 +1,59 @@
+package field
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+// BigIntSerializerName name of BigIntSerializer used in gorm serializer tag
+const BigIntSerializerName = "bigint"
+
+// BigIntSerializer convert zero scale decimal(e.g. decimal(38,0), postgres numeric) overflowing int64 to *big.Int,
+// register it before use: schema.RegisterSerializer(field.BigIntSerializerName, field.BigIntSerializer{})
+type BigIntSerializer struct{}
+
+// Scan implements serializer interface, NULL leaves field nil
+func (BigIntSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) (err error) {
+	if dbValue == nil {
+		return nil
+	}
+
+	var value string
+	switch v := dbValue.(type) {
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	case int64:
+		value = fmt.Sprint(v)
+	default:
+		return fmt.Errorf("unsupported data %#v for big int", dbValue)
+	}
+	if i := strings.IndexByte(value, '.'); i >= 0 && strings.Trim(value[i+1:], "0") == "" { // e.g. 12.000 of numeric without scale
+		value = value[:i]
+	}
+	n, ok := new(big.Int).SetString(strings.TrimSpace(value), 10)
+	if !ok {
+		return fmt.Errorf("invalid big int value %q", value)
+	}
+	field.ReflectValueOf(ctx, dst).Set(reflect.ValueOf(n))
+	return nil
+}
+
+// Value implements serializer interface, nil is stored as NULL
+func (BigIntSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	switch v := fieldValue.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, nil
+		}
+		return v.String(), nil
+	default:
+		return nil, fmt.Errorf("invalid field type %#v for big int", fieldValue)
+	}
+}
@@ -0,0 +1,16 @@
+package field
+
+// index types of IndexDef
+const (
+	IndexTypePrimaryKey = "PRIMARY KEY"
+	IndexTypeUnique     = "UNIQUE"
+	IndexTypeIndex      = "INDEX"
+)
+
+// IndexDef index definition of model returned by generated Indexes() method
+type IndexDef struct {
+	Name    string   // index name reported by database or declared in config
+	Columns []string // columns ordered by priority
+	Unique  bool     // unique index or primary key
+	Type    string   // IndexTypePrimaryKey, IndexTypeUnique or IndexTypeIndex
+}
@@ -6,13 +6,18 @@ import (
 )
 
 const (
-	TagKeyGorm    = "gorm"
-	TagKeyJson    = "json"
-	TagKeyBinding = "binding"
+	TagKeyGorm     = "gorm"
+	TagKeyJson     = "json"
+	TagKeyBinding  = "binding"
+	TagKeyValidate = "validate"
+	TagKeyProtobuf = "protobuf"
+	TagKeyExample  = "example"
 
 	//gorm tag
 	TagKeyGormColumn        = "column"
 	TagKeyGormType          = "type"
+	TagKeyGormPrecision     = "precision"
+	TagKeyGormScale         = "scale"
 	TagKeyGormPrimaryKey    = "primaryKey"
 	TagKeyGormAutoIncrement = "autoIncrement"
 	TagKeyGormNotNull       = "not null"
@@ -20,26 +25,71 @@ const (
 	TagKeyGormIndex         = "index"
 	TagKeyGormDefault       = "default"
 	TagKeyGormComment       = "comment"
+	TagKeyGormSerializer    = "serializer"
+	TagKeyGormCheck         = "check"
+	TagKeyGormReadOnly      = "->"
+	TagKeyGormWrite         = "<-"
+	TagKeyGormForeignKey    = "foreignKey"
+	TagKeyGormReferences    = "references"
+
+	TagKeyGormAutoIncrementIncrement = "autoIncrementIncrement"
+	TagKeyGormAutoCreateTime         = "autoCreateTime"
+	TagKeyGormAutoUpdateTime         = "autoUpdateTime"
 )
 
 var (
 	tagKeyPriorities = map[string]int16{
-		TagKeyGorm:    100,
-		TagKeyJson:    99,
-		TagKeyBinding: 98,
+		TagKeyGorm:     100,
+		TagKeyJson:     99,
+		TagKeyBinding:  98,
+		TagKeyValidate: 97,
+		TagKeyProtobuf: 96,
+		TagKeyExample:  95,
 
 		TagKeyGormColumn:        10,
 		TagKeyGormType:          9,
+		TagKeyGormPrecision:     8,
+		TagKeyGormScale:         8,
 		TagKeyGormPrimaryKey:    8,
 		TagKeyGormAutoIncrement: 7,
 		TagKeyGormNotNull:       6,
 		TagKeyGormUniqueIndex:   5,
 		TagKeyGormIndex:         4,
 		TagKeyGormDefault:       3,
+		TagKeyGormSerializer:    2,
+		TagKeyGormCheck:         1,
+		TagKeyGormReadOnly:      1,
+		TagKeyGormWrite:         1,
 		TagKeyGormComment:       0,
+
+		TagKeyGormAutoIncrementIncrement: 7,
+		TagKeyGormAutoCreateTime:         2,
+		TagKeyGormAutoUpdateTime:         2,
 	}
 )
 
+// GormTagOrderV2 gorm tag option order of GORM v2 field tags documentation, used to compare with GORM declared tags
+var GormTagOrderV2 = []string{
+	TagKeyGormColumn,
+	TagKeyGormType,
+	TagKeyGormSerializer,
+	TagKeyGormPrimaryKey,
+	TagKeyGormDefault,
+	TagKeyGormPrecision,
+	TagKeyGormScale,
+	TagKeyGormNotNull,
+	TagKeyGormAutoIncrement,
+	TagKeyGormAutoIncrementIncrement,
+	TagKeyGormAutoCreateTime,
+	TagKeyGormAutoUpdateTime,
+	TagKeyGormIndex,
+	TagKeyGormUniqueIndex,
+	TagKeyGormCheck,
+	TagKeyGormWrite,
+	TagKeyGormReadOnly,
+	TagKeyGormComment,
+}
+
 type TagBuilder interface {
 	Build() string
 }
@@ -94,11 +144,16 @@ func (tag GormTag) Remove(key string) GormTag {
 }
 
 func (tag GormTag) Build() string {
+	return tag.BuildWithOrder(nil)
+}
+
+// BuildWithOrder build tag with keys in order first, keys not in order follow by default priority
+func (tag GormTag) BuildWithOrder(order []string) string {
 	if tag == nil || len(tag) == 0 {
 		return ""
 	}
 	tags := make([]string, 0, len(tag))
-	for _, k := range gormKeys(tag) {
+	for _, k := range gormKeys(tag, order) {
 		vs := tag[k]
 		if len(vs) == 0 && k == "" {
 			continue
@@ -136,7 +191,7 @@ func tagKeys(tag Tag) []string {
 	return keySort(keys)
 }
 
-func gormKeys(tag GormTag) []string {
+func gormKeys(tag GormTag, order []string) []string {
 	keys := make([]string, 0, len(tag))
 	if len(tag) == 0 {
 		return keys
@@ -144,7 +199,26 @@ func gormKeys(tag GormTag) []string {
 	for k, _ := range tag {
 		keys = append(keys, k)
 	}
-	return keySort(keys)
+	keys = keySort(keys)
+	if len(order) == 0 {
+		return keys
+	}
+
+	rank := make(map[string]int, len(order))
+	for i, k := range order {
+		if _, ok := rank[k]; !ok {
+			rank[k] = i
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		ri, iok := rank[keys[i]]
+		rj, jok := rank[keys[j]]
+		if iok && jok {
+			return ri < rj
+		}
+		return iok && !jok
+	})
+	return keys
 }
 
 func keySort(keys []string) []string {
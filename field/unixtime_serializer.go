@@ -0,0 +1,85 @@
+package field
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// UnixMilliSerializerName name of UnixTimeSerializer for unix milliseconds used in gorm serializer tag
+	UnixMilliSerializerName = "unixmilli"
+	// UnixNanoSerializerName name of UnixTimeSerializer for unix nanoseconds used in gorm serializer tag
+	UnixNanoSerializerName = "unixnano"
+)
+
+// UnixTimeSerializer convert integer unix timestamp of Unit precision stored in database to time.Time,
+// seconds precision is supported by gorm's built-in unixtime serializer,
+// register it before use: schema.RegisterSerializer(field.UnixMilliSerializerName, field.UnixTimeSerializer{Unit: time.Millisecond})
+type UnixTimeSerializer struct {
+	Unit time.Duration
+}
+
+// Scan implements serializer interface
+func (s UnixTimeSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) (err error) {
+	if dbValue == nil {
+		return nil
+	}
+
+	var ts int64
+	switch v := dbValue.(type) {
+	case int64:
+		ts = v
+	case int:
+		ts = int64(v)
+	case int32:
+		ts = int64(v)
+	case uint64:
+		ts = int64(v)
+	case uint32:
+		ts = int64(v)
+	case []byte:
+		ts, err = strconv.ParseInt(string(v), 10, 64)
+	case string:
+		ts, err = strconv.ParseInt(v, 10, 64)
+	default:
+		err = fmt.Errorf("unsupported data %#v for unix time", dbValue)
+	}
+	if err != nil {
+		return err
+	}
+
+	t := time.Unix(0, ts*int64(s.unit()))
+	fieldValue := reflect.ValueOf(t)
+	if field.FieldType.Kind() == reflect.Ptr {
+		fieldValue = reflect.ValueOf(&t)
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+// Value implements serializer interface
+func (s UnixTimeSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	switch v := fieldValue.(type) {
+	case time.Time:
+		return v.UnixNano() / int64(s.unit()), nil
+	case *time.Time:
+		if v == nil {
+			return nil, nil
+		}
+		return v.UnixNano() / int64(s.unit()), nil
+	default:
+		return nil, fmt.Errorf("invalid field type %#v for unix time", fieldValue)
+	}
+}
+
+func (s UnixTimeSerializer) unit() time.Duration {
+	if s.Unit <= 0 {
+		return time.Second
+	}
+	return s.Unit
+}
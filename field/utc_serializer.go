@@ -0,0 +1,77 @@
+package field
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// UTCTimeSerializerName name of UTCTimeSerializer used in gorm serializer tag
+const UTCTimeSerializerName = "utc"
+
+var utcTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+// UTCTimeSerializer treat naive(without zone) time stored in database as UTC time,
+// register it before use: schema.RegisterSerializer(field.UTCTimeSerializerName, field.UTCTimeSerializer{})
+type UTCTimeSerializer struct{}
+
+// Scan implements serializer interface, wall clock read from database is interpreted as UTC
+func (UTCTimeSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) (err error) {
+	if dbValue == nil {
+		return nil
+	}
+
+	var t time.Time
+	switch v := dbValue.(type) {
+	case time.Time:
+		t = time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), time.UTC)
+	case []byte:
+		t, err = parseUTCTime(string(v))
+	case string:
+		t, err = parseUTCTime(v)
+	default:
+		err = fmt.Errorf("unsupported data %#v for utc time", dbValue)
+	}
+	if err != nil {
+		return err
+	}
+
+	fieldValue := reflect.ValueOf(t)
+	if field.FieldType.Kind() == reflect.Ptr {
+		fieldValue = reflect.ValueOf(&t)
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+// Value implements serializer interface, save UTC wall clock without zone
+func (UTCTimeSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	switch v := fieldValue.(type) {
+	case time.Time:
+		return v.UTC().Format(utcTimeLayouts[0]), nil
+	case *time.Time:
+		if v == nil {
+			return nil, nil
+		}
+		return v.UTC().Format(utcTimeLayouts[0]), nil
+	default:
+		return nil, fmt.Errorf("invalid field type %#v for utc time", fieldValue)
+	}
+}
+
+func parseUTCTime(value string) (t time.Time, err error) {
+	for _, layout := range utcTimeLayouts {
+		if t, err = time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return t, err
+}
@@ -0,0 +1,49 @@
+package field
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+func TestUTCTimeSerializer(t *testing.T) {
+	type user struct {
+		CreatedAt time.Time  `gorm:"serializer:utc"`
+		DeletedAt *time.Time `gorm:"serializer:utc"`
+	}
+	schema.RegisterSerializer(UTCTimeSerializerName, UTCTimeSerializer{})
+	s, err := schema.Parse(&user{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("parse schema fail: %s", err)
+	}
+	ctx := context.Background()
+	expected := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	local := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.FixedZone("UTC+8", 8*3600))
+
+	for _, dbValue := range []interface{}{local, "2024-01-02 03:04:05.000006", []byte("2024-01-02T03:04:05.000006")} {
+		var u user
+		for _, f := range s.Fields {
+			if err = f.Serializer.Scan(ctx, f, reflect.ValueOf(&u), dbValue); err != nil {
+				t.Fatalf("scan %#v fail: %s", dbValue, err)
+			}
+		}
+		if !u.CreatedAt.Equal(expected) || u.CreatedAt.Location() != time.UTC || u.DeletedAt == nil || !u.DeletedAt.Equal(expected) {
+			t.Errorf("scan %#v expect wall clock as utc: %s, got: %s %v", dbValue, expected, u.CreatedAt, u.DeletedAt)
+		}
+	}
+
+	var u user
+	if err = s.FieldsByName["DeletedAt"].Serializer.Scan(ctx, s.FieldsByName["DeletedAt"], reflect.ValueOf(&u), nil); err != nil || u.DeletedAt != nil {
+		t.Errorf("scan null expect nil, got: %v %s", u.DeletedAt, err)
+	}
+	if value, err := (UTCTimeSerializer{}).Value(ctx, nil, reflect.Value{}, local.Add(8*time.Hour)); err != nil || value != "2024-01-02 03:04:05.000006" {
+		t.Errorf("value expect utc wall clock, got: %v %v", value, err)
+	}
+	if value, err := (UTCTimeSerializer{}).Value(ctx, nil, reflect.Value{}, (*time.Time)(nil)); err != nil || value != nil {
+		t.Errorf("value of nil expect nil, got: %v %v", value, err)
+	}
+}
@@ -0,0 +1,49 @@
+package field
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+// XMLSerializerName name of XMLSerializer used in gorm serializer tag
+const XMLSerializerName = "xml"
+
+// XMLSerializer marshal struct to xml document stored in xml column with encoding/xml,
+// register it before use: schema.RegisterSerializer(field.XMLSerializerName, field.XMLSerializer{})
+type XMLSerializer struct{}
+
+// Scan implements serializer interface, NULL and empty document leave field zero value
+func (XMLSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) (err error) {
+	fieldValue := reflect.New(field.FieldType)
+	if dbValue != nil {
+		var data []byte
+		switch v := dbValue.(type) {
+		case []byte:
+			data = v
+		case string:
+			data = []byte(v)
+		default:
+			return fmt.Errorf("failed to unmarshal xml value: %#v", dbValue)
+		}
+		if len(data) > 0 {
+			if err = xml.Unmarshal(data, fieldValue.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+// Value implements serializer interface, nil pointer is stored as NULL
+func (XMLSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	if rv := reflect.ValueOf(fieldValue); fieldValue == nil || rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, nil
+	}
+	data, err := xml.Marshal(fieldValue)
+	return string(data), err
+}
@@ -0,0 +1,88 @@
+package field
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+// YesNoSerializerName name of YesNoSerializer used in gorm serializer tag
+const YesNoSerializerName = "yesno"
+
+// YesNoSerializer convert 'Y'/'N' stored in char(1) column to bool, NULL leaves field zero value(false or nil pointer),
+// register it before use: schema.RegisterSerializer(field.YesNoSerializerName, field.YesNoSerializer{})
+type YesNoSerializer struct {
+	// Strict only 'Y' and 'N' are accepted, otherwise 'y'/'n', '1'/'0' and 'T'/'F' are accepted case-insensitively
+	// and other values are scanned as false
+	Strict bool
+}
+
+// Scan implements serializer interface
+func (s YesNoSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) (err error) {
+	if dbValue == nil {
+		return nil
+	}
+
+	var value string
+	switch v := dbValue.(type) {
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	default:
+		return fmt.Errorf("unsupported data %#v for yes/no", dbValue)
+	}
+
+	b, err := s.parse(strings.TrimSpace(value))
+	if err != nil {
+		return err
+	}
+	fieldValue := reflect.ValueOf(b)
+	if field.FieldType.Kind() == reflect.Ptr {
+		fieldValue = reflect.ValueOf(&b)
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+// Value implements serializer interface, nil pointer is stored as NULL
+func (s YesNoSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	switch v := fieldValue.(type) {
+	case bool:
+		return yesNo(v), nil
+	case *bool:
+		if v == nil {
+			return nil, nil
+		}
+		return yesNo(*v), nil
+	default:
+		return nil, fmt.Errorf("invalid field type %#v for yes/no", fieldValue)
+	}
+}
+
+func (s YesNoSerializer) parse(value string) (bool, error) {
+	switch {
+	case value == "Y":
+		return true, nil
+	case value == "N":
+		return false, nil
+	case s.Strict:
+		return false, fmt.Errorf("invalid yes/no value %q", value)
+	}
+	switch strings.ToUpper(value) {
+	case "Y", "1", "T":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Y"
+	}
+	return "N"
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 
 	"gorm.io/gen/field"
 	"gorm.io/gen/internal/generate"
@@ -36,6 +37,39 @@ var (
 			}
 		}
 	}
+	// FieldEmbed embed struct(e.g. gorm.Model{}) in model, anonymous if fieldName is empty, prefix is embeddedPrefix of
+	// columns, columns of embedded struct are recorded to detect collision with table columns, see Config.FieldEmbeddedConflict
+	FieldEmbed = func(fieldName string, embedded interface{}, prefix string) model.CreateFieldOpt {
+		st := reflect.TypeOf(embedded)
+		if st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		var columns []string
+		if s, err := schema.Parse(embedded, &sync.Map{}, ns); err == nil {
+			for _, f := range s.Fields {
+				if f.DBName != "" {
+					columns = append(columns, prefix+f.DBName)
+				}
+			}
+		}
+		gormTag := field.GormTag{}
+		if fieldName != "" || prefix != "" {
+			gormTag.Set("embedded")
+		}
+		if prefix != "" {
+			gormTag.Set("embeddedPrefix", prefix)
+		}
+
+		return func(*model.Field) *model.Field {
+			return &model.Field{
+				Name:            fieldName,
+				Type:            st.String(),
+				Tag:             field.Tag{},
+				GORMTag:         gormTag,
+				EmbeddedColumns: columns,
+			}
+		}
+	}
 	// FieldIgnore ignore some columns by name
 	FieldIgnore = func(columnNames ...string) model.FilterFieldOpt {
 		return func(m *model.Field) *model.Field {
@@ -256,6 +290,23 @@ var (
 		}
 	}
 
+	// WithFieldNullable override Config.FieldNullable for table model
+	WithFieldNullable = func(on bool) model.FieldConfigOpt {
+		return func(cfg *model.FieldConfig) { cfg.FieldNullable = on }
+	}
+	// WithFieldCoverable override Config.FieldCoverable for table model
+	WithFieldCoverable = func(on bool) model.FieldConfigOpt {
+		return func(cfg *model.FieldConfig) { cfg.FieldCoverable = on }
+	}
+	// WithFieldEnumType override Config.WithEnumType for table model
+	WithFieldEnumType = func(on bool) model.FieldConfigOpt {
+		return func(cfg *model.FieldConfig) { cfg.FieldEnumType = on }
+	}
+	// WithFieldSignable override Config.FieldSignable for table model
+	WithFieldSignable = func(on bool) model.FieldConfigOpt {
+		return func(cfg *model.FieldConfig) { cfg.FieldSignable = on }
+	}
+
 	// WithMethod add custom method for table model
 	WithMethod = func(methods ...interface{}) model.AddMethodOpt {
 		return func() []interface{} { return methods }
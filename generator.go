@@ -3,16 +3,22 @@ package gen
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 
 	"golang.org/x/tools/go/packages"
@@ -21,6 +27,7 @@ import (
 	"gorm.io/gorm/schema"
 
 	"gorm.io/gen/helper"
+	"gorm.io/gen/internal/ddl"
 	"gorm.io/gen/internal/generate"
 	"gorm.io/gen/internal/model"
 	"gorm.io/gen/internal/parser"
@@ -58,9 +65,33 @@ func NewGenerator(cfg Config) *Generator {
 		Config: cfg,
 		Data:   make(map[string]*genInfo),
 		models: make(map[string]*generate.QueryStructMeta),
+		protos: make(map[string]bool),
 	}
 }
 
+// NewGeneratorFromDDL create a new generator reading tables from DDL files instead of database connection,
+// CREATE TABLE(columns, indexes, comments, defaults, foreign keys), CREATE INDEX, ALTER TABLE ADD/DROP, DROP TABLE and
+// COMMENT ON statements are applied in order, directory is read as its .sql files in name order(e.g. migrations),
+// dialect of DDL is set by Config.DDLDialect. Metadata queried from database is not available and not queried, so that
+// FieldReadOnlyGenerated, FieldDetectIdentity, FieldWithCheckTag, FieldViewNullable(inference, overrides still apply),
+// WithFunctionalIndexes and nulls not distinct indexes take no effect, schema of WithSchemaTableName is read from
+// WithDbNameOpts only, and column ordinal position is the declaration order
+func NewGeneratorFromDDL(cfg Config, paths ...string) *Generator {
+	if cfg.DDLDialect == "" {
+		cfg.DDLDialect = "mysql"
+	}
+	tables := ddl.NewSchema(cfg.DDLDialect)
+	if err := tables.ParseFiles(paths...); err != nil {
+		panic(fmt.Errorf("create generator fail: %w", err))
+	}
+	db, err := gorm.Open(ddl.Dialector{Schema: tables})
+	if err != nil {
+		panic(fmt.Errorf("create generator fail: %w", err))
+	}
+	cfg.db = db
+	return NewGenerator(cfg)
+}
+
 // genInfo info about generated code
 type genInfo struct {
 	*generate.QueryStructMeta
@@ -91,6 +122,12 @@ type Generator struct {
 
 	Data   map[string]*genInfo                  //gen query data
 	models map[string]*generate.QueryStructMeta //gen model data
+	protos map[string]bool                      //models generating protobuf message
+
+	optionsHash string            // hash of generate options and templates, see Config.Incremental
+	hashes      map[string]string // hashes of generated files from last run
+	nextHashes  map[string]string // hashes of generated files of this run
+	hashesMu    sync.Mutex
 }
 
 // UseDB set db connection
@@ -112,7 +149,12 @@ func (g *Generator) GenerateModel(tableName string, opts ...ModelOpt) *generate.
 
 // GenerateModelAs catch table info from db, return a BaseStruct
 func (g *Generator) GenerateModelAs(tableName string, modelName string, opts ...ModelOpt) *generate.QueryStructMeta {
-	meta, err := generate.GetQueryStructMeta(g.db, g.genModelConfig(tableName, modelName, opts))
+	meta, err := generate.GetQueryStructMeta(g.db, g.genModelConfig(tableName, modelName, g.mergeModelOpts(opts)))
+	return g.addModel(tableName, meta, err)
+}
+
+// addModel register model generated from table
+func (g *Generator) addModel(tableName string, meta *generate.QueryStructMeta, err error) *generate.QueryStructMeta {
 	if err != nil {
 		g.db.Logger.Error(context.Background(), "generate struct from table fail: %s", err)
 		panic("generate struct fail")
@@ -127,6 +169,18 @@ func (g *Generator) GenerateModelAs(tableName string, modelName string, opts ...
 	return meta
 }
 
+// GenerateProto catch table info from db like GenerateModel, protobuf message of model is generated into ProtoOutPath,
+// nullable field is declared by ProtoNullable, see WithProtoConverters for conversion helpers
+func (g *Generator) GenerateProto(tableName string, opts ...ModelOpt) *generate.QueryStructMeta {
+	// message fields are numbered by column ordinal position
+	opts = append(opts[:len(opts):len(opts)], model.FieldConfigOpt(func(cfg *model.FieldConfig) { cfg.FieldOrdinals = true }))
+	meta := g.GenerateModel(tableName, opts...)
+	if meta != nil {
+		g.protos[meta.ModelStructName] = true
+	}
+	return meta
+}
+
 // GenerateAllTable generate all tables in db
 func (g *Generator) GenerateAllTable(opts ...ModelOpt) (tableModels []interface{}) {
 	tableList, err := g.db.Migrator().GetTables()
@@ -136,9 +190,32 @@ func (g *Generator) GenerateAllTable(opts ...ModelOpt) (tableModels []interface{
 
 	g.info(fmt.Sprintf("find %d table from db: %s", len(tableList), tableList))
 
+	metas := make([]*generate.QueryStructMeta, len(tableList))
+	errs := make([]error, len(tableList))
+	modelOpts := g.mergeModelOpts(opts) // merged once, shared by concurrent reads
+	read := func(i int, tableName string) {
+		modelName := g.db.Config.NamingStrategy.SchemaName(tableName)
+		metas[i], errs[i] = generate.GetQueryStructMeta(g.db, g.genModelConfig(tableName, modelName, modelOpts))
+	}
+	if g.Concurrency > 1 { // read tables concurrently, models are registered in table order
+		pool := pools.NewPool(g.Concurrency)
+		for i, tableName := range tableList {
+			pool.Wait()
+			go func(i int, tableName string) {
+				defer pool.Done()
+				read(i, tableName)
+			}(i, tableName)
+		}
+		pool.WaitAll()
+	} else {
+		for i, tableName := range tableList {
+			read(i, tableName)
+		}
+	}
+
 	tableModels = make([]interface{}, len(tableList))
 	for i, tableName := range tableList {
-		tableModels[i] = g.GenerateModel(tableName, opts...)
+		tableModels[i] = g.addModel(tableName, metas[i], errs[i])
 	}
 	return tableModels
 }
@@ -155,12 +232,15 @@ func (g *Generator) GenerateModelFrom(obj helper.Object) *generate.QueryStructMe
 	return s
 }
 
-func (g *Generator) genModelConfig(tableName string, modelName string, modelOpts []ModelOpt) *model.Config {
-	if modelOpts == nil {
-		modelOpts = g.modelOpts
-	} else {
-		modelOpts = append(modelOpts, g.modelOpts...)
+// mergeModelOpts options of model followed by global ones, opts is copied as caller may own spare capacity of it
+func (g *Generator) mergeModelOpts(opts []ModelOpt) []ModelOpt {
+	if opts == nil {
+		return g.modelOpts
 	}
+	return append(append(make([]ModelOpt, 0, len(opts)+len(g.modelOpts)), opts...), g.modelOpts...)
+}
+
+func (g *Generator) genModelConfig(tableName string, modelName string, modelOpts []ModelOpt) *model.Config {
 	return &model.Config{
 		ModelPkg:       g.Config.ModelPkgPath,
 		TablePrefix:    g.getTablePrefix(),
@@ -168,22 +248,141 @@ func (g *Generator) genModelConfig(tableName string, modelName string, modelOpts
 		ModelName:      modelName,
 		ImportPkgPaths: g.importPkgPaths,
 		ModelOpts:      modelOpts,
+
+		WithTableCommentDoc: g.WithTableCommentDoc,
+		WithDocCommentName:  g.WithDocCommentName,
+		WithEnumScanner:     g.WithEnumScanner,
+		WithEnumInteger:     g.WithEnumInteger,
+		WithEnumDefault:     g.WithEnumDefault,
+		WithSchemaTableName: g.WithSchemaTableName,
+
+		WithUpdatableColumns: g.WithUpdatableColumns,
+
+		WithFunctionalIndexes: g.WithFunctionalIndexes,
 		NameStrategy: model.NameStrategy{
 			SchemaNameOpts: g.dbNameOpts,
 			TableNameNS:    g.tableNameNS,
+			TableSchemaNS:  g.tableSchemaNS,
 			ModelNameNS:    g.modelNameNS,
 			FileNameNS:     g.fileNameNS,
 		},
 		FieldConfig: model.FieldConfig{
-			DataTypeMap: g.dataTypeMap,
+			DataTypeMap:     g.dataTypeMap,
+			TypeTagOverride: g.typeTagOverride,
+			TypeTagDialect:  g.typeTagDialect,
+			GormTagOrder:    g.gormTagOrder,
+
+			AutoIncrementOverride: g.autoIncrement,
+			ColumnNameOverride:    g.columnName,
+
+			ViewNullableOverride: g.viewNullable,
+
+			FieldGoDefaults: g.goDefaults,
+
+			FieldTimePrecisionTag:  g.FieldTimePrecisionTag,
+			FieldFloatPrecisionTag: g.FieldFloatPrecisionTag,
 
 			FieldSignable:     g.FieldSignable,
+			FieldSignMapped:   g.FieldSignMapped,
+			FieldUnsignedPK:   g.FieldUnsignedPK,
+			FieldBoolScanType: g.FieldBoolScanType,
+			FieldViewNullable: g.FieldViewNullable,
+			FieldEnumType:     g.WithEnumType,
 			FieldNullable:     g.FieldNullable,
 			FieldCoverable:    g.FieldCoverable,
 			FieldWithIndexTag: g.FieldWithIndexTag,
 			FieldWithTypeTag:  g.FieldWithTypeTag,
+			FieldWithCheckTag: g.FieldWithCheckTag,
+			FieldOrdinalOrder: g.FieldOrdinalOrder,
+			FieldExampleTag:   g.FieldExampleTag,
+			FieldProtobufTag:  g.FieldProtobufTag,
+
+			FieldUniqueAsIndex: g.FieldUniqueAsIndex,
+			FieldIndexDBOrder:  g.FieldIndexDBOrder,
+
+			FieldCompositeIndexes: g.compositeIndexes,
+			FieldIndexPrecedence:  g.FieldIndexPrecedence,
+			FieldIndexNameLimit:   g.FieldIndexNameLimit,
+			FieldIndexNameMaxLen:  g.FieldIndexNameMaxLen,
+			FieldIndexNormalize:   g.FieldIndexNormalize,
+
+			FieldUnsignedDecimal:     g.unsignedDecimal,
+			FieldUnsignedDecimalType: g.unsignedDecimalType,
+			FieldBigIntDecimal:       g.bigIntDecimal,
+			FieldBigIntDecimalType:   g.bigIntDecimalType,
+
+			FieldBindingOmitempty:  g.FieldBindingOmitempty,
+			FieldJSONDirective:     g.FieldJSONDirective,
+			FieldCheckDirective:    g.FieldCheckDirective,
+			FieldDetectIdentity:    g.FieldDetectIdentity,
+			FieldFullTextReadOnly:  g.FieldFullTextReadOnly,
+			FieldReadOnlyGenerated: g.FieldReadOnlyGenerated,
+			FieldUUIDBinding:       g.FieldUUIDBinding,
+			FieldNetType:           g.FieldNetType,
+			FieldReadOnlyColumns:   g.readOnlyColumns,
+			FieldTriggerColumns:    g.triggerColumns,
+			FieldSkipZeroDefault:   g.FieldSkipZeroDefault,
+			FieldEnumDefault:       g.FieldEnumDefault,
+
+			FieldBinaryDefault:  g.FieldBinaryDefault,
+			FieldEmptyDefault:   g.emptyDefault,
+			FieldPointerDefault: g.FieldPointerDefault,
+
+			FieldPointerPolicy:       g.typePointerPolicy,
+			FieldColumnPointerPolicy: g.columnPointerPolicy,
+
+			FieldJSONType:         g.FieldJSONType,
+			FieldJSONArrayColumns: g.jsonArrayColumns,
+
+			FieldJSONStructTypes: g.jsonStructTypes,
+
+			FieldXMLType:       g.FieldXMLType,
+			FieldXMLStructType: g.xmlStructType,
+
+			FieldArrayType: g.FieldArrayType,
+
+			FieldYesNoColumns: g.yesNoColumns,
+			FieldNumericBool:  g.numericBoolNames,
+
+			FieldUTCTimeSerializer: g.utcTimeSerializer,
+			FieldUTCTimeDialects:   g.utcTimeDialects,
+
+			FieldTimestampType:    g.timestampType,
+			FieldTimestampColumns: g.timestampColumns,
+
+			FieldUnixTimeRules: g.unixTimeRules,
 
 			FieldJSONTagNS: g.fieldJSONTagNS,
+			FieldTagNS:     g.fieldTagNS,
+
+			FieldTagOmitempty: g.tagOmitempty,
+
+			FieldTagGenerators: g.tagGenerators,
+
+			FieldCommentSource: g.commentSource,
+
+			FieldCommentFallback: g.commentFallback,
+
+			FieldNullableStrategy: g.FieldNullableStrategy,
+			FieldNullableSelector: g.nullableSelector,
+			FieldNullableBool:     g.FieldNullableBool,
+			FieldEmbeddedConflict: g.FieldEmbeddedConflict,
+			FieldSharedPrimaryKey: g.FieldSharedPrimaryKey,
+
+			FieldForeignKeyRelations: g.WithForeignKeyRelations,
+
+			FieldProtobufNumbers: g.protobufNumbers,
+		},
+		MethodConfig: model.MethodConfig{
+			WithAfterFindHook:  g.WithAfterFindHook,
+			WithGoDefaultHook:  g.WithGoDefaultHook,
+			WithValidateMethod: g.WithValidateMethod,
+			WithModelInterface: g.WithModelInterface,
+			WithSoftDeletable:  g.WithSoftDeletable,
+			WithCloneMethod:    g.WithCloneMethod,
+			WithIndexesMethod:  g.WithIndexesMethod,
+
+			EntityInterface: g.entityInterface,
 		},
 	}
 }
@@ -262,19 +461,79 @@ func (g *Generator) apply(fc interface{}, structs []*generate.QueryStructMeta) {
 func (g *Generator) Execute() {
 	g.info("Start generating code.")
 
+	if err := g.checkTemplateOverrides(); err != nil {
+		g.db.Logger.Error(context.Background(), "check template override fail: %s", err)
+		panic("check template override fail")
+	}
+
+	if g.Incremental {
+		g.loadHashes()
+	}
+
+	if g.WithForeignKeyRelations {
+		g.relateForeignKeys()
+	}
+
 	if err := g.generateModelFile(); err != nil {
 		g.db.Logger.Error(context.Background(), "generate model struct fail: %s", err)
 		panic("generate model struct fail")
 	}
 
+	if err := g.generateProtoFile(); err != nil {
+		g.db.Logger.Error(context.Background(), "generate protobuf message fail: %s", err)
+		panic("generate protobuf message fail")
+	}
+
 	if err := g.generateQueryFile(); err != nil {
 		g.db.Logger.Error(context.Background(), "generate query code fail: %s", err)
 		panic("generate query code fail")
 	}
 
+	if g.Incremental {
+		if err := g.saveHashes(); err != nil {
+			g.db.Logger.Error(context.Background(), "save hash file fail: %s", err)
+			panic("save hash file fail")
+		}
+	}
+
 	g.info("Generate code done.")
 }
 
+// checkTemplateOverrides check templates of WithTemplateOverride are known by name and parsable
+func (g *Generator) checkTemplateOverrides() error {
+	names := make([]string, 0, len(g.templateOverrides))
+	for name := range g.templateOverrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := tmpl.Overridable[name]; !ok {
+			return fmt.Errorf("unknown template %q", name)
+		}
+		if _, err := template.New(name).Parse(g.templateOverrides[name]); err != nil {
+			return fmt.Errorf("parse template %q fail: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// templateOf template of name, overridden by WithTemplateOverride or built-in
+func (g *Generator) templateOf(name string) string {
+	if text, ok := g.templateOverrides[name]; ok {
+		return text
+	}
+	return tmpl.Overridable[name]
+}
+
+// relateForeignKeys add association fields inferred from foreign keys between generated models
+func (g *Generator) relateForeignKeys() {
+	models := make([]*generate.QueryStructMeta, 0, len(g.models))
+	for _, m := range g.models {
+		models = append(models, m)
+	}
+	generate.RelateForeignKeys(models)
+}
+
 // info logger
 func (g *Generator) info(logInfos ...string) {
 	for _, l := range logInfos {
@@ -293,7 +552,7 @@ func (g *Generator) generateQueryFile() (err error) {
 		return fmt.Errorf("make dir outpath(%s) fail: %s", g.OutPath, err)
 	}
 
-	errChan := make(chan error)
+	errChan := make(chan error, len(g.Data)) // buffered, failed goroutines must not block pool
 	pool := pools.NewPool(concurrent)
 	// generate query code for all struct
 	for _, info := range g.Data {
@@ -313,15 +572,16 @@ func (g *Generator) generateQueryFile() (err error) {
 			}
 		}(info)
 	}
+	pool.WaitAll()
 	select {
 	case err = <-errChan:
 		return err
-	case <-pool.AsyncWaitAll():
+	default:
 	}
 
 	// generate query file
 	var buf bytes.Buffer
-	err = render(tmpl.Header, &buf, map[string]interface{}{
+	err = render(g.templateOf("Header"), &buf, map[string]interface{}{
 		"Package":        g.queryPkgName,
 		"ImportPkgPaths": importList.Add(g.importPkgPaths...).Paths(),
 	})
@@ -330,12 +590,12 @@ func (g *Generator) generateQueryFile() (err error) {
 	}
 
 	if g.judgeMode(WithDefaultQuery) {
-		err = render(tmpl.DefaultQuery, &buf, g)
+		err = render(g.templateOf("DefaultQuery"), &buf, g)
 		if err != nil {
 			return err
 		}
 	}
-	err = render(tmpl.QueryMethod, &buf, g)
+	err = render(g.templateOf("QueryMethod"), &buf, g)
 	if err != nil {
 		return err
 	}
@@ -350,7 +610,7 @@ func (g *Generator) generateQueryFile() (err error) {
 	if g.WithUnitTest {
 		buf.Reset()
 
-		err = render(tmpl.Header, &buf, map[string]interface{}{
+		err = render(g.templateOf("Header"), &buf, map[string]interface{}{
 			"Package":        g.queryPkgName,
 			"ImportPkgPaths": unitTestImportList.Add(g.importPkgPaths...).Paths(),
 		})
@@ -358,11 +618,11 @@ func (g *Generator) generateQueryFile() (err error) {
 			g.db.Logger.Error(context.Background(), "generate query unit test fail: %s", err)
 			return nil
 		}
-		err = render(tmpl.DIYMethodTestBasic, &buf, nil)
+		err = render(g.templateOf("DIYMethodTestBasic"), &buf, nil)
 		if err != nil {
 			return err
 		}
-		err = render(tmpl.QueryMethodTest, &buf, g)
+		err = render(g.templateOf("QueryMethodTest"), &buf, g)
 		if err != nil {
 			g.db.Logger.Error(context.Background(), "generate query unit test fail: %s", err)
 			return nil
@@ -381,13 +641,19 @@ func (g *Generator) generateQueryFile() (err error) {
 
 // generateSingleQueryFile generate query code and save to file
 func (g *Generator) generateSingleQueryFile(data *genInfo) (err error) {
+	queryFile := fmt.Sprintf("%s%s%s.gen.go", g.OutPath, string(os.PathSeparator), data.FileName)
+	if g.unchanged(queryFile, data.Hash(), generate.HashInterfaceMethods(data.Interfaces), g.modelPkgPath) {
+		g.info(fmt.Sprintf("skip unchanged query file: %s", queryFile))
+		return nil
+	}
+
 	var buf bytes.Buffer
 
 	structPkgPath := data.StructInfo.PkgPath
 	if structPkgPath == "" {
 		structPkgPath = g.modelPkgPath
 	}
-	err = render(tmpl.Header, &buf, map[string]interface{}{
+	err = render(g.templateOf("Header"), &buf, map[string]interface{}{
 		"Package":        g.queryPkgName,
 		"ImportPkgPaths": importList.Add(g.importPkgPaths...).Add(structPkgPath).Add(getImportPkgPaths(data)...).Paths(),
 	})
@@ -397,9 +663,9 @@ func (g *Generator) generateSingleQueryFile(data *genInfo) (err error) {
 
 	data.QueryStructMeta = data.QueryStructMeta.IfaceMode(g.judgeMode(WithQueryInterface))
 
-	structTmpl := tmpl.TableQueryStructWithContext
+	structTmpl := g.templateOf("TableQueryStructWithContext")
 	if g.judgeMode(WithoutContext) {
-		structTmpl = tmpl.TableQueryStruct
+		structTmpl = g.templateOf("TableQueryStruct")
 	}
 	err = render(structTmpl, &buf, data.QueryStructMeta)
 	if err != nil {
@@ -407,37 +673,43 @@ func (g *Generator) generateSingleQueryFile(data *genInfo) (err error) {
 	}
 
 	if g.judgeMode(WithQueryInterface) {
-		err = render(tmpl.TableQueryIface, &buf, data)
+		err = render(g.templateOf("TableQueryIface"), &buf, data)
 		if err != nil {
 			return err
 		}
 	}
 
 	for _, method := range data.Interfaces {
-		err = render(tmpl.DIYMethod, &buf, method)
+		err = render(g.templateOf("DIYMethod"), &buf, method)
 		if err != nil {
 			return err
 		}
 	}
 
-	err = render(tmpl.CRUDMethod, &buf, data.QueryStructMeta)
+	err = render(g.templateOf("CRUDMethod"), &buf, data.QueryStructMeta)
 	if err != nil {
 		return err
 	}
 
-	defer g.info(fmt.Sprintf("generate query file: %s%s%s.gen.go", g.OutPath, string(os.PathSeparator), data.FileName))
-	return g.output(fmt.Sprintf("%s%s%s.gen.go", g.OutPath, string(os.PathSeparator), data.FileName), buf.Bytes())
+	defer g.info(fmt.Sprintf("generate query file: %s", queryFile))
+	return g.output(queryFile, buf.Bytes())
 }
 
 // generateQueryUnitTestFile generate unit test file for query
 func (g *Generator) generateQueryUnitTestFile(data *genInfo) (err error) {
+	testFile := fmt.Sprintf("%s%s%s.gen_test.go", g.OutPath, string(os.PathSeparator), data.FileName)
+	if g.unchanged(testFile, data.Hash(), generate.HashInterfaceMethods(data.Interfaces), g.modelPkgPath) {
+		g.info(fmt.Sprintf("skip unchanged unit test file: %s", testFile))
+		return nil
+	}
+
 	var buf bytes.Buffer
 
 	structPkgPath := data.StructInfo.PkgPath
 	if structPkgPath == "" {
 		structPkgPath = g.modelPkgPath
 	}
-	err = render(tmpl.Header, &buf, map[string]interface{}{
+	err = render(g.templateOf("Header"), &buf, map[string]interface{}{
 		"Package":        g.queryPkgName,
 		"ImportPkgPaths": unitTestImportList.Add(structPkgPath).Add(data.ImportPkgPaths...).Paths(),
 	})
@@ -445,20 +717,20 @@ func (g *Generator) generateQueryUnitTestFile(data *genInfo) (err error) {
 		return err
 	}
 
-	err = render(tmpl.CRUDMethodTest, &buf, data.QueryStructMeta)
+	err = render(g.templateOf("CRUDMethodTest"), &buf, data.QueryStructMeta)
 	if err != nil {
 		return err
 	}
 
 	for _, method := range data.Interfaces {
-		err = render(tmpl.DIYMethodTest, &buf, method)
+		err = render(g.templateOf("DIYMethodTest"), &buf, method)
 		if err != nil {
 			return err
 		}
 	}
 
-	defer g.info(fmt.Sprintf("generate unit test file: %s%s%s.gen_test.go", g.OutPath, string(os.PathSeparator), data.FileName))
-	return g.output(fmt.Sprintf("%s%s%s.gen_test.go", g.OutPath, string(os.PathSeparator), data.FileName), buf.Bytes())
+	defer g.info(fmt.Sprintf("generate unit test file: %s", testFile))
+	return g.output(testFile, buf.Bytes())
 }
 
 // generateModelFile generate model structures and save to file
@@ -476,47 +748,389 @@ func (g *Generator) generateModelFile() error {
 		return fmt.Errorf("create model pkg path(%s) fail: %s", modelOutPath, err)
 	}
 
-	errChan := make(chan error)
+	// methods written by user in model package, generated ones with the same name will be skipped
+	declaredMethods, err := parser.GetDeclaredMethods(modelOutPath)
+	if err != nil {
+		g.db.Logger.Warn(context.Background(), "parse declared model methods fail: %s", err)
+	}
+
+	// consts of enum types shared by tables are declared by merged type
+	generate.ResolveEnumDefaults(g.models)
+
+	modelFiles := g.groupModelFiles()
+	errChan := make(chan error, len(modelFiles)) // buffered, failed goroutines must not block pool
 	pool := pools.NewPool(concurrent)
-	for _, data := range g.models {
-		if data == nil || !data.Generated {
-			continue
-		}
+	for fileName, models := range modelFiles {
 		pool.Wait()
-		go func(data *generate.QueryStructMeta) {
+		go func(fileName string, models []*generate.QueryStructMeta) {
 			defer pool.Done()
 
+			modelFile := modelOutPath + fileName + ".gen.go"
+			if g.unchanged(modelFile, modelFileHashParts(models, declaredMethods)...) {
+				g.info(fmt.Sprintf("skip unchanged model file: %s", modelFile))
+				return
+			}
+
 			var buf bytes.Buffer
-			err := render(tmpl.Model, &buf, data)
+			if tag := strings.TrimSpace(g.modelBuildTags[fileName]); tag != "" {
+				// build constraint must precede package clause, followed by a blank line
+				buf.WriteString("//go:build " + strings.TrimPrefix(tag, "//go:build ") + "\n")
+			}
+			err := render(g.templateOf("ModelHeader"), &buf, mergeModelHeader(models))
 			if err != nil {
 				errChan <- err
 				return
 			}
 
-			for _, method := range data.ModelMethods {
-				err = render(tmpl.ModelMethod, &buf, method)
+			for _, data := range models {
+				err = g.renderModel(&buf, data, declaredMethods)
 				if err != nil {
 					errChan <- err
 					return
 				}
 			}
 
-			modelFile := modelOutPath + data.FileName + ".gen.go"
 			err = g.output(modelFile, buf.Bytes())
 			if err != nil {
 				errChan <- err
 				return
 			}
 
-			g.info(fmt.Sprintf("generate model file(table <%s> -> {%s.%s}): %s", data.TableName, data.StructInfo.Package, data.StructInfo.Type, modelFile))
-		}(data)
+			for _, data := range models {
+				g.info(fmt.Sprintf("generate model file(table <%s> -> {%s.%s}): %s", data.TableName, data.StructInfo.Package, data.StructInfo.Type, modelFile))
+			}
+		}(fileName, models)
 	}
+	pool.WaitAll()
 	select {
 	case err = <-errChan:
 		return err
-	case <-pool.AsyncWaitAll():
+	default:
 		g.fillModelPkgPath(modelOutPath)
 	}
+
+	if g.WithEnumScanner || g.hasEnumTypes() { // enum type may be turned on by table model
+		if err = g.generateEnumFile(modelOutPath, declaredMethods); err != nil {
+			return err
+		}
+	}
+	if g.WithValidateMethod {
+		if err = g.generateValidatorFile(modelOutPath); err != nil {
+			return err
+		}
+	}
+	if g.WithModelRegistry {
+		return g.generateModelRegistryFile(modelOutPath)
+	}
+	return nil
+}
+
+// modelFileHashParts hashes of models in model file and methods declared by user(generated ones are skipped)
+func modelFileHashParts(models []*generate.QueryStructMeta, declaredMethods map[string]map[string]bool) (parts []string) {
+	for _, data := range models {
+		methods := make([]string, 0, len(declaredMethods[data.ModelStructName]))
+		for name := range declaredMethods[data.ModelStructName] {
+			methods = append(methods, name)
+		}
+		sort.Strings(methods)
+		parts = append(parts, data.Hash(), strings.Join(methods, ","))
+	}
+	return parts
+}
+
+// groupModelFiles group models by output file name, each model has its own file unless grouped by WithModelFileGroup
+func (g *Generator) groupModelFiles() map[string][]*generate.QueryStructMeta {
+	files := make(map[string][]*generate.QueryStructMeta)
+	for _, data := range g.models {
+		if data == nil || !data.Generated {
+			continue
+		}
+		fileName := data.FileName
+		if g.modelFileGroupNS != nil {
+			if group := g.modelFileGroupNS(data.TableName); group != "" {
+				fileName = group
+			}
+		}
+		files[fileName] = append(files[fileName], data)
+	}
+	for _, models := range files {
+		sort.Slice(models, func(i, j int) bool { return models[i].ModelStructName < models[j].ModelStructName })
+	}
+	return files
+}
+
+// mergeModelHeader header data of models in the same file, unused imports are removed when formatting
+func mergeModelHeader(models []*generate.QueryStructMeta) *generate.QueryStructMeta {
+	if len(models) == 1 {
+		return models[0]
+	}
+	header := &generate.QueryStructMeta{StructInfo: models[0].StructInfo}
+	imported := make(map[string]bool)
+	for _, data := range models {
+		for _, path := range data.ImportPkgPaths {
+			if !imported[path] {
+				imported[path] = true
+				header.ImportPkgPaths = append(header.ImportPkgPaths, path)
+			}
+		}
+	}
+	return header
+}
+
+// renderModel render model struct and its methods without package and imports
+func (g *Generator) renderModel(buf *bytes.Buffer, data *generate.QueryStructMeta, declaredMethods map[string]map[string]bool) error {
+	if g.fieldTemplate != "" {
+		if err := renderFieldTemplate(g.fieldTemplate, data); err != nil {
+			return err
+		}
+	}
+
+	err := render(g.templateOf("ModelStruct"), buf, data)
+	if err != nil {
+		return err
+	}
+
+	if g.WithModelColumns {
+		err = render(g.templateOf("ModelColumns"), buf, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.WithParamStructs {
+		err = render(g.templateOf("ModelParams"), buf, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.WithZeroValues {
+		err = render(g.templateOf("ModelZeroValues"), buf, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.WithUpdatableColumns {
+		err = render(g.templateOf("ModelUpdatableColumns"), buf, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.WithEnumDefault {
+		err = render(g.templateOf("ModelEnumDefaults"), buf, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.WithModelInterface {
+		err = render(g.templateOf("ModelInterface"), buf, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.entityInterface != nil {
+		err = render(g.templateOf("ModelEntityAssertion"), buf, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, method := range data.ModelMethods {
+		if declaredMethods[data.ModelStructName][method.MethodName] {
+			continue
+		}
+		err = render(g.templateOf("ModelMethod"), buf, method)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasEnumTypes whether any generated model has enum types
+func (g *Generator) hasEnumTypes() bool {
+	for _, data := range g.models {
+		if data != nil && data.Generated && len(data.EnumTypes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// generateEnumFile generate named types of enum columns in shared file, the same type in multiple tables is declared once
+func (g *Generator) generateEnumFile(modelOutPath string, declaredMethods map[string]map[string]bool) error {
+	enums := generate.MergeEnumTypes(g.models)
+	if len(enums) == 0 {
+		return nil
+	}
+
+	declaredTypes, err := parser.GetDeclaredTypes(modelOutPath)
+	if err != nil {
+		g.db.Logger.Warn(context.Background(), "parse declared model types fail: %s", err)
+	}
+
+	var pkgName string
+	for _, data := range g.models {
+		if data != nil && data.Generated {
+			pkgName = data.StructInfo.Package
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = render(g.templateOf("EnumFile"), &buf, pkgName); err != nil {
+		return err
+	}
+	for _, enum := range enums {
+		enum.Scanner = g.WithEnumScanner || (enum.Integer && !enum.Native) // int backed value is converted to member string
+		enum.Text = g.WithEnumText
+		enum.TypeDeclared = declaredTypes[enum.Name]
+		enum.DeclaredMethods = declaredMethods[enum.Name]
+		if err = render(g.templateOf("EnumType"), &buf, enum); err != nil {
+			return err
+		}
+	}
+
+	enumFile := modelOutPath + "enums.gen.go"
+	if err = g.output(enumFile, buf.Bytes()); err != nil {
+		return err
+	}
+	g.info(fmt.Sprintf("generate enum types file: %s", enumFile))
+	return nil
+}
+
+// generateModelRegistryFile generate AllModels() listing all generated models of model package, used by AutoMigrate
+func (g *Generator) generateModelRegistryFile(modelOutPath string) error {
+	var pkgName string
+	var models []string
+	for _, data := range g.models {
+		if data == nil || !data.Generated {
+			continue
+		}
+		if pkgName == "" {
+			pkgName = data.StructInfo.Package
+		}
+		if data.StructInfo.Package == pkgName {
+			models = append(models, data.ModelStructName)
+		}
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	sort.Strings(models)
+
+	var buf bytes.Buffer
+	err := render(g.templateOf("ModelRegistry"), &buf, map[string]interface{}{"Package": pkgName, "Models": models})
+	if err != nil {
+		return err
+	}
+
+	registryFile := modelOutPath + "models.gen.go"
+	if err = g.output(registryFile, buf.Bytes()); err != nil {
+		return err
+	}
+	g.info(fmt.Sprintf("generate model registry file: %s", registryFile))
+	return nil
+}
+
+// generateValidatorFile generate validators shared by Validate methods of models, one for each validated tag
+func (g *Generator) generateValidatorFile(modelOutPath string) error {
+	var pkgName string
+	tags := make(map[string]bool)
+	for _, data := range g.models {
+		if data == nil || !data.Generated || data.ValidateTag == "" {
+			continue
+		}
+		if pkgName == "" {
+			pkgName = data.StructInfo.Package
+		}
+		if data.StructInfo.Package == pkgName {
+			tags[data.ValidateTag] = true
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	validators := make([]map[string]string, 0, len(tags))
+	for tag := range tags {
+		validators = append(validators, map[string]string{"Name": parser.ValidatorName(tag), "Tag": tag})
+	}
+	sort.Slice(validators, func(i, j int) bool { return validators[i]["Name"] < validators[j]["Name"] })
+
+	var buf bytes.Buffer
+	err := render(g.templateOf("ModelValidators"), &buf, map[string]interface{}{"Package": pkgName, "Validators": validators})
+	if err != nil {
+		return err
+	}
+
+	validatorFile := modelOutPath + "validators.gen.go"
+	if err = g.output(validatorFile, buf.Bytes()); err != nil {
+		return err
+	}
+	g.info(fmt.Sprintf("generate validators file: %s", validatorFile))
+	return nil
+}
+
+// generateProtoFile generate protobuf message file of models from GenerateProto, and conversion helpers into model package
+func (g *Generator) generateProtoFile() error {
+	if len(g.protos) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(g.ProtoOutPath, os.ModePerm); err != nil {
+		return fmt.Errorf("create proto path(%s) fail: %s", g.ProtoOutPath, err)
+	}
+	modelOutPath, err := g.getModelOutputPath()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(g.protos))
+	for name := range g.protos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		data := g.models[name]
+		if data == nil || !data.Generated {
+			continue
+		}
+		msg := data.ProtoMessage(g.ProtoNullable)
+
+		var buf bytes.Buffer
+		err = render(g.templateOf("ProtoFile"), &buf, map[string]interface{}{"Package": g.ProtoPackage, "GoPackage": g.ProtoGoPackage, "Message": msg})
+		if err != nil {
+			return err
+		}
+		protoFile := filepath.Join(g.ProtoOutPath, data.FileName+".proto")
+		if err = g.writeFile(protoFile, buf.Bytes()); err != nil {
+			return err
+		}
+		g.info(fmt.Sprintf("generate protobuf message file: %s", protoFile))
+
+		if !g.WithProtoConverters {
+			continue
+		}
+		buf.Reset()
+		err = render(g.templateOf("ProtoConverter"), &buf, map[string]interface{}{
+			"Package":   data.StructInfo.Package,
+			"GoPackage": g.ProtoGoPackage,
+			"Model":     data.ModelStructName,
+			"Message":   msg,
+		})
+		if err != nil {
+			return err
+		}
+		converterFile := modelOutPath + data.FileName + "_proto.gen.go"
+		if err = g.output(converterFile, buf.Bytes()); err != nil {
+			return err
+		}
+		g.info(fmt.Sprintf("generate protobuf converter file: %s", converterFile))
+	}
 	return nil
 }
 
@@ -567,7 +1181,93 @@ func (g *Generator) output(fileName string, content []byte) error {
 		}
 		return fmt.Errorf("cannot format file: %w", err)
 	}
-	return ioutil.WriteFile(fileName, result, 0640)
+	return g.writeFile(fileName, result)
+}
+
+// writeFile write file, file with unchanged content is not rewritten in incremental mode
+func (g *Generator) writeFile(fileName string, content []byte) error {
+	if g.Incremental {
+		if old, err := ioutil.ReadFile(fileName); err == nil && bytes.Equal(old, content) {
+			return nil
+		}
+	}
+	return ioutil.WriteFile(fileName, content, 0640)
+}
+
+// hashFileName file keeping hashes of generated files in OutPath, used by incremental generation
+const hashFileName = ".gen.hash.json"
+
+// loadHashes load hashes of generated files from last run and hash generate options and templates,
+// all files are regenerated if hash file is missing or broken
+func (g *Generator) loadHashes() {
+	g.hashes, g.nextHashes = make(map[string]string), make(map[string]string)
+	if content, err := ioutil.ReadFile(filepath.Join(g.OutPath, hashFileName)); err == nil {
+		if err = json.Unmarshal(content, &g.hashes); err != nil {
+			g.db.Logger.Warn(context.Background(), "parse hash file fail, all files are regenerated: %s", err)
+		}
+	}
+
+	// options set by function(e.g. WithDataTypeMap) take effect through generated struct hashed per table
+	var options []string
+	cfg := reflect.ValueOf(g.Config)
+	for i := 0; i < cfg.NumField(); i++ {
+		if f := cfg.Type().Field(i); f.IsExported() {
+			switch f.Type.Kind() {
+			case reflect.Bool, reflect.Int, reflect.Uint, reflect.String:
+				options = append(options, fmt.Sprintf("%s=%v", f.Name, cfg.Field(i)))
+			}
+		}
+	}
+	for name := range tmpl.Overridable {
+		options = append(options, "template "+name+"="+g.templateOf(name))
+	}
+	for name, tag := range g.modelBuildTags {
+		options = append(options, "build tag "+name+"="+tag)
+	}
+	sort.Strings(options)
+	options = append(options, "field template="+g.fieldTemplate, fmt.Sprintf("imports=%q", g.importPkgPaths))
+	g.optionsHash = hashOf(options...)
+}
+
+// saveHashes save hashes of files generated in this run
+func (g *Generator) saveHashes() error {
+	content, err := json.MarshalIndent(g.nextHashes, "", "\t")
+	if err != nil {
+		return err
+	}
+	return g.writeFile(filepath.Join(g.OutPath, hashFileName), content)
+}
+
+// unchanged record hash of generated file, report whether file exists and has the same hash in last run,
+// always false if not in incremental mode
+func (g *Generator) unchanged(fileName string, parts ...string) bool {
+	if !g.Incremental {
+		return false
+	}
+	key := fileName
+	if rel, err := filepath.Rel(g.OutPath, fileName); err == nil {
+		key = filepath.ToSlash(rel)
+	}
+	hash := hashOf(append([]string{g.optionsHash}, parts...)...)
+
+	g.hashesMu.Lock()
+	g.nextHashes[key] = hash
+	g.hashesMu.Unlock()
+
+	if g.hashes[key] != hash {
+		return false
+	}
+	_, err := os.Stat(fileName)
+	return err == nil
+}
+
+func hashOf(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 func (g *Generator) pushQueryStructMeta(meta *generate.QueryStructMeta) (*genInfo, error) {
@@ -590,6 +1290,22 @@ func render(tmpl string, wr io.Writer, data interface{}) error {
 	return t.Execute(wr, data)
 }
 
+// renderFieldTemplate render field lines of model with custom field template
+func renderFieldTemplate(text string, data *generate.QueryStructMeta) error {
+	t, err := template.New("field").Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse field template fail: %w", err)
+	}
+	for _, f := range data.Fields {
+		var buf bytes.Buffer
+		if err = t.Execute(&buf, f); err != nil {
+			return fmt.Errorf("render field template of %s.%s fail: %w", data.TableName, f.ColumnName, err)
+		}
+		f.Rendered = strings.TrimSpace(buf.String())
+	}
+	return nil
+}
+
 func getImportPkgPaths(data *genInfo) []string {
 	importPathMap := make(map[string]struct{})
 	for _, path := range data.ImportPkgPaths {
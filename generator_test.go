@@ -2,16 +2,26 @@ package gen
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"golang.org/x/tools/go/packages"
 	"gorm.io/gorm"
 	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
 	"gorm.io/gorm/utils/tests"
 
 	"gorm.io/gen/field"
+	"gorm.io/gen/internal/generate"
+	"gorm.io/gen/internal/model"
 )
 
 func TestConfig(t *testing.T) {
@@ -27,6 +37,669 @@ func TestConfig(t *testing.T) {
 	}
 }
 
+func TestRenderFieldTemplate(t *testing.T) {
+	data := &generate.QueryStructMeta{
+		TableName: "users",
+		Fields: []*model.Field{
+			{Name: "ID", Type: "int64", ColumnName: "id"},
+			{Name: "Name", Type: "string", ColumnName: "name"},
+		},
+	}
+
+	if err := renderFieldTemplate(`{{if eq .Name "Name"}} {{.Name}} {{.Type}} // custom {{end}}`, data); err != nil {
+		t.Fatalf("render field template fail: %s", err)
+	}
+	if data.Fields[0].Rendered != "" {
+		t.Errorf("empty output should keep built-in rendering, got: %q", data.Fields[0].Rendered)
+	}
+	if data.Fields[1].Rendered != "Name string // custom" {
+		t.Errorf("unexpected rendered field: %q", data.Fields[1].Rendered)
+	}
+
+	err := renderFieldTemplate(`{{.Unknown}}`, data)
+	if err == nil || !strings.Contains(err.Error(), "users.id") {
+		t.Errorf("expect error identifying users.id, got: %v", err)
+	}
+	if err = renderFieldTemplate(`{{.Name`, data); err == nil {
+		t.Errorf("expect parse error, got nil")
+	}
+}
+
+func TestTemplateOverride(t *testing.T) {
+	g := NewGenerator(Config{})
+	g.WithTemplateOverride("ModelHeader", "// Copyright\npackage {{.StructInfo.Package}}\n")
+	if err := g.checkTemplateOverrides(); err != nil {
+		t.Fatalf("check template override fail: %s", err)
+	}
+	if text := g.templateOf("ModelHeader"); !strings.HasPrefix(text, "// Copyright") {
+		t.Errorf("overridden template expect custom header, got: %q", text)
+	}
+	if text := g.templateOf("ModelStruct"); text == "" {
+		t.Errorf("template not overridden expect built-in one")
+	}
+
+	g.WithTemplateOverride("ModelHeaders", "")
+	if err := g.checkTemplateOverrides(); err == nil || !strings.Contains(err.Error(), "ModelHeaders") {
+		t.Errorf("unknown template expect error, got: %v", err)
+	}
+	delete(g.templateOverrides, "ModelHeaders")
+	g.WithTemplateOverride("CRUDMethod", "{{.Name")
+	if err := g.checkTemplateOverrides(); err == nil {
+		t.Errorf("unparsable template expect error, got nil")
+	}
+}
+
+func TestIncrementalHashes(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "users.gen.go")
+	newGenerator := func() *Generator {
+		g := NewGenerator(Config{OutPath: dir, Incremental: true})
+		g.loadHashes()
+		return g
+	}
+
+	g := newGenerator()
+	if g.unchanged(file, "h1") {
+		t.Errorf("file without hash of last run expect changed")
+	}
+	if err := g.saveHashes(); err != nil {
+		t.Fatalf("save hashes fail: %s", err)
+	}
+	if g = newGenerator(); g.unchanged(file, "h1") {
+		t.Errorf("missing file expect changed")
+	}
+	if err := g.writeFile(file, []byte("package model")); err != nil {
+		t.Fatalf("write file fail: %s", err)
+	}
+	if !g.unchanged(file, "h1") {
+		t.Errorf("existing file with the same hash expect unchanged")
+	}
+	if g.unchanged(file, "h2") {
+		t.Errorf("file with different hash expect changed")
+	}
+
+	g.WithTemplateOverride("ModelStruct", "")
+	if g.loadHashes(); g.unchanged(file, "h1") {
+		t.Errorf("file expect changed by template override")
+	}
+}
+
+func TestGenerate_RelationsWithInterface(t *testing.T) {
+	dir := generateFromDDL(t, Config{WithForeignKeyRelations: true, WithModelInterface: true, WithCloneMethod: true},
+		"CREATE TABLE users (id bigint NOT NULL AUTO_INCREMENT, name varchar(64) NOT NULL, PRIMARY KEY (id));\n"+
+			"CREATE TABLE orders (id bigint NOT NULL AUTO_INCREMENT, user_id bigint NOT NULL, PRIMARY KEY (id),\n"+
+			"  CONSTRAINT fk_orders_user FOREIGN KEY (user_id) REFERENCES users (id));")
+	checkGeneratedPackages(t, dir, "model", "query")
+
+	content, _ := os.ReadFile(filepath.Join(dir, "model", "orders.gen.go"))
+	for _, expected := range []string{"GetUser() *User", "func (o *Order) GetUser() *User", "cloned.User = &v"} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("generated order model expect %q", expected)
+		}
+	}
+}
+
+func TestGenerate_ModelInterface(t *testing.T) {
+	ddl := "CREATE TABLE users (name varchar(64) NOT NULL, nickname varchar(64), profile json, PRIMARY KEY (name));\n" +
+		"CREATE TABLE orders (id bigint NOT NULL AUTO_INCREMENT, user_name varchar(64) NOT NULL, PRIMARY KEY (id),\n" +
+		"  CONSTRAINT fk_orders_user FOREIGN KEY (user_name) REFERENCES users (name));"
+	for _, relations := range []bool{false, true} {
+		dir := generateFromDDL(t, Config{WithModelInterface: true, WithForeignKeyRelations: relations, FieldNullable: true, FieldJSONType: JSONTypeRaw},
+			ddl, FieldEmbed("", gorm.Model{}, ""))
+		checkGeneratedPackages(t, dir, "model", "query")
+
+		content, _ := os.ReadFile(filepath.Join(dir, "model", "users.gen.go"))
+		expected := []string{"type UserGetter interface", "GetModel() gorm.Model", "GetNickname() *string", "GetProfile() *datatypes.JSON",
+			"func (u *User) GetModel() gorm.Model {\n\treturn u.Model\n}"}
+		if relations {
+			expected = append(expected, "GetOrders() []Order")
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("generated user model(relations: %t) expect %q, got:\n%s", relations, e, content)
+			}
+		}
+	}
+}
+
+func TestGenerate_ModelInterfaceWithEntity(t *testing.T) {
+	ddl := "CREATE TABLE users (id bigint NOT NULL AUTO_INCREMENT, name varchar(64) NOT NULL, PRIMARY KEY (id));"
+	dir := generateFromDDL(t, Config{}, ddl)
+	entity := "package repo\n\ntype Entity interface {\n\tGetID() uint64\n}\n"
+	if err := os.MkdirAll(filepath.Join(dir, "repo"), os.ModePerm); err != nil {
+		t.Fatalf("create repo package fail: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "repo", "entity.go"), []byte(entity), 0640); err != nil {
+		t.Fatalf("write entity interface fail: %s", err)
+	}
+
+	// getter GetID() int64 is replaced by entity method returning ID type
+	cfg := Config{WithModelInterface: true}
+	cfg.WithEntityInterface(EntityInterface{Name: "repo.Entity", IDType: "uint64"}, "gorm.io/gen/"+filepath.ToSlash(filepath.Join(dir, "repo")))
+	generateIntoDir(t, dir, cfg, ddl)
+	checkGeneratedPackages(t, dir, "model", "query")
+
+	content, _ := os.ReadFile(filepath.Join(dir, "model", "users.gen.go"))
+	for _, e := range []string{"GetID() uint64\n", "func (u *User) GetID() uint64 {\n\treturn uint64(u.ID)\n}", "GetName() string", "var _ repo.Entity = (*User)(nil)"} {
+		if !strings.Contains(string(content), e) {
+			t.Errorf("generated user model expect %q, got:\n%s", e, content)
+		}
+	}
+	if n := strings.Count(string(content), "GetID()"); n != 2 {
+		t.Errorf("generated user model expect GetID declared once in getter interface and once as method, got %d:\n%s", n, content)
+	}
+}
+
+func TestGenerate_ModelFileGroup(t *testing.T) {
+	cfg := Config{FieldJSONType: JSONTypeRaw}
+	cfg.WithModelFileGroup(func(tableName string) string {
+		if strings.HasPrefix(tableName, "order") {
+			return "sales"
+		}
+		return ""
+	})
+	dir := generateFromDDL(t, cfg, "CREATE TABLE users (id bigint NOT NULL, profile json NOT NULL, PRIMARY KEY (id));\n"+
+		"CREATE TABLE orders (id bigint NOT NULL, paid_at datetime NOT NULL, PRIMARY KEY (id));\n"+
+		"CREATE TABLE order_items (id bigint NOT NULL, extra json NOT NULL, PRIMARY KEY (id));\n"+
+		"CREATE TABLE tags (id bigint NOT NULL, PRIMARY KEY (id));")
+	checkGeneratedPackages(t, dir, "model", "query")
+
+	for file, imports := range map[string]map[string]bool{
+		"sales.gen.go": {`"time"`: true, `"gorm.io/datatypes"`: true},
+		"users.gen.go": {`"time"`: false, `"gorm.io/datatypes"`: true},
+		"tags.gen.go":  {`"time"`: false, `"gorm.io/datatypes"`: false},
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, "model", file))
+		if err != nil {
+			t.Fatalf("model file %s expect generated: %s", file, err)
+		}
+		for path, expected := range imports {
+			if got := strings.Contains(string(content), path); got != expected {
+				t.Errorf("model file %s import %s expect: %t, got:\n%s", file, path, expected, content)
+			}
+		}
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "model", "sales.gen.go"))
+	if strings.Index(string(content), "type OrderItem struct") < strings.Index(string(content), "type Order struct") {
+		t.Errorf("models of group file expect ordered by name, got:\n%s", content)
+	}
+	for _, file := range []string{"orders.gen.go", "order_items.gen.go"} {
+		if _, err := os.Stat(filepath.Join(dir, "model", file)); !os.IsNotExist(err) {
+			t.Errorf("model file %s expect merged into group file", file)
+		}
+	}
+}
+
+func TestGenerate_AfterFindHook(t *testing.T) {
+	ddl := "CREATE TABLE users (id bigint NOT NULL, first_name varchar(64) NOT NULL, PRIMARY KEY (id));\n" +
+		"CREATE TABLE orders (id bigint NOT NULL, PRIMARY KEY (id));"
+	stub := "func (*User) AfterFind(tx *gorm.DB) error {\n\treturn nil\n}"
+
+	dir := generateFromDDL(t, Config{WithAfterFindHook: true}, ddl)
+	content, _ := os.ReadFile(filepath.Join(dir, "model", "users.gen.go"))
+	if strings.Contains(string(content), "AfterFind") {
+		t.Errorf("model without transient field expect no AfterFind hook, got:\n%s", content)
+	}
+
+	dir = generateFromDDL(t, Config{WithAfterFindHook: true}, ddl, FieldNew("FullName", "string", field.Tag{"gorm": "-"}))
+	checkGeneratedPackages(t, dir, "model", "query")
+	content, _ = os.ReadFile(filepath.Join(dir, "model", "users.gen.go"))
+	if !strings.Contains(string(content), stub) {
+		t.Errorf("model with transient field expect AfterFind hook %q, got:\n%s", stub, content)
+	}
+
+	// hook declared by user in model package is kept, generated stub is skipped
+	userHook := "package model\n\nimport \"gorm.io/gorm\"\n\nfunc (u *User) AfterFind(tx *gorm.DB) error {\n\tu.FullName = u.FirstName\n\treturn nil\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "model", "hooks.go"), []byte(userHook), 0640); err != nil {
+		t.Fatalf("write user hook fail: %s", err)
+	}
+	generateIntoDir(t, dir, Config{WithAfterFindHook: true}, ddl, FieldNew("FullName", "string", field.Tag{"gorm": "-"}))
+	checkGeneratedPackages(t, dir, "model", "query")
+	content, _ = os.ReadFile(filepath.Join(dir, "model", "users.gen.go"))
+	if strings.Contains(string(content), "AfterFind") {
+		t.Errorf("model with user declared AfterFind expect no generated hook, got:\n%s", content)
+	}
+	content, _ = os.ReadFile(filepath.Join(dir, "model", "orders.gen.go"))
+	if !strings.Contains(string(content), "func (*Order) AfterFind(tx *gorm.DB) error") {
+		t.Errorf("other model expect generated AfterFind hook, got:\n%s", content)
+	}
+}
+
+func TestGenerate_ModelColumns(t *testing.T) {
+	dir := generateFromDDL(t, Config{WithModelColumns: true, WithForeignKeyRelations: true, FieldJSONType: JSONTypeRaw},
+		"CREATE TABLE users (id bigint NOT NULL, name varchar(64) NOT NULL, score double NOT NULL, created_at datetime NOT NULL,\n"+
+			"  profile json NOT NULL, PRIMARY KEY (id));\n"+
+			"CREATE TABLE orders (id bigint NOT NULL, user_id bigint NOT NULL, PRIMARY KEY (id),\n"+
+			"  CONSTRAINT fk_orders_user FOREIGN KEY (user_id) REFERENCES users (id));",
+		FieldNew("FullName", "string", field.Tag{"gorm": "-"}))
+	checkGeneratedPackages(t, dir, "model", "query")
+
+	// transient and relation fields are excluded, unknown type falls back to field.Field
+	expected := "var UserColumns = struct {\n" +
+		"\tID        field.Int64\n\tName      field.String\n\tScore     field.Float64\n\tCreatedAt field.Time\n\tProfile   field.Field\n" +
+		"}{\n" +
+		"\tID:        field.NewInt64(\"users\", \"id\"),\n" +
+		"\tName:      field.NewString(\"users\", \"name\"),\n" +
+		"\tScore:     field.NewFloat64(\"users\", \"score\"),\n" +
+		"\tCreatedAt: field.NewTime(\"users\", \"created_at\"),\n" +
+		"\tProfile:   field.NewField(\"users\", \"profile\"),\n" +
+		"}\n"
+	content, _ := os.ReadFile(filepath.Join(dir, "model", "users.gen.go"))
+	if !strings.Contains(string(content), expected) {
+		t.Errorf("generated user model expect typed columns %q, got:\n%s", expected, content)
+	}
+}
+
+func TestGenerate_FullTextSearch(t *testing.T) {
+	cfg := Config{FieldFullTextReadOnly: true}
+	cfg.WithDataTypeMap(map[string]func(gorm.ColumnType) string{"tsquery": func(gorm.ColumnType) string { return "[]byte" }})
+	dir := generateFromDDL(t, cfg, "CREATE TABLE docs (id bigint NOT NULL, title varchar(64) NOT NULL, search tsvector, query tsquery, PRIMARY KEY (id));")
+	checkGeneratedPackages(t, dir, "model", "query")
+
+	content, _ := os.ReadFile(filepath.Join(dir, "model", "docs.gen.go"))
+	for _, expected := range []string{
+		"Title  string `gorm:\"column:title;not null\"", // type tag removed without FieldWithTypeTag
+		"Search string `gorm:\"column:search;type:tsvector;->\"",
+		"Query  []byte `gorm:\"column:query;type:tsquery\"",
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("generated doc model expect %q, got:\n%s", expected, content)
+		}
+	}
+}
+
+func TestGenerate_UniqueAsIndex(t *testing.T) {
+	ddl := "CREATE TABLE users (id bigint NOT NULL, tenant_id bigint NOT NULL, email varchar(64) NOT NULL, PRIMARY KEY (id),\n" +
+		"  UNIQUE KEY idx_tenant_email (tenant_id, email), KEY idx_email (email));"
+	for uniqueAsIndex, expected := range map[bool][]string{
+		false: {"column:tenant_id;not null;uniqueIndex:idx_tenant_email,priority:1",
+			"column:email;not null;uniqueIndex:idx_tenant_email,priority:2;index:idx_email,priority:1"},
+		true: {"column:tenant_id;not null;index:idx_tenant_email,unique,priority:1",
+			"column:email;not null;index:idx_tenant_email,unique,priority:2;index:idx_email,priority:1"},
+	} {
+		dir := generateFromDDL(t, Config{FieldWithIndexTag: true, FieldUniqueAsIndex: uniqueAsIndex}, ddl)
+		content, _ := os.ReadFile(filepath.Join(dir, "model", "users.gen.go"))
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("generated user model(unique as index: %t) expect %q, got:\n%s", uniqueAsIndex, e, content)
+			}
+		}
+	}
+}
+
+func TestGenerate_EnumScanner(t *testing.T) {
+	ddl := "CREATE TABLE users (id bigint NOT NULL, status enum('active','disabled') NOT NULL, PRIMARY KEY (id));\n" +
+		"CREATE TABLE orders (id bigint NOT NULL, status enum('active','disabled'), PRIMARY KEY (id));"
+	count := func(dir string, expected map[string]int) {
+		content, _ := os.ReadFile(filepath.Join(dir, "model", "enums.gen.go"))
+		for e, n := range expected {
+			if got := strings.Count(string(content), e); got != n {
+				t.Errorf("enum file expect %q %d times, got %d:\n%s", e, n, got, content)
+			}
+		}
+	}
+
+	dir := generateFromDDL(t, Config{WithEnumType: true, WithEnumScanner: true}, ddl, FieldType("status", "Status"))
+	checkGeneratedPackages(t, dir, "model", "query")
+	// shared by tables, declared once
+	count(dir, map[string]int{"type Status string": 1, "func (e *Status) Scan(value interface{}) error": 1,
+		"func (e Status) Value() (driver.Value, error)": 1, "StatusActive   Status = \"active\"": 1})
+
+	// type and method declared by user in model package are skipped
+	userCode := "package model\n\nimport \"database/sql/driver\"\n\ntype Status string\n\n" +
+		"func (e Status) Value() (driver.Value, error) {\n\treturn string(e), nil\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "model", "status.go"), []byte(userCode), 0640); err != nil {
+		t.Fatalf("write user code fail: %s", err)
+	}
+	generateIntoDir(t, dir, Config{WithEnumType: true, WithEnumScanner: true}, ddl, FieldType("status", "Status"))
+	checkGeneratedPackages(t, dir, "model", "query")
+	count(dir, map[string]int{"type Status string": 0, "func (e *Status) Scan(value interface{}) error": 1,
+		"func (e Status) Value() (driver.Value, error)": 0})
+}
+
+func TestGenerate_CaseSensitiveColumn(t *testing.T) {
+	cfg := Config{DDLDialect: "postgres", Mode: WithoutContext}
+	dir := generateFromDDL(t, cfg, `CREATE TABLE orders (id bigint NOT NULL, "userID" integer NOT NULL, "Order" integer NOT NULL,
+  "Select" text, PRIMARY KEY (id));`)
+	checkGeneratedPackages(t, dir, "model", "query")
+
+	// mixed-case reserved word keeps exact column name while field name is escaped for query methods
+	for f, expected := range map[string][]string{
+		"model/orders.gen.go": {"UserID  int32  `gorm:\"column:userID;not null\"", "Order_  int32  `gorm:\"column:Order;not null\"",
+			"Select_ string `gorm:\"column:Select\""},
+		"query/orders.gen.go": {`field.NewInt32(tableName, "userID")`, `_order.Order_ = field.NewInt32(tableName, "Order")`,
+			`_order.Select_ = field.NewString(tableName, "Select")`},
+	} {
+		content, _ := os.ReadFile(filepath.Join(dir, f))
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("generated %s expect %q, got:\n%s", f, e, content)
+			}
+		}
+	}
+}
+
+func TestGenerate_ValidateMethod(t *testing.T) {
+	dir := generateFromDDL(t, Config{WithValidateMethod: true},
+		"CREATE TABLE users (id bigint NOT NULL, email varchar(64) NOT NULL, PRIMARY KEY (id));\n"+
+			"CREATE TABLE orders (id bigint NOT NULL, note varchar(64) NOT NULL, PRIMARY KEY (id));\n"+
+			"CREATE TABLE tags (id bigint NOT NULL, PRIMARY KEY (id));",
+		FieldTag("email", func(tag field.Tag) field.Tag { return tag.Set(field.TagKeyValidate, "email") }),
+		FieldTag("note", func(tag field.Tag) field.Tag { return tag.Set(field.TagKeyBinding, "max=64") }))
+
+	for file, expected := range map[string]string{
+		"users.gen.go":  "func (u *User) Validate() error {\n\treturn Validator.Struct(u)\n}",
+		"orders.gen.go": "func (o *Order) Validate() error {\n\treturn BindingValidator.Struct(o)\n}",
+	} {
+		content, _ := os.ReadFile(filepath.Join(dir, "model", file))
+		if !strings.Contains(string(content), expected) || strings.Contains(string(content), "validator") {
+			t.Errorf("model file %s expect Validate by shared validator %q, got:\n%s", file, expected, content)
+		}
+	}
+
+	// one validator for each tag, shared by models of package
+	content, err := os.ReadFile(filepath.Join(dir, "model", "validators.gen.go"))
+	if err != nil {
+		t.Fatalf("validators file expect generated: %s", err)
+	}
+	for expected, count := range map[string]int{
+		"package model\n": 1, "\"github.com/go-playground/validator/v10\"": 1,
+		"var BindingValidator = newTagValidator(\"binding\")": 1, "var Validator = newTagValidator(\"validate\")": 1,
+		"validator.New()": 1,
+	} {
+		if got := strings.Count(string(content), expected); got != count {
+			t.Errorf("validators file expect %q %d times, got %d:\n%s", expected, count, got, content)
+		}
+	}
+}
+
+func TestGenerate_CloneDeepCopy(t *testing.T) {
+	dir := generateFromDDL(t, Config{WithCloneMethod: true, FieldNullable: true, FieldJSONType: JSONTypeRaw},
+		"CREATE TABLE docs (id bigint NOT NULL, title varchar(64), meta json NOT NULL, body json, PRIMARY KEY (id));")
+
+	// mutate clone in generated package, original must be unchanged
+	cloneTest := `package model
+
+import (
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestDocClone(t *testing.T) {
+	title, body := "title", datatypes.JSON("{}")
+	doc := &Doc{ID: 1, Title: &title, Meta: datatypes.JSON("[1]"), Body: &body}
+	cloned := doc.Clone()
+	*cloned.Title = "changed"
+	cloned.Meta[1] = '2'
+	(*cloned.Body)[0] = '['
+	if *doc.Title != "title" || string(doc.Meta) != "[1]" || string(*doc.Body) != "{}" {
+		t.Errorf("original changed by clone: %s %s %s", *doc.Title, doc.Meta, *doc.Body)
+	}
+	if (*Doc)(nil).Clone() != nil {
+		t.Errorf("clone of nil expect nil")
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "model", "clone_test.go"), []byte(cloneTest), 0640); err != nil {
+		t.Fatalf("write clone test fail: %s", err)
+	}
+	if out, err := exec.Command("go", "test", "-count=1", "./"+filepath.ToSlash(filepath.Join(dir, "model"))).CombinedOutput(); err != nil {
+		t.Errorf("clone of generated model expect deep copy: %s\n%s", err, out)
+	}
+}
+
+func TestGenerate_SharedEnumDefault(t *testing.T) {
+	dir := generateFromDDL(t, Config{WithEnumType: true, WithEnumDefault: true},
+		"CREATE TABLE orders (id bigint NOT NULL, status enum('in progress','in-progress') NOT NULL DEFAULT 'in progress', PRIMARY KEY (id));\n"+
+			"CREATE TABLE tasks (id bigint NOT NULL, status enum('in-progress','done') NOT NULL DEFAULT 'in-progress', PRIMARY KEY (id));",
+		FieldType("status", "Status"))
+	checkGeneratedPackages(t, dir, "model", "query")
+
+	// in-progress is declared as StatusInProgress2 by type merged from both tables
+	for file, expected := range map[string]string{"orders.gen.go": "Status: StatusInProgress,", "tasks.gen.go": "Status: StatusInProgress2,"} {
+		content, _ := os.ReadFile(filepath.Join(dir, "model", file))
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("model file %s expect %q, got:\n%s", file, expected, content)
+		}
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "model", "enums.gen.go"))
+	if expected := "StatusInProgress2 Status = \"in-progress\""; !strings.Contains(string(content), expected) {
+		t.Errorf("enum file expect %q, got:\n%s", expected, content)
+	}
+}
+
+func TestGenerate_EnumText(t *testing.T) {
+	dir := generateFromDDL(t, Config{WithEnumType: true, WithEnumInteger: true, WithEnumText: true},
+		"CREATE TABLE users (id bigint NOT NULL, status enum('active','disabled') NOT NULL, level enum('1','2','3') NOT NULL, PRIMARY KEY (id));\n"+
+			"CREATE TABLE groups (id bigint NOT NULL, status enum('active','archived') NOT NULL, PRIMARY KEY (id));",
+		FieldType("status", "Status"))
+	checkGeneratedPackages(t, dir, "model", "query")
+
+	content, _ := os.ReadFile(filepath.Join(dir, "model", "enums.gen.go"))
+	for expected, count := range map[string]int{
+		"func (e Status) MarshalText() ([]byte, error)":     1, // shared by tables, declared once
+		"func (e *Status) UnmarshalText(text []byte) error": 1,
+		"StatusArchived": 1,
+		"func (e UsersLevel) MarshalText() ([]byte, error)": 1,
+		"return []byte(strconv.Itoa(int(e))), nil":          1,
+	} {
+		if got := strings.Count(string(content), expected); got != count {
+			t.Errorf("enum file expect %q %d times, got %d:\n%s", expected, count, got, content)
+		}
+	}
+}
+
+func TestGenerate_ModelRegistry(t *testing.T) {
+	cfg := Config{ModelPkgPath: "entity", WithModelRegistry: true}
+	cfg.WithModelFileGroup(func(tableName string) string {
+		if strings.HasPrefix(tableName, "order") {
+			return "orders"
+		}
+		return ""
+	})
+	dir := generateFromDDL(t, cfg, "CREATE TABLE users (id bigint NOT NULL, PRIMARY KEY (id));\n"+
+		"CREATE TABLE orders (id bigint NOT NULL, PRIMARY KEY (id));\n"+
+		"CREATE TABLE order_items (id bigint NOT NULL, PRIMARY KEY (id));")
+	checkGeneratedPackages(t, dir, "entity", "query")
+
+	content, err := os.ReadFile(filepath.Join(dir, "entity", "models.gen.go"))
+	if err != nil {
+		t.Fatalf("model registry expect generated in model package: %s", err)
+	}
+	if expected := "package entity\n"; !strings.Contains(string(content), expected) {
+		t.Errorf("model registry expect %q, got:\n%s", expected, content)
+	}
+	if expected := "\t\tnew(Order),\n\t\tnew(OrderItem),\n\t\tnew(User),\n"; !strings.Contains(string(content), expected) {
+		t.Errorf("model registry expect all models of split files %q, got:\n%s", expected, content)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "query", "models.gen.go")); !os.IsNotExist(err) {
+		t.Errorf("model registry expect not generated in query package")
+	}
+}
+
+func TestGenerate_MultilineTableComment(t *testing.T) {
+	dir := generateFromDDL(t, Config{WithTableCommentDoc: true, WithDocCommentName: true},
+		"CREATE TABLE users (id bigint NOT NULL AUTO_INCREMENT, PRIMARY KEY (id)) COMMENT='users of app\nsecond line';")
+	checkGeneratedPackages(t, dir, "model", "query")
+
+	content, _ := os.ReadFile(filepath.Join(dir, "model", "users.gen.go"))
+	if !strings.Contains(string(content), "// User users of app\n// second line\n") {
+		t.Errorf("generated user model expect multiline doc comment, got:\n%s", content)
+	}
+	content, _ = os.ReadFile(filepath.Join(dir, "query", "users.gen.go"))
+	if !strings.Contains(string(content), "// user users of app\n// second line\n") {
+		t.Errorf("generated user query expect multiline comment, got:\n%s", content)
+	}
+}
+
+func TestGenerateAllTable_Concurrency(t *testing.T) {
+	schemaFile := filepath.Join(t.TempDir(), "schema.sql")
+	var ddl strings.Builder
+	for i := 0; i < 8; i++ {
+		fmt.Fprintf(&ddl, "CREATE TABLE t%d (id bigint NOT NULL, name varchar(64), PRIMARY KEY (id));\n", i)
+	}
+	if err := os.WriteFile(schemaFile, []byte(ddl.String()), 0640); err != nil {
+		t.Fatalf("write DDL fail: %s", err)
+	}
+
+	for _, concurrency := range []int{0, 4} {
+		var mu sync.Mutex
+		calls := make(map[string]int) // stateful callback, only guarded when reading concurrently
+		cfg := Config{Concurrency: concurrency}
+		cfg.WithDataTypeMap(map[string]func(columnType gorm.ColumnType) (dataType string){
+			"bigint": func(columnType gorm.ColumnType) string {
+				if concurrency > 1 {
+					mu.Lock()
+					defer mu.Unlock()
+				}
+				calls[columnType.Name()]++
+				return "int64"
+			},
+		})
+		cfg.WithOpts(FieldComment("name", "name of row"))
+		g := NewGeneratorFromDDL(cfg, schemaFile)
+		opts := make([]ModelOpt, 1, 4) // spare capacity must not be shared by concurrent reads
+		opts[0] = FieldType("name", "string")
+		models := g.GenerateAllTable(opts...)
+		if opts[:2][1] != nil {
+			t.Errorf("concurrency %d expect model options of caller unchanged", concurrency)
+		}
+		if len(models) != 8 || calls["id"] != 8 {
+			t.Fatalf("concurrency %d expect 8 models and data type map calls, got: %d, %d", concurrency, len(models), calls["id"])
+		}
+		for i, m := range models {
+			if name := m.(*generate.QueryStructMeta).TableName; name != fmt.Sprintf("t%d", i) {
+				t.Errorf("concurrency %d expect models in table order, got %s at %d", concurrency, name, i)
+			}
+		}
+	}
+}
+
+// recordLogger logger recording warnings, errors and failed queries
+type recordLogger struct {
+	logger.Interface
+	logs []string
+}
+
+func (l *recordLogger) Warn(_ context.Context, msg string, data ...interface{}) {
+	l.logs = append(l.logs, fmt.Sprintf(msg, data...))
+}
+
+func (l *recordLogger) Error(_ context.Context, msg string, data ...interface{}) {
+	l.logs = append(l.logs, fmt.Sprintf(msg, data...))
+}
+
+func (l *recordLogger) Trace(_ context.Context, _ time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if err != nil {
+		sql, _ := fc()
+		l.logs = append(l.logs, sql+": "+err.Error())
+	}
+}
+
+func TestNewGeneratorFromDDL_Offline(t *testing.T) {
+	schemaFile := filepath.Join(t.TempDir(), "schema.sql")
+	err := os.WriteFile(schemaFile, []byte("CREATE TABLE users (id bigint NOT NULL, name varchar(64), email varchar(64), "+
+		"PRIMARY KEY (id), UNIQUE INDEX idx_email (email), CHECK (id > 0));\nCREATE VIEW user_names AS SELECT id, name FROM users;"), 0640)
+	if err != nil {
+		t.Fatalf("write DDL fail: %s", err)
+	}
+
+	for _, dialect := range []string{"mysql", "postgres", "sqlite", "sqlserver"} {
+		g := NewGeneratorFromDDL(Config{DDLDialect: dialect, FieldReadOnlyGenerated: true, FieldDetectIdentity: true,
+			FieldWithCheckTag: true, FieldWithIndexTag: true, FieldViewNullable: true, FieldOrdinalOrder: true,
+			WithSchemaTableName: true, WithUpdatableColumns: true, WithFunctionalIndexes: true}, schemaFile)
+		record := &recordLogger{Interface: logger.Discard}
+		g.db.Logger = record
+
+		meta := g.GenerateModel("users")
+		if len(record.logs) > 0 {
+			t.Errorf("%s DDL expect no database query, got: %q", dialect, record.logs)
+		}
+		if len(meta.Fields) != 3 || meta.Fields[2].Ordinal != 3 {
+			t.Errorf("%s DDL expect ordinal of declaration order, got: %+v", dialect, meta.Fields)
+		}
+	}
+}
+
+func TestNewGeneratorFromDDL_MatchesDatabase(t *testing.T) {
+	// models of integration test schema read from DDL are the same as expected ones read from database
+	ddl, err := os.ReadFile(filepath.Join("tests", "tables.sql"))
+	if err != nil {
+		t.Fatalf("read tables.sql fail: %s", err)
+	}
+	dir := generateFromDDL(t, Config{Mode: WithDefaultQuery}, string(ddl))
+
+	expectDir := filepath.Join("tests", ".expect", "dal_1", "model")
+	files, err := os.ReadDir(expectDir)
+	if err != nil {
+		t.Fatalf("read expected models fail: %s", err)
+	}
+	for _, file := range files {
+		expected, _ := os.ReadFile(filepath.Join(expectDir, file.Name()))
+		content, err := os.ReadFile(filepath.Join(dir, "model", file.Name()))
+		if err != nil {
+			t.Errorf("model %s expect generated from DDL: %s", file.Name(), err)
+			continue
+		}
+		if string(content) != string(expected) {
+			t.Errorf("model %s from DDL expect:\n%s\ngot:\n%s", file.Name(), expected, content)
+		}
+	}
+}
+
+// generateFromDDL generate models and queries of all tables declared by DDL into testdata of module, so that
+// generated packages can be type checked with module dependencies, model package is ModelPkgPath(default model)
+// under output directory, return output directory
+func generateFromDDL(t *testing.T, cfg Config, ddl string, opts ...ModelOpt) (dir string) {
+	_, err := os.Stat("testdata")
+	if os.IsNotExist(err) {
+		t.Cleanup(func() { _ = os.Remove("testdata") })
+	}
+	if err = os.MkdirAll("testdata", os.ModePerm); err != nil {
+		t.Fatalf("create testdata fail: %s", err)
+	}
+	if dir, err = os.MkdirTemp("testdata", "gen"); err != nil {
+		t.Fatalf("create output dir fail: %s", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	generateIntoDir(t, dir, cfg, ddl, opts...)
+	return dir
+}
+
+// generateIntoDir generate models and queries of all tables declared by DDL into existing output directory dir
+func generateIntoDir(t *testing.T, dir string, cfg Config, ddl string, opts ...ModelOpt) {
+	schemaFile := filepath.Join(dir, "schema.sql")
+	if err := os.WriteFile(schemaFile, []byte(ddl), 0640); err != nil {
+		t.Fatalf("write DDL fail: %s", err)
+	}
+	if cfg.ModelPkgPath == "" {
+		cfg.ModelPkgPath = "model"
+	}
+	cfg.OutPath, cfg.ModelPkgPath = filepath.Join(dir, "query"), filepath.Join(dir, cfg.ModelPkgPath)
+	g := NewGeneratorFromDDL(cfg, schemaFile)
+	g.ApplyBasic(g.GenerateAllTable(opts...)...)
+	g.Execute()
+}
+
+// checkGeneratedPackages type check generated packages in dir
+func checkGeneratedPackages(t *testing.T, dir string, pkgs ...string) {
+	patterns := make([]string, len(pkgs))
+	for i, pkg := range pkgs {
+		patterns[i] = "./" + filepath.ToSlash(filepath.Join(dir, pkg))
+	}
+	loaded, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes}, patterns...)
+	if err != nil {
+		t.Fatalf("load generated packages fail: %s", err)
+	}
+	for _, pkg := range loaded {
+		for _, e := range pkg.Errors {
+			t.Errorf("generated package %s: %s", pkg.PkgPath, e)
+		}
+	}
+}
+
 // test data
 type mysqlDialectors struct{ tests.DummyDialector }
 
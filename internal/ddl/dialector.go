@@ -0,0 +1,167 @@
+package ddl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen/internal/model"
+)
+
+// ErrOffline error of query executed without database connection, metadata beyond DDL(e.g. check constraints
+// of mysql information_schema) is not available
+var ErrOffline = errors.New("no database connection, tables are read from DDL")
+
+// Dialector gorm dialector serving table metadata of DDL schema through migrator, named after dialect of DDL
+// so that dialect specific mapping applies, queries fail with ErrOffline
+type Dialector struct {
+	*Schema
+}
+
+// Name dialect of DDL
+func (d Dialector) Name() string { return d.Dialect }
+
+// Initialize register default callbacks with connection pool failing every query
+func (d Dialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	db.ConnPool = offlineConnPool{}
+	return nil
+}
+
+// Migrator migrator reading DDL schema
+func (d Dialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return Migrator{Migrator: migrator.Migrator{Config: migrator.Config{DB: db, Dialector: d}}, schema: d.Schema}
+}
+
+// DataTypeOf not supported, tables are not migrated
+func (d Dialector) DataTypeOf(*schema.Field) string { return "" }
+
+// DefaultValueOf default value expression
+func (d Dialector) DefaultValueOf(*schema.Field) clause.Expression {
+	return clause.Expr{SQL: "DEFAULT"}
+}
+
+// BindVarTo write bind var
+func (d Dialector) BindVarTo(writer clause.Writer, _ *gorm.Statement, _ interface{}) {
+	_ = writer.WriteByte('?')
+}
+
+// QuoteTo write quoted identifier, backtick for mysql, double quote for others
+func (d Dialector) QuoteTo(writer clause.Writer, str string) {
+	quote := byte('"')
+	if d.Dialect == "mysql" {
+		quote = '`'
+	}
+	_ = writer.WriteByte(quote)
+	_, _ = writer.WriteString(str)
+	_ = writer.WriteByte(quote)
+}
+
+// Explain explain sql with vars
+func (d Dialector) Explain(sql string, vars ...interface{}) string {
+	return logger.ExplainSQL(sql, nil, `'`, vars...)
+}
+
+// Migrator migrator reading tables, columns, indexes and foreign keys from DDL schema, schema change is not supported
+type Migrator struct {
+	migrator.Migrator
+	schema *Schema
+}
+
+// CurrentDatabase empty, DDL is not bound to database
+func (m Migrator) CurrentDatabase() string { return "" }
+
+// GetTables tables in declaration order
+func (m Migrator) GetTables() (tableList []string, err error) {
+	for _, t := range m.schema.Tables {
+		tableList = append(tableList, t.Name)
+	}
+	return tableList, nil
+}
+
+// HasTable check if table is declared
+func (m Migrator) HasTable(value interface{}) bool {
+	_, err := m.table(value)
+	return err == nil
+}
+
+// TableType table type with comment
+func (m Migrator) TableType(value interface{}) (gorm.TableType, error) {
+	table, err := m.table(value)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.TableType{NameValue: table.Name, TypeValue: "BASE TABLE", CommentValue: table.Comment}, nil
+}
+
+// ColumnTypes column types in declaration order
+func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
+	table, err := m.table(value)
+	if err != nil {
+		return nil, err
+	}
+	columnTypes := make([]gorm.ColumnType, len(table.Columns))
+	for i, c := range table.Columns {
+		columnTypes[i] = *c
+	}
+	return columnTypes, nil
+}
+
+// GetIndexes indexes including primary key
+func (m Migrator) GetIndexes(value interface{}) ([]gorm.Index, error) {
+	table, err := m.table(value)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]gorm.Index, len(table.Indexes))
+	for i, idx := range table.Indexes {
+		indexes[i] = *idx
+	}
+	return indexes, nil
+}
+
+// GetForeignKeys foreign keys of table, one per column
+func (m Migrator) GetForeignKeys(tableName string) ([]*model.ForeignKey, error) {
+	table, err := m.table(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return table.ForeignKeys, nil
+}
+
+func (m Migrator) table(value interface{}) (table *Table, err error) {
+	err = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if table = m.schema.Table(stmt.Table); table == nil {
+			return fmt.Errorf("table %s is not declared in DDL", stmt.Table)
+		}
+		return nil
+	})
+	return table, err
+}
+
+type offlineConnPool struct{}
+
+func (offlineConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, ErrOffline
+}
+
+func (offlineConnPool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, ErrOffline
+}
+
+func (offlineConnPool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, ErrOffline
+}
+
+// QueryRowContext nil row, sql.Row carrying error cannot be constructed, Row() is not used by generator
+func (offlineConnPool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
@@ -0,0 +1,153 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // keyword, bare identifier or number
+	tokenQuoted                  // quoted identifier, e.g. `name`, "name", [name]
+	tokenString                  // string literal, e.g. 'abc', $$abc$$
+	tokenSymbol                  // punctuation or operator, e.g. ( ) , ; . = ::
+)
+
+type token struct {
+	kind  tokenKind
+	text  string // unquoted text of quoted identifier and string literal, raw text of others
+	start int    // offset of token in source
+	end   int
+}
+
+// is check if token is the keyword or symbol, case-insensitive
+func (t token) is(text string) bool {
+	return (t.kind == tokenWord || t.kind == tokenSymbol) && strings.EqualFold(t.text, text)
+}
+
+// isIdent check if token can be used as identifier
+func (t token) isIdent() bool {
+	return t.kind == tokenWord || t.kind == tokenQuoted
+}
+
+// tokenize split sql into tokens, comments are dropped, backslash escape is decoded in string literal if backslash(mysql)
+func tokenize(src string, backslash bool) (tokens []token, err error) {
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+		case c == '-' && strings.HasPrefix(src[i:], "--"), c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '/' && strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment at offset %d", i)
+			}
+			i += end + 4
+		case c == '\'':
+			end, text, err := scanQuoted(src, i, '\'', backslash)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, start: i, end: end})
+			i = end
+		case c == '`' || c == '"' || c == '[' && !isArraySuffix(src, i):
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			end, text, err := scanQuoted(src, i, closing, false)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenQuoted, text: text, start: i, end: end})
+			i = end
+		case c == '$' && dollarTag(src[i:]) != "":
+			tag := dollarTag(src[i:])
+			end := strings.Index(src[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated dollar-quoted string at offset %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: src[i+len(tag) : i+len(tag)+end], start: i, end: i + len(tag) + end + len(tag)})
+			i += len(tag) + end + len(tag)
+		case isWordChar(c):
+			start := i
+			for i < len(src) && (isWordChar(src[i]) || src[i] == '.' && isDigits(src[start:i]) && i+1 < len(src) && isDigit(src[i+1])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: src[start:i], start: start, end: i})
+		case c == ':' && strings.HasPrefix(src[i:], "::"):
+			tokens = append(tokens, token{kind: tokenSymbol, text: "::", start: i, end: i + 2})
+			i += 2
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, text: src[i : i+1], start: i, end: i + 1})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// mysqlEscapes decoded char of mysql backslash escape in string literal, other escaped char is itself
+var mysqlEscapes = map[byte]byte{'0': 0, 'b': '\b', 'n': '\n', 'r': '\r', 't': '\t', 'Z': 0x1a}
+
+// scanQuoted scan quoted text starting at src[start], doubled closing char is escaped,
+// backslash escape is decoded if backslash, return offset after closing char
+func scanQuoted(src string, start int, closing byte, backslash bool) (end int, text string, err error) {
+	var b strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		switch c := src[i]; {
+		case c == '\\' && backslash && i+1 < len(src):
+			i++
+			if decoded, ok := mysqlEscapes[src[i]]; ok {
+				b.WriteByte(decoded)
+			} else {
+				b.WriteByte(src[i])
+			}
+		case c == closing && i+1 < len(src) && src[i+1] == closing:
+			i++
+			b.WriteByte(c)
+		case c == closing:
+			return i + 1, b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return 0, "", fmt.Errorf("unterminated quoted text at offset %d", start)
+}
+
+// isArraySuffix check if [ at src[i] is postgres array suffix of type, e.g. text[], int[3]
+func isArraySuffix(src string, i int) bool {
+	return i+1 < len(src) && (src[i+1] == ']' || isDigit(src[i+1]))
+}
+
+// dollarTag tag of postgres dollar-quoted string, e.g. $$, $body$
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '$' {
+			return s[:i+1]
+		}
+		if !isWordChar(s[i]) {
+			return ""
+		}
+	}
+	return ""
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return s != ""
+}
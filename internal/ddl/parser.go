@@ -0,0 +1,978 @@
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/migrator"
+
+	"gorm.io/gen/internal/model"
+)
+
+// Schema tables declared by DDL statements, in declaration order
+type Schema struct {
+	Dialect string
+	Tables  []*Table
+}
+
+// Table table declared by CREATE TABLE statement and altered by later statements
+type Table struct {
+	Name        string
+	Comment     sql.NullString
+	Columns     []*migrator.ColumnType
+	Indexes     []*migrator.Index
+	ForeignKeys []*model.ForeignKey
+}
+
+// NewSchema create empty schema of dialect, dialect decides naming of unnamed index and case folding of identifier
+func NewSchema(dialect string) *Schema {
+	return &Schema{Dialect: dialect}
+}
+
+// Table get declared table by name, exact match first then case-insensitive
+func (s *Schema) Table(name string) *Table {
+	for _, t := range s.Tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	for _, t := range s.Tables {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Schema) setTable(table *Table) {
+	for i, t := range s.Tables {
+		if t.Name == table.Name {
+			s.Tables[i] = table
+			return
+		}
+	}
+	s.Tables = append(s.Tables, table)
+}
+
+func (s *Schema) dropTable(name string) {
+	for i, t := range s.Tables {
+		if t.Name == name || strings.EqualFold(t.Name, name) {
+			s.Tables = append(s.Tables[:i], s.Tables[i+1:]...)
+			return
+		}
+	}
+}
+
+// ParseFiles parse DDL files in order, directory is read as its .sql files in name order(e.g. numbered migrations)
+func (s *Schema) ParseFiles(paths ...string) error {
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		files := []string{path}
+		if info.IsDir() {
+			if files, err = filepath.Glob(filepath.Join(path, "*.sql")); err != nil {
+				return err
+			}
+		}
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if err = s.Parse(string(content)); err != nil {
+				return fmt.Errorf("parse %s fail: %w", file, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Parse parse DDL statements separated by semicolon, CREATE TABLE, CREATE INDEX, ALTER TABLE ADD/DROP,
+// DROP TABLE and COMMENT ON are applied to schema, other statements are ignored
+func (s *Schema) Parse(src string) error {
+	tokens, err := tokenize(src, s.Dialect == "mysql")
+	if err != nil {
+		return err
+	}
+	for len(tokens) > 0 {
+		end := 0
+		for end < len(tokens) && !tokens[end].is(";") {
+			end++
+		}
+		if end > 0 {
+			p := &parser{schema: s, src: src, tokens: tokens[:end]}
+			if err = p.statement(); err != nil {
+				return fmt.Errorf("statement at line %d: %w", strings.Count(src[:tokens[0].start], "\n")+1, err)
+			}
+		}
+		if end == len(tokens) {
+			break
+		}
+		tokens = tokens[end+1:]
+	}
+	return nil
+}
+
+type parser struct {
+	schema *Schema
+	src    string
+	tokens []token
+	pos    int
+}
+
+// peek token at offset n from current, empty symbol if out of range
+func (p *parser) peek(n int) token {
+	if p.pos+n < len(p.tokens) {
+		return p.tokens[p.pos+n]
+	}
+	return token{kind: tokenSymbol}
+}
+
+func (p *parser) next() token {
+	t := p.peek(0)
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.tokens) }
+
+// accept consume keywords or symbols if all match in sequence
+func (p *parser) accept(words ...string) bool {
+	for i, w := range words {
+		if !p.peek(i).is(w) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+// acceptOne consume keyword or symbol if it matches any of words
+func (p *parser) acceptOne(words ...string) bool {
+	for _, w := range words {
+		if p.accept(w) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(word string) error {
+	if !p.accept(word) {
+		return p.errorf("expect %s", word)
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	near := "end of statement"
+	if !p.eof() {
+		near = strconv.Quote(p.peek(0).text)
+	}
+	return fmt.Errorf(format+" near %s", append(args, near)...)
+}
+
+// name parse possibly qualified name, e.g. db.table, schema.table.column, unquoted name is lower-cased for postgres
+func (p *parser) name() (parts []string, err error) {
+	for {
+		t := p.next()
+		if !t.isIdent() {
+			return nil, p.errorf("expect name")
+		}
+		if t.kind == tokenWord && p.schema.Dialect == "postgres" {
+			t.text = strings.ToLower(t.text)
+		}
+		parts = append(parts, t.text)
+		if !p.peek(0).is(".") || !p.peek(1).isIdent() {
+			return parts, nil
+		}
+		p.pos++
+	}
+}
+
+// ident parse name, schema qualifier is dropped
+func (p *parser) ident() (string, error) {
+	parts, err := p.name()
+	if err != nil {
+		return "", err
+	}
+	return parts[len(parts)-1], nil
+}
+
+// group consume parenthesized group at current position, return tokens inside split by top-level comma
+func (p *parser) group() (items [][]token, err error) {
+	if err = p.expect("("); err != nil {
+		return nil, err
+	}
+	var item []token
+	for depth := 0; ; {
+		if p.eof() {
+			return nil, p.errorf("unbalanced parentheses")
+		}
+		t := p.next()
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")") && depth == 0:
+			return append(items, item), nil
+		case t.is(")"):
+			depth--
+		case t.is(",") && depth == 0:
+			items, item = append(items, item), nil
+			continue
+		}
+		item = append(item, t)
+	}
+}
+
+// skipElement skip tokens until top-level comma or closing parenthesis, which is not consumed
+func (p *parser) skipElement() error {
+	for !p.eof() && !p.peek(0).is(",") && !p.peek(0).is(")") {
+		if p.peek(0).is("(") {
+			if _, err := p.group(); err != nil {
+				return err
+			}
+			continue
+		}
+		p.pos++
+	}
+	return nil
+}
+
+// raw source text of tokens
+func (p *parser) raw(tokens []token) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	return p.src[tokens[0].start:tokens[len(tokens)-1].end]
+}
+
+func (p *parser) statement() error {
+	switch {
+	case p.accept("CREATE"):
+		p.accept("OR", "REPLACE")
+		for p.acceptOne("TEMPORARY", "TEMP", "UNLOGGED", "GLOBAL", "LOCAL") {
+		}
+		if p.accept("TABLE") {
+			return p.createTable()
+		}
+		return p.createIndex()
+	case p.accept("ALTER", "TABLE"):
+		return p.alterTable()
+	case p.accept("DROP", "TABLE"):
+		p.accept("IF", "EXISTS")
+		for {
+			name, err := p.ident()
+			if err != nil {
+				return err
+			}
+			p.schema.dropTable(name)
+			if !p.accept(",") {
+				return nil
+			}
+		}
+	case p.accept("COMMENT", "ON"):
+		return p.commentOn()
+	}
+	return nil
+}
+
+func (p *parser) createTable() error {
+	p.accept("IF", "NOT", "EXISTS")
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	if !p.peek(0).is("(") { // CREATE TABLE ... AS SELECT or LIKE, columns are unknown
+		return nil
+	}
+	p.pos++
+
+	table := &Table{Name: name}
+	for {
+		if err = p.tableElement(table); err != nil {
+			return err
+		}
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err = p.expect(")"); err != nil {
+		return err
+	}
+	for !p.eof() { // table options
+		if p.accept("COMMENT") {
+			p.accept("=")
+			if t := p.next(); t.kind == tokenString {
+				table.Comment = sql.NullString{String: t.text, Valid: true}
+			}
+			continue
+		}
+		p.pos++
+	}
+	for _, idx := range table.Indexes { // constraint may be declared before column
+		table.markColumns(idx)
+	}
+	p.schema.setTable(table)
+	return nil
+}
+
+// constraintStarts keywords starting table constraint instead of column definition
+var constraintStarts = map[string]bool{
+	"CONSTRAINT": true, "PRIMARY": true, "UNIQUE": true, "KEY": true, "INDEX": true, "FULLTEXT": true,
+	"SPATIAL": true, "FOREIGN": true, "CHECK": true, "EXCLUDE": true, "LIKE": true, "PERIOD": true,
+}
+
+func (p *parser) tableElement(table *Table) error {
+	if t := p.peek(0); t.kind == tokenWord && constraintStarts[strings.ToUpper(t.text)] {
+		return p.tableConstraint(table)
+	}
+	return p.column(table)
+}
+
+func (p *parser) tableConstraint(table *Table) (err error) {
+	var name string
+	if p.accept("CONSTRAINT") && !constraintStarts[strings.ToUpper(p.peek(0).text)] {
+		if name, err = p.ident(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.accept("PRIMARY", "KEY"):
+		columns, expression, err := p.indexColumns()
+		if err != nil || expression {
+			return err
+		}
+		if name == "" {
+			name = p.primaryKeyName(table)
+		}
+		table.addIndex(&migrator.Index{TableName: table.Name, NameValue: name, ColumnList: columns,
+			PrimaryKeyValue: sql.NullBool{Bool: true, Valid: true}, UniqueValue: sql.NullBool{Bool: true, Valid: true}})
+	case p.accept("UNIQUE"), p.acceptOne("KEY", "INDEX"), p.acceptOne("FULLTEXT", "SPATIAL"):
+		unique, option := p.tokens[p.pos-1].is("UNIQUE"), ""
+		if t := p.tokens[p.pos-1]; t.is("FULLTEXT") || t.is("SPATIAL") {
+			option = strings.ToUpper(t.text)
+		}
+		p.acceptOne("KEY", "INDEX")
+		if t := p.peek(0); t.isIdent() && !t.is("USING") && !t.is("NULLS") {
+			if name, err = p.ident(); err != nil {
+				return err
+			}
+		}
+		columns, expression, err := p.indexColumns()
+		if err != nil || expression {
+			return err
+		}
+		p.addIndex(table, name, columns, unique, option)
+	case p.accept("FOREIGN", "KEY"):
+		if t := p.peek(0); t.isIdent() {
+			if name, err = p.ident(); err != nil {
+				return err
+			}
+		}
+		columns, _, err := p.indexColumns()
+		if err != nil {
+			return err
+		}
+		if err = p.expect("REFERENCES"); err != nil {
+			return err
+		}
+		if err = p.references(table, name, columns); err != nil {
+			return err
+		}
+	}
+	return p.skipElement()
+}
+
+// indexColumns parse column list of index, expression reports whether index has expression part(functional index)
+func (p *parser) indexColumns() (columns []string, expression bool, err error) {
+	if p.accept("USING") { // mysql index type, e.g. USING BTREE
+		p.pos++
+	}
+	items, err := p.group()
+	if err != nil {
+		return nil, false, err
+	}
+	for _, item := range items {
+		if len(item) == 0 || !item[0].isIdent() {
+			expression = true
+			continue
+		}
+		// prefix length of mysql, e.g. name(10), otherwise function call, e.g. lower(name)
+		if len(item) > 1 && item[1].is("(") && !(len(item) > 3 && isDigits(item[2].text) && item[3].is(")")) {
+			expression = true
+			continue
+		}
+		name := item[0].text
+		if item[0].kind == tokenWord && p.schema.Dialect == "postgres" {
+			name = strings.ToLower(name)
+		}
+		columns = append(columns, name)
+	}
+	return columns, expression, nil
+}
+
+// addIndex add unique or plain index, unnamed index is named as dialect does
+func (p *parser) addIndex(table *Table, name string, columns []string, unique bool, option string) {
+	if len(columns) == 0 {
+		return
+	}
+	if name == "" {
+		name = p.indexName(table, columns, unique)
+	}
+	table.addIndex(&migrator.Index{TableName: table.Name, NameValue: name, ColumnList: columns, OptionValue: option,
+		PrimaryKeyValue: sql.NullBool{Bool: false, Valid: true}, UniqueValue: sql.NullBool{Bool: unique, Valid: true}})
+}
+
+// indexName name of unnamed index, postgres: table_columns_key(unique) or table_columns_idx, others: first column name
+func (p *parser) indexName(table *Table, columns []string, unique bool) string {
+	base := columns[0]
+	if p.schema.Dialect == "postgres" {
+		suffix := "idx"
+		if unique {
+			suffix = "key"
+		}
+		base = table.Name + "_" + strings.Join(columns, "_") + "_" + suffix
+	}
+	name := base
+	for i := 2; table.index(name) != nil; i++ {
+		name = base + "_" + strconv.Itoa(i)
+	}
+	return name
+}
+
+func (p *parser) primaryKeyName(table *Table) string {
+	if p.schema.Dialect == "postgres" {
+		return table.Name + "_pkey"
+	}
+	return "PRIMARY"
+}
+
+// references parse referenced table and columns after REFERENCES, unnamed foreign key is named as dialect does
+func (p *parser) references(table *Table, name string, columns []string) error {
+	refTable, err := p.ident()
+	if err != nil {
+		return err
+	}
+	var refColumns []string
+	if p.peek(0).is("(") {
+		if refColumns, _, err = p.indexColumns(); err != nil {
+			return err
+		}
+	}
+	for { // referential actions, e.g. ON DELETE SET NULL, DEFERRABLE INITIALLY DEFERRED
+		switch {
+		case p.accept("ON"):
+			p.pos++ // DELETE or UPDATE
+			p.acceptOne("SET", "NO")
+			p.pos++
+		case p.accept("MATCH"), p.accept("INITIALLY"):
+			p.pos++
+		case p.accept("NOT", "DEFERRABLE"), p.accept("DEFERRABLE"):
+		default:
+			if name == "" {
+				name = p.foreignKeyName(table, columns)
+			}
+			for i, column := range columns {
+				fk := &model.ForeignKey{Name: name, Column: column, RefTable: refTable}
+				if i < len(refColumns) {
+					fk.RefColumn = refColumns[i]
+				}
+				table.ForeignKeys = append(table.ForeignKeys, fk)
+			}
+			return nil
+		}
+	}
+}
+
+// foreignKeyName name of unnamed foreign key, postgres: table_columns_fkey, others: table_ibfk_N
+func (p *parser) foreignKeyName(table *Table, columns []string) string {
+	if p.schema.Dialect == "postgres" {
+		return table.Name + "_" + strings.Join(columns, "_") + "_fkey"
+	}
+	names := make(map[string]bool)
+	for _, fk := range table.ForeignKeys {
+		names[fk.Name] = true
+	}
+	return table.Name + "_ibfk_" + strconv.Itoa(len(names)+1)
+}
+
+// columnConstraintStarts keywords ending data type of column definition
+var columnConstraintStarts = map[string]bool{
+	"NOT": true, "NULL": true, "DEFAULT": true, "PRIMARY": true, "UNIQUE": true, "KEY": true, "COMMENT": true,
+	"AUTO_INCREMENT": true, "AUTOINCREMENT": true, "IDENTITY": true, "REFERENCES": true, "CHECK": true,
+	"CONSTRAINT": true, "GENERATED": true, "AS": true, "COLLATE": true, "CHARSET": true, "ON": true,
+	"VISIBLE": true, "INVISIBLE": true, "STORAGE": true, "COLUMN_FORMAT": true, "SRID": true,
+}
+
+func (p *parser) column(table *Table) error {
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	col := &migrator.ColumnType{
+		NameValue:          sql.NullString{String: name, Valid: true},
+		PrimaryKeyValue:    sql.NullBool{Bool: false, Valid: true},
+		UniqueValue:        sql.NullBool{Bool: false, Valid: true},
+		AutoIncrementValue: sql.NullBool{Bool: false, Valid: true},
+		NullableValue:      sql.NullBool{Bool: true, Valid: true},
+	}
+	if err = p.dataType(col); err != nil {
+		return err
+	}
+	table.dropColumn(name)
+	table.Columns = append(table.Columns, col)
+
+	for !p.eof() && !p.peek(0).is(",") && !p.peek(0).is(")") {
+		switch {
+		case p.accept("NOT", "NULL"):
+			col.NullableValue.Bool = false
+		case p.accept("NULL"):
+			col.NullableValue.Bool = true
+		case p.accept("DEFAULT"):
+			start := p.pos
+			value, err := p.expression()
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(strings.ToLower(value), "nextval(") { // postgres sequence
+				col.AutoIncrementValue.Bool = true
+			} else if !strings.EqualFold(value, "NULL") {
+				col.DefaultValueValue = sql.NullString{String: p.reportedDefault(value, p.tokens[start:p.pos]), Valid: true}
+			}
+		case p.accept("PRIMARY", "KEY"):
+			p.acceptOne("ASC", "DESC")
+			table.addIndex(&migrator.Index{TableName: table.Name, NameValue: p.primaryKeyName(table), ColumnList: []string{name},
+				PrimaryKeyValue: sql.NullBool{Bool: true, Valid: true}, UniqueValue: sql.NullBool{Bool: true, Valid: true}})
+		case p.accept("UNIQUE"):
+			p.acceptOne("KEY", "INDEX")
+			p.addIndex(table, "", []string{name}, true, "")
+		case p.acceptOne("AUTO_INCREMENT", "AUTOINCREMENT"):
+			col.AutoIncrementValue.Bool = true
+		case p.accept("IDENTITY"): // sqlserver, e.g. IDENTITY(1,1)
+			col.AutoIncrementValue.Bool = true
+			if p.peek(0).is("(") {
+				if _, err = p.group(); err != nil {
+					return err
+				}
+			}
+		case p.accept("GENERATED"):
+			if !p.accept("ALWAYS") {
+				p.accept("BY", "DEFAULT")
+			}
+			if p.accept("AS", "IDENTITY") {
+				col.AutoIncrementValue.Bool = true
+			} else if !p.accept("AS") {
+				continue
+			}
+			if p.peek(0).is("(") { // identity options or generation expression
+				if _, err = p.group(); err != nil {
+					return err
+				}
+			}
+		case p.accept("COMMENT"):
+			if t := p.next(); t.kind == tokenString {
+				col.CommentValue = sql.NullString{String: t.text, Valid: true}
+			}
+		case p.accept("REFERENCES"):
+			if err = p.references(table, "", []string{name}); err != nil {
+				return err
+			}
+		case p.accept("ON", "UPDATE"): // mysql, e.g. ON UPDATE CURRENT_TIMESTAMP
+			if _, err = p.expression(); err != nil {
+				return err
+			}
+		case p.accept("CONSTRAINT"):
+			if _, err = p.ident(); err != nil {
+				return err
+			}
+		case p.accept("CHARACTER", "SET"), p.acceptOne("CHARSET", "COLLATE"):
+			p.pos++
+		case p.peek(0).is("("): // e.g. CHECK (...), AS (...)
+			if _, err = p.group(); err != nil {
+				return err
+			}
+		default:
+			p.pos++
+		}
+	}
+	if pk, _ := col.PrimaryKey(); pk {
+		col.NullableValue.Bool = false
+	}
+	return nil
+}
+
+// expression parse value of DEFAULT or ON UPDATE, return raw text, e.g. 'abc', -1, now(), b'0', 'a'::character varying
+func (p *parser) expression() (string, error) {
+	start := p.pos
+	for p.acceptOne("-", "+") {
+	}
+	switch t := p.next(); {
+	case t.is("("):
+		p.pos--
+		if _, err := p.group(); err != nil {
+			return "", err
+		}
+	case t.kind == tokenWord && p.peek(0).is("("): // function call
+		if _, err := p.group(); err != nil {
+			return "", err
+		}
+	case t.kind == tokenWord && p.peek(0).kind == tokenString && p.peek(0).start == t.end: // prefixed literal, e.g. b'0', x'ff', N'abc'
+		p.pos++
+	case t.kind == tokenSymbol:
+		return "", p.errorf("expect expression")
+	}
+	for p.accept("::") { // postgres type cast
+		if err := p.dataType(&migrator.ColumnType{}); err != nil {
+			return "", err
+		}
+	}
+	return p.raw(p.tokens[start:p.pos]), nil
+}
+
+// castLiteralRegexp literal with single word type cast, unquoted by postgres driver, e.g. 'abc'::text
+var castLiteralRegexp = regexp.MustCompile(`'(.*)'::[\w]+$`)
+
+// reportedDefault default value as driver of dialect reports it, so that DDL and database give the same model,
+// mysql: string literal is unquoted(e.g. '666' -> 666), postgres: literal cast to single word type is unquoted
+func (p *parser) reportedDefault(value string, tokens []token) string {
+	switch p.schema.Dialect {
+	case "mysql":
+		if len(tokens) == 1 && tokens[0].kind == tokenString {
+			return strings.Trim(tokens[0].text, "'")
+		}
+	case "postgres":
+		return castLiteralRegexp.ReplaceAllString(value, "$1")
+	}
+	return value
+}
+
+// dataTypeAliases canonical data type of alias, used as database type name for data type mapping
+var dataTypeAliases = map[string]string{
+	"int2":                        "smallint",
+	"int4":                        "int",
+	"int8":                        "bigint",
+	"smallserial":                 "smallint",
+	"serial2":                     "smallint",
+	"serial":                      "int",
+	"serial4":                     "int",
+	"bigserial":                   "bigint",
+	"serial8":                     "bigint",
+	"float4":                      "float",
+	"float8":                      "double",
+	"double precision":            "double",
+	"dec":                         "decimal",
+	"fixed":                       "decimal",
+	"bool":                        "boolean",
+	"character":                   "char",
+	"character varying":           "varchar",
+	"nchar":                       "char",
+	"nvarchar":                    "varchar",
+	"ntext":                       "text",
+	"bytea":                       "blob",
+	"timestamptz":                 "timestamp",
+	"timestamp with time zone":    "timestamp",
+	"timestamp without time zone": "timestamp",
+	"timetz":                      "time",
+	"time with time zone":         "time",
+	"time without time zone":      "time",
+	"datetime2":                   "datetime",
+	"smalldatetime":               "datetime",
+}
+
+// dataType parse data type of column definition into database type name(canonical, lower-cased, without arguments),
+// column type(as declared, e.g. varchar(64), int(10) unsigned, text[]), length, decimal size and scan type
+func (p *parser) dataType(col *migrator.ColumnType) error {
+	var names, words []string
+	var args [][]token
+	for {
+		t := p.peek(0)
+		switch {
+		case t.kind == tokenWord && !columnConstraintStarts[strings.ToUpper(t.text)] && !(t.is("CHARACTER") && p.peek(1).is("SET")):
+			p.pos++
+			word := strings.ToLower(t.text)
+			if word != "unsigned" && word != "signed" && word != "zerofill" && (word != "binary" || len(words) == 0) {
+				names = append(names, word)
+			}
+			words = append(words, word)
+			continue
+		case t.is("(") && len(words) > 0 && args == nil:
+			items, err := p.group()
+			if err != nil {
+				return err
+			}
+			parts := make([]string, len(items))
+			for i, item := range items {
+				parts[i] = p.raw(item)
+			}
+			args = items
+			words[len(words)-1] += "(" + strings.Join(parts, ",") + ")"
+			continue
+		case t.is("[") && len(words) > 0: // postgres array, e.g. text[], int[3]
+			for !p.eof() && !p.next().is("]") {
+			}
+			words[len(words)-1] += "[]"
+			continue
+		}
+		break
+	}
+	if len(words) == 0 { // sqlite column without type
+		col.DataTypeValue = sql.NullString{String: "", Valid: true}
+		col.ColumnTypeValue = sql.NullString{String: "", Valid: true}
+		col.ScanTypeValue = reflect.TypeOf("")
+		return nil
+	}
+
+	name := strings.Join(names, " ")
+	if strings.HasSuffix(name, "serial") || strings.HasPrefix(name, "serial") { // postgres serial types
+		col.AutoIncrementValue = sql.NullBool{Bool: true, Valid: true}
+	}
+	if alias, ok := dataTypeAliases[name]; ok {
+		name = alias
+	}
+	columnType := strings.Join(words, " ")
+	if strings.HasSuffix(columnType, "[]") { // same as postgres reports, e.g. _text
+		name = "_" + name
+	}
+	col.DataTypeValue = sql.NullString{String: name, Valid: true}
+	col.ColumnTypeValue = sql.NullString{String: columnType, Valid: true}
+	col.ScanTypeValue = scanType(name)
+
+	col.LengthValue = sql.NullInt64{Valid: true}
+	col.DecimalSizeValue = sql.NullInt64{Valid: true}
+	col.ScaleValue = sql.NullInt64{Valid: true}
+	switch name {
+	case "char", "varchar", "binary", "varbinary":
+		if len(args) == 1 {
+			col.LengthValue.Int64, _ = strconv.ParseInt(p.raw(args[0]), 10, 64)
+		}
+	case "decimal", "numeric":
+		if len(args) > 0 {
+			col.DecimalSizeValue.Int64, _ = strconv.ParseInt(p.raw(args[0]), 10, 64)
+		}
+		if len(args) > 1 {
+			col.ScaleValue.Int64, _ = strconv.ParseInt(p.raw(args[1]), 10, 64)
+		}
+	}
+	return nil
+}
+
+// scanType scan type of data type like drivers report, used when dialect maps column by scan type(e.g. postgres)
+func scanType(dataType string) reflect.Type {
+	switch dataType {
+	case "tinyint", "smallint":
+		return reflect.TypeOf(int16(0))
+	case "mediumint", "int", "integer":
+		return reflect.TypeOf(int32(0))
+	case "bigint":
+		return reflect.TypeOf(int64(0))
+	case "float", "real":
+		return reflect.TypeOf(float32(0))
+	case "double", "decimal", "numeric":
+		return reflect.TypeOf(float64(0))
+	case "boolean":
+		return reflect.TypeOf(false)
+	case "date", "time", "datetime", "timestamp":
+		return reflect.TypeOf(time.Time{})
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return reflect.TypeOf([]byte{})
+	}
+	return reflect.TypeOf("")
+}
+
+func (p *parser) createIndex() error {
+	unique, option := p.accept("UNIQUE"), ""
+	if p.acceptOne("FULLTEXT", "SPATIAL") {
+		option = strings.ToUpper(p.tokens[p.pos-1].text)
+	}
+	if !p.accept("INDEX") { // other objects, e.g. view, function, sequence
+		return nil
+	}
+	p.accept("CONCURRENTLY")
+	p.accept("IF", "NOT", "EXISTS")
+	var name string
+	var err error
+	if !p.peek(0).is("ON") {
+		if name, err = p.ident(); err != nil {
+			return err
+		}
+	}
+	if p.accept("USING") {
+		p.pos++
+	}
+	if err = p.expect("ON"); err != nil {
+		return err
+	}
+	p.accept("ONLY")
+	tableName, err := p.ident()
+	if err != nil {
+		return err
+	}
+	columns, expression, err := p.indexColumns()
+	if table := p.schema.Table(tableName); table != nil && err == nil && !expression {
+		p.addIndex(table, name, columns, unique, option)
+	}
+	return err
+}
+
+func (p *parser) alterTable() error {
+	p.accept("IF", "EXISTS")
+	p.accept("ONLY")
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	table := p.schema.Table(name)
+	if table == nil { // table created outside of DDL files
+		return nil
+	}
+	for !p.eof() {
+		switch {
+		case p.accept("ADD"):
+			if p.accept("COLUMN") {
+				p.accept("IF", "NOT", "EXISTS")
+				err = p.column(table)
+			} else {
+				err = p.tableElement(table)
+			}
+		case p.accept("DROP"):
+			if p.acceptOne("INDEX", "KEY", "CONSTRAINT") {
+				p.accept("IF", "EXISTS")
+				if name, err = p.ident(); err == nil {
+					table.dropIndex(name)
+				}
+			} else if p.accept("PRIMARY", "KEY") {
+				table.dropIndex(p.primaryKeyName(table))
+			} else if !p.acceptOne("FOREIGN", "CHECK") {
+				p.accept("COLUMN")
+				p.accept("IF", "EXISTS")
+				if name, err = p.ident(); err == nil {
+					table.dropColumn(name)
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err = p.skipElement(); err != nil {
+			return err
+		}
+		if !p.accept(",") {
+			break
+		}
+	}
+	return nil
+}
+
+// commentOn parse postgres COMMENT ON TABLE/COLUMN statement
+func (p *parser) commentOn() error {
+	isTable := p.accept("TABLE")
+	if !isTable && !p.accept("COLUMN") {
+		return nil
+	}
+	parts, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err = p.expect("IS"); err != nil {
+		return err
+	}
+	comment := sql.NullString{String: p.peek(0).text, Valid: p.peek(0).kind == tokenString}
+	if isTable {
+		if table := p.schema.Table(parts[len(parts)-1]); table != nil {
+			table.Comment = comment
+		}
+		return nil
+	}
+	if len(parts) < 2 {
+		return p.errorf("expect table name of column")
+	}
+	if table := p.schema.Table(parts[len(parts)-2]); table != nil {
+		if col := table.column(parts[len(parts)-1]); col != nil {
+			col.CommentValue = comment
+		}
+	}
+	return nil
+}
+
+func (t *Table) column(name string) *migrator.ColumnType {
+	for _, c := range t.Columns {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (t *Table) dropColumn(name string) {
+	for i, c := range t.Columns {
+		if c.Name() == name {
+			t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *Table) index(name string) *migrator.Index {
+	for _, idx := range t.Indexes {
+		if idx.NameValue == name {
+			return idx
+		}
+	}
+	return nil
+}
+
+// addIndex add index and mark its columns as primary key or unique(single column unique index) like drivers report
+func (t *Table) addIndex(idx *migrator.Index) {
+	if pk, _ := idx.PrimaryKey(); pk && t.index(idx.NameValue) != nil { // e.g. inline PRIMARY KEY of composite key
+		t.index(idx.NameValue).ColumnList = append(t.index(idx.NameValue).ColumnList, idx.ColumnList...)
+	} else {
+		t.Indexes = append(t.Indexes, idx)
+	}
+	t.markColumns(idx)
+}
+
+func (t *Table) markColumns(idx *migrator.Index) {
+	pk, _ := idx.PrimaryKey()
+	unique, _ := idx.Unique()
+	for _, name := range idx.ColumnList {
+		col := t.column(name)
+		if col == nil {
+			continue
+		}
+		if pk {
+			col.PrimaryKeyValue.Bool, col.NullableValue.Bool = true, false
+		}
+		if unique && !pk && len(idx.ColumnList) == 1 {
+			col.UniqueValue.Bool = true
+		}
+	}
+}
+
+// dropIndex drop index or foreign key by name
+func (t *Table) dropIndex(name string) {
+	for i, idx := range t.Indexes {
+		if idx.NameValue == name {
+			t.Indexes = append(t.Indexes[:i], t.Indexes[i+1:]...)
+			break
+		}
+	}
+	foreignKeys := t.ForeignKeys[:0]
+	for _, fk := range t.ForeignKeys {
+		if fk.Name != name {
+			foreignKeys = append(foreignKeys, fk)
+		}
+	}
+	t.ForeignKeys = foreignKeys
+}
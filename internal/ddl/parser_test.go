@@ -0,0 +1,213 @@
+package ddl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"gorm.io/gen/internal/model"
+)
+
+func TestSchema_ParseMySQL(t *testing.T) {
+	s := NewSchema("mysql")
+	err := s.Parse("CREATE TABLE `users` (\n" +
+		"  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(64) NOT NULL DEFAULT 'it''s' COMMENT 'user name',\n" +
+		"  `code` char(8) DEFAULT NULL UNIQUE,\n" +
+		"  `flag` bit(1) NOT NULL DEFAULT b'0',\n" +
+		"  `price` decimal(10,2) NOT NULL DEFAULT -1.5,\n" +
+		"  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `idx_name` (`name`(10), `code`),\n" +
+		"  KEY `idx_expr` ((lower(`name`))),\n" +
+		"  CONSTRAINT `fk_group` FOREIGN KEY (`id`) REFERENCES `groups` (`id`) ON DELETE SET NULL\n" +
+		") ENGINE=InnoDB COMMENT='users; table';\n" +
+		"INSERT INTO users (name) VALUES ('a;b');\n" +
+		"ALTER TABLE users ADD COLUMN age int, DROP COLUMN flag, ADD UNIQUE KEY (age);")
+	if err != nil {
+		t.Fatalf("parse ddl fail: %s", err)
+	}
+	table := s.Table("USERS")
+	if table == nil || table.Comment.String != "users; table" {
+		t.Fatalf("table expect users with comment, got: %+v", table)
+	}
+
+	var columns []string
+	for _, c := range table.Columns {
+		typ, _ := c.ColumnType()
+		def, _ := c.DefaultValue()
+		columns = append(columns, c.Name()+" "+c.DatabaseTypeName()+" "+typ+" "+def)
+	}
+	expected := []string{
+		"id int int(10) unsigned ",
+		"name varchar varchar(64) it's",
+		"code char char(8) ",
+		"price decimal decimal(10,2) -1.5",
+		"updated_at datetime datetime CURRENT_TIMESTAMP",
+		"age int int ",
+	}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Errorf("columns expect: %q, got: %q", expected, columns)
+	}
+	if pk, _ := table.Columns[0].PrimaryKey(); !pk {
+		t.Errorf("column id expect primary key")
+	}
+	if ai, _ := table.Columns[0].AutoIncrement(); !ai {
+		t.Errorf("column id expect auto increment")
+	}
+	if unique, _ := table.Columns[2].Unique(); !unique {
+		t.Errorf("column code expect unique")
+	}
+	if comment, _ := table.Columns[1].Comment(); comment != "user name" {
+		t.Errorf("column name comment expect: user name, got: %s", comment)
+	}
+	if size, scale, _ := table.Columns[3].DecimalSize(); size != 10 || scale != 2 {
+		t.Errorf("column price decimal size expect: 10,2, got: %d,%d", size, scale)
+	}
+
+	var indexes []string
+	for _, idx := range table.Indexes {
+		unique, _ := idx.Unique()
+		indexes = append(indexes, idx.Name()+":"+strings.Join(idx.Columns(), ",")+":"+map[bool]string{true: "unique"}[unique])
+	}
+	if expected := []string{"code:code:unique", "PRIMARY:id:unique", "idx_name:name,code:", "age:age:unique"}; !reflect.DeepEqual(indexes, expected) {
+		t.Errorf("indexes expect: %q, got: %q", expected, indexes)
+	}
+	if len(table.ForeignKeys) != 1 || *table.ForeignKeys[0] != (model.ForeignKey{Name: "fk_group", Column: "id", RefTable: "groups", RefColumn: "id"}) {
+		t.Errorf("foreign keys expect fk_group, got: %+v", table.ForeignKeys)
+	}
+}
+
+func TestSchema_ParsePostgres(t *testing.T) {
+	s := NewSchema("postgres")
+	err := s.Parse(`CREATE TABLE public.Accounts (
+		id bigserial,
+		"Handle" character varying(32) NOT NULL,
+		tags text[],
+		owner_id int8 REFERENCES users,
+		created_at timestamp with time zone DEFAULT now() NOT NULL,
+		kind character varying DEFAULT 'basic'::character varying,
+		note text DEFAULT 'C:\new'::text,
+		CONSTRAINT accounts_pk PRIMARY KEY (id)
+	);
+	COMMENT ON TABLE accounts IS 'billing accounts';
+	COMMENT ON COLUMN public.accounts."Handle" IS 'login';
+	CREATE UNIQUE INDEX ON accounts (kind, "Handle");
+	CREATE FUNCTION f() RETURNS trigger AS $$ BEGIN RETURN NEW; END; $$ LANGUAGE plpgsql;
+	DROP TABLE IF EXISTS users;`)
+	if err != nil {
+		t.Fatalf("parse ddl fail: %s", err)
+	}
+	table := s.Table("accounts")
+	if table == nil || table.Name != "accounts" || table.Comment.String != "billing accounts" {
+		t.Fatalf("table expect accounts with comment, got: %+v", table)
+	}
+
+	var columns []string
+	for _, c := range table.Columns {
+		typ, _ := c.ColumnType()
+		columns = append(columns, c.Name()+" "+c.DatabaseTypeName()+" "+typ)
+	}
+	expected := []string{
+		"id bigint bigserial",
+		"Handle varchar character varying(32)",
+		"tags _text text[]",
+		"owner_id bigint int8",
+		"created_at timestamp timestamp with time zone",
+		"kind varchar character varying",
+		"note text text",
+	}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Errorf("columns expect: %q, got: %q", expected, columns)
+	}
+	if ai, _ := table.Columns[0].AutoIncrement(); !ai {
+		t.Errorf("column id expect auto increment")
+	}
+	if nullable, _ := table.Columns[0].Nullable(); nullable {
+		t.Errorf("primary key column id expect not null")
+	}
+	if comment, _ := table.Columns[1].Comment(); comment != "login" {
+		t.Errorf("column Handle comment expect: login, got: %s", comment)
+	}
+	if def, _ := table.Columns[5].DefaultValue(); def != "'basic'::character varying" {
+		t.Errorf("column kind default expect cast kept, got: %s", def)
+	}
+	if def, _ := table.Columns[6].DefaultValue(); def != `C:\new` {
+		t.Errorf("column note default expect unquoted like driver reports, got: %s", def)
+	}
+	if idx := table.index("accounts_kind_Handle_key"); idx == nil || !reflect.DeepEqual(idx.Columns(), []string{"kind", "Handle"}) {
+		t.Errorf("unnamed unique index expect named accounts_kind_Handle_key, got: %+v", table.Indexes)
+	}
+	if fk := table.ForeignKeys; len(fk) != 1 || fk[0].Name != "accounts_owner_id_fkey" || fk[0].RefTable != "users" || fk[0].RefColumn != "" {
+		t.Errorf("foreign key expect accounts_owner_id_fkey referencing users, got: %+v", fk)
+	}
+}
+
+func TestSchema_ParseMySQLEscape(t *testing.T) {
+	s := NewSchema("mysql")
+	err := s.Parse("CREATE TABLE users (\n" +
+		"  `alive` tinyint(1) DEFAULT NULL COMMENT 'multiline\\nline1\\tline2\\r\\0\\b\\Z\\\\ \\'a\\' \\\"b\\\" \\%',\n" +
+		"  `company_id` bigint(20) unsigned DEFAULT '666',\n" +
+		"  `private_url` varchar(255) DEFAULT 'https://a.b.c ',\n" +
+		"  `address` varchar(255) DEFAULT '',\n" +
+		"  `flag` bit(1) NOT NULL DEFAULT b'0'\n" +
+		");")
+	if err != nil {
+		t.Fatalf("parse ddl fail: %s", err)
+	}
+	columns := s.Table("users").Columns
+	if comment, _ := columns[0].Comment(); comment != "multiline\nline1\tline2\r\x00\b\x1a\\ 'a' \"b\" %" {
+		t.Errorf("comment expect escapes decoded, got: %q", comment)
+	}
+	// string literal is unquoted like information_schema reports, other expression is kept
+	var defaults []string
+	for _, c := range columns[1:] {
+		def, _ := c.DefaultValue()
+		defaults = append(defaults, def)
+	}
+	if expected := []string{"666", "https://a.b.c ", "", "b'0'"}; !reflect.DeepEqual(defaults, expected) {
+		t.Errorf("defaults expect: %q, got: %q", expected, defaults)
+	}
+}
+
+func TestSchema_ParseError(t *testing.T) {
+	testcases := []string{
+		"CREATE TABLE users (id int",
+		"CREATE TABLE users (id int DEFAULT 'abc);",
+		"SELECT 1;\n\nCREATE TABLE users (id int, PRIMARY KEY id);",
+	}
+	for _, testcase := range testcases {
+		if err := NewSchema("mysql").Parse(testcase); err == nil {
+			t.Errorf("parse %q expect error", testcase)
+		}
+	}
+	if err := NewSchema("mysql").Parse(testcases[2]); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("parse error expect line number of statement, got: %v", err)
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	s := NewSchema("sqlite")
+	if err := s.Parse("CREATE TABLE users (id integer PRIMARY KEY AUTOINCREMENT, name text NOT NULL);"); err != nil {
+		t.Fatalf("parse ddl fail: %s", err)
+	}
+	db, err := gorm.Open(Dialector{Schema: s})
+	if err != nil {
+		t.Fatalf("open db fail: %s", err)
+	}
+	if tables, _ := db.Migrator().GetTables(); !reflect.DeepEqual(tables, []string{"users"}) {
+		t.Errorf("tables expect: [users], got: %v", tables)
+	}
+	if columns, err := db.Migrator().ColumnTypes("users"); err != nil || len(columns) != 2 {
+		t.Errorf("column types expect 2 columns, got: %v %v", columns, err)
+	}
+	if _, err = db.Migrator().ColumnTypes("posts"); err == nil {
+		t.Errorf("column types of undeclared table expect error")
+	}
+	var count int
+	if err = db.Raw("SELECT count(*) FROM users").Scan(&count).Error; err != ErrOffline {
+		t.Errorf("query expect ErrOffline, got: %v", err)
+	}
+}
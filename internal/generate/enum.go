@@ -0,0 +1,234 @@
+package generate
+
+import (
+	"context"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+
+	"gorm.io/gen/internal/model"
+)
+
+// EnumType named type of enum column, declared once in shared file with sql.Scanner/driver.Valuer
+type EnumType struct {
+	Name    string
+	Values  []string
+	Integer bool              // backed by int, all values are integers stored as string in database
+	Native  bool              // integer stored as integer in database, declared by comment annotation of integer column
+	Labels  map[string]string // const name suffix of values declared by comment annotation, e.g. 1 => Active
+
+	Scanner         bool            // generate sql.Scanner/driver.Valuer
+	Text            bool            // generate encoding.TextMarshaler/TextUnmarshaler
+	TypeDeclared    bool            // type declared by user, only methods generated
+	DeclaredMethods map[string]bool // methods declared by user, skipped
+}
+
+// EnumDefault enum field initialized by typed const of column default
+type EnumDefault struct {
+	Field string
+	Const string
+	Type  string // enum type of field
+	Value string // enum member of column default, Const is resolved by it against merged type, see ResolveEnumDefaults
+}
+
+// EnumConst const of enum value
+type EnumConst struct {
+	Name  string
+	Value string
+}
+
+// Consts enum value consts, named by type name and value, e.g. OrderStatusPending,
+// value without letter or digit is named Empty, duplicate names are numbered, e.g. in-progress/in progress => InProgress/InProgress2
+func (e *EnumType) Consts() (consts []EnumConst) {
+	names := make(map[string]bool, len(e.Values))
+	for _, v := range e.Values {
+		suffix := enumConstSuffix(v)
+		if label := enumConstSuffix(e.Labels[v]); label != "" {
+			suffix = label
+		}
+		if suffix == "" {
+			suffix = "Empty"
+		}
+		if e.Integer && strings.HasPrefix(v, "-") && e.Labels[v] == "" {
+			suffix = "Minus" + suffix
+		}
+		name := e.Name + suffix
+		for i := 2; names[name]; i++ {
+			name = e.Name + suffix + strconv.Itoa(i)
+		}
+		if !token.IsIdentifier(name) {
+			continue
+		}
+		names[name] = true
+		consts = append(consts, EnumConst{Name: name, Value: v})
+	}
+	return consts
+}
+
+func enumConstSuffix(value string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(value, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		runes := []rune(word)
+		b.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+	}
+	return b.String()
+}
+
+// getEnumTypes get enum(set, annotated integer) columns mapped to named types(declared in model package), e.g. OrderStatus,
+// enum with all integer values is backed by int when integer is on, annotated integer column is always int backed
+func getEnumTypes(columns []*model.Column, fields []*model.Field, integer bool) (enums []*EnumType) {
+	fieldMap := make(map[string]*model.Field, len(fields))
+	for _, f := range fields {
+		if f.ColumnName != "" && !f.IsRelation() {
+			fieldMap[f.ColumnName] = f
+		}
+	}
+	for _, col := range columns {
+		f, ok := fieldMap[col.Name()]
+		if !ok || len(col.EnumValues()) == 0 {
+			continue
+		}
+		typeName := strings.TrimPrefix(f.Type, "*")
+		if !token.IsIdentifier(typeName) || types.Universe.Lookup(typeName) != nil {
+			continue
+		}
+		if values, labels := col.EnumAnnotation(); len(values) > 0 {
+			enums = append(enums, &EnumType{Name: typeName, Values: values, Integer: true, Native: true, Labels: labels})
+			continue
+		}
+		values := col.EnumValues()
+		enums = append(enums, &EnumType{Name: typeName, Values: values, Integer: integer && isIntegerValues(values)})
+	}
+	return enums
+}
+
+// getEnumDefaults get typed consts of enum column defaults, default matching no member is warned and skipped,
+// pointer field is skipped since nil leaves default to database
+func getEnumDefaults(db *gorm.DB, columns []*model.Column, fields []*model.Field, enums []*EnumType) (defaults []EnumDefault) {
+	enumMap := make(map[string]*EnumType, len(enums))
+	for _, e := range enums {
+		enumMap[e.Name] = e
+	}
+	fieldMap := make(map[string]*model.Field, len(fields))
+	for _, f := range fields {
+		if f.ColumnName != "" && !f.IsRelation() {
+			fieldMap[f.ColumnName] = f
+		}
+	}
+	for _, col := range columns {
+		f, ok := fieldMap[col.Name()]
+		if !ok || enumMap[f.Type] == nil {
+			continue
+		}
+		value, ok := col.DefaultValue()
+		if !ok || strings.EqualFold(strings.TrimSpace(value), "null") {
+			continue
+		}
+		member, ok := model.EnumMember(value, col.EnumValues())
+		if ok {
+			ok = false
+			for _, c := range enumMap[f.Type].Consts() {
+				if c.Value == member {
+					defaults, ok = append(defaults, EnumDefault{Field: f.Name, Const: c.Name, Type: f.Type, Value: member}), true
+					break
+				}
+			}
+		}
+		if !ok {
+			db.Logger.Warn(context.Background(), "default %s of enum column %s.%s matches no member, skipped", value, col.TableName, col.Name())
+		}
+	}
+	return defaults
+}
+
+// ResolveEnumDefaults resolve consts of enum defaults against enum types merged from all models, const names of type
+// shared by tables depend on merged values(e.g. numbered duplicates), default matching no merged const is dropped
+func ResolveEnumDefaults(metas map[string]*QueryStructMeta) {
+	consts := make(map[string]map[string]string)
+	for _, e := range MergeEnumTypes(metas) {
+		consts[e.Name] = make(map[string]string)
+		for _, c := range e.Consts() {
+			consts[e.Name][c.Value] = c.Name
+		}
+	}
+	for _, meta := range metas {
+		if meta == nil || !meta.Generated || len(meta.EnumDefaults) == 0 {
+			continue
+		}
+		defaults := make([]EnumDefault, 0, len(meta.EnumDefaults))
+		for _, d := range meta.EnumDefaults {
+			if name, ok := consts[d.Type][d.Value]; ok {
+				d.Const = name
+				defaults = append(defaults, d)
+			}
+		}
+		meta.EnumDefaults = defaults
+	}
+}
+
+// MergeEnumTypes merge enum types of all models, the same type name in multiple tables is declared once
+func MergeEnumTypes(metas map[string]*QueryStructMeta) []*EnumType {
+	names := make([]string, 0, len(metas))
+	for name := range metas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	enumMap := make(map[string]*EnumType)
+	for _, name := range names {
+		meta := metas[name]
+		if meta == nil || !meta.Generated {
+			continue
+		}
+		for _, e := range meta.EnumTypes {
+			merged, ok := enumMap[e.Name]
+			if !ok {
+				merged = &EnumType{Name: e.Name, Integer: true, Native: true, Labels: make(map[string]string)}
+				enumMap[e.Name] = merged
+			}
+			merged.Integer = merged.Integer && e.Integer
+			merged.Native = merged.Native && e.Native
+			for v, label := range e.Labels {
+				if _, ok := merged.Labels[v]; !ok {
+					merged.Labels[v] = label
+				}
+			}
+			for _, v := range e.Values {
+				if !contains(merged.Values, v) {
+					merged.Values = append(merged.Values, v)
+				}
+			}
+		}
+	}
+
+	enums := make([]*EnumType, 0, len(enumMap))
+	for _, e := range enumMap {
+		enums = append(enums, e)
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
+	return enums
+}
+
+// isIntegerValues check if all values are integers in canonical form, e.g. '01' is not
+func isIntegerValues(values []string) bool {
+	for _, v := range values {
+		if i, err := strconv.Atoi(v); err != nil || strconv.Itoa(i) != v {
+			return false
+		}
+	}
+	return len(values) > 0
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
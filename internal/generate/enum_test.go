@@ -0,0 +1,156 @@
+package generate
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/migrator"
+
+	"gorm.io/gen/internal/model"
+)
+
+func newEnumColumn(name, columnType string) *model.Column {
+	return &model.Column{TableName: "users", ColumnType: migrator.ColumnType{
+		NameValue:       sql.NullString{String: name, Valid: true},
+		DataTypeValue:   sql.NullString{String: "enum", Valid: true},
+		ColumnTypeValue: sql.NullString{String: columnType, Valid: true},
+	}}
+}
+
+func TestGetEnumTypes_Integer(t *testing.T) {
+	columns := []*model.Column{
+		newEnumColumn("level", "enum('0','1','-2')"),
+		newEnumColumn("grade", "enum('1','a')"),
+		newEnumColumn("code", "enum('01','02')"),
+	}
+	fields := []*model.Field{
+		{Name: "Level", Type: "Level", ColumnName: "level"},
+		{Name: "Grade", Type: "*Grade", ColumnName: "grade"},
+		{Name: "Code", Type: "Code", ColumnName: "code"},
+	}
+	testcases := []struct {
+		integer  bool
+		expected map[string]bool
+	}{
+		{false, map[string]bool{"Level": false, "Grade": false, "Code": false}},
+		{true, map[string]bool{"Level": true, "Grade": false, "Code": false}},
+	}
+	for _, testcase := range testcases {
+		enums := getEnumTypes(columns, fields, testcase.integer)
+		if len(enums) != len(testcase.expected) {
+			t.Fatalf("enum types expect: %d, got: %d", len(testcase.expected), len(enums))
+		}
+		for _, e := range enums {
+			if e.Integer != testcase.expected[e.Name] {
+				t.Errorf("enum type %s integer expect: %t, got: %t", e.Name, testcase.expected[e.Name], e.Integer)
+			}
+		}
+	}
+
+	level := &EnumType{Name: "Level", Values: []string{"0", "1", "-2"}, Integer: true}
+	var names []string
+	for _, c := range level.Consts() {
+		names = append(names, c.Name)
+	}
+	if expected := "Level0,Level1,LevelMinus2"; strings.Join(names, ",") != expected {
+		t.Errorf("enum consts expect: %s, got: %s", expected, strings.Join(names, ","))
+	}
+}
+
+func TestGetEnumTypes_Annotated(t *testing.T) {
+	status := &model.Column{TableName: "users", ColumnType: migrator.ColumnType{
+		NameValue:     sql.NullString{String: "status", Valid: true},
+		DataTypeValue: sql.NullString{String: "int", Valid: true},
+		CommentValue:  sql.NullString{String: "[[enum:1=Active,2=Disabled,3]]", Valid: true},
+	}}
+	enums := getEnumTypes([]*model.Column{status}, []*model.Field{{Name: "Status", Type: "UsersStatus", ColumnName: "status"}}, false)
+	if len(enums) != 1 || !enums[0].Integer || !enums[0].Native {
+		t.Fatalf("annotated enum type expect native integer, got: %+v", enums)
+	}
+	var names []string
+	for _, c := range enums[0].Consts() {
+		names = append(names, c.Name)
+	}
+	if expected := "UsersStatusActive,UsersStatusDisabled,UsersStatus3"; strings.Join(names, ",") != expected {
+		t.Errorf("enum consts expect: %s, got: %s", expected, strings.Join(names, ","))
+	}
+}
+
+func TestMergeEnumTypes_Integer(t *testing.T) {
+	metas := map[string]*QueryStructMeta{
+		"a": {Generated: true, EnumTypes: []*EnumType{{Name: "Level", Values: []string{"0", "1"}, Integer: true}}},
+		"b": {Generated: true, EnumTypes: []*EnumType{{Name: "Level", Values: []string{"x"}}}},
+		"c": {Generated: true, EnumTypes: []*EnumType{{Name: "Grade", Values: []string{"1"}, Integer: true}}},
+	}
+	for _, e := range MergeEnumTypes(metas) {
+		if expected := e.Name == "Grade"; e.Integer != expected {
+			t.Errorf("merged enum type %s integer expect: %t, got: %t", e.Name, expected, e.Integer)
+		}
+	}
+}
+
+func TestEnumType_Consts(t *testing.T) {
+	status := &EnumType{Name: "UsersStatus", Values: []string{"active", "in progress", "in-progress", "", "2fa"}}
+	var names []string
+	for _, c := range status.Consts() {
+		names = append(names, c.Name)
+	}
+	expected := "UsersStatusActive,UsersStatusInProgress,UsersStatusInProgress2,UsersStatusEmpty,UsersStatus2fa"
+	if strings.Join(names, ",") != expected {
+		t.Errorf("enum consts expect: %s, got: %s", expected, strings.Join(names, ","))
+	}
+}
+
+func TestGetEnumDefaults(t *testing.T) {
+	withDefault := func(col *model.Column, value string) *model.Column {
+		ct := col.ColumnType.(migrator.ColumnType)
+		ct.DefaultValueValue = sql.NullString{String: value, Valid: true}
+		col.ColumnType = ct
+		return col
+	}
+	columns := []*model.Column{
+		withDefault(newEnumColumn("status", "enum('active','in-active')"), "in-active"),
+		withDefault(newEnumColumn("kind", "enum('a','b')"), "'b'"),
+		withDefault(newEnumColumn("level", "enum('low','high')"), "medium"),
+		withDefault(newEnumColumn("grade", "enum('a','b')"), "a"),
+		newEnumColumn("role", "enum('admin','guest')"),
+	}
+	fields := []*model.Field{
+		{Name: "Status", Type: "UsersStatus", ColumnName: "status"},
+		{Name: "Kind", Type: "UsersKind", ColumnName: "kind"},
+		{Name: "Level", Type: "UsersLevel", ColumnName: "level"},
+		{Name: "Grade", Type: "*UsersGrade", ColumnName: "grade"},
+		{Name: "Role", Type: "UsersRole", ColumnName: "role"},
+	}
+	db := &gorm.DB{Config: &gorm.Config{Logger: logger.Discard}}
+	expected := []EnumDefault{{Field: "Status", Const: "UsersStatusInActive", Type: "UsersStatus", Value: "in-active"},
+		{Field: "Kind", Const: "UsersKindB", Type: "UsersKind", Value: "b"}}
+	if got := getEnumDefaults(db, columns, fields, getEnumTypes(columns, fields, false)); !reflect.DeepEqual(got, expected) {
+		t.Errorf("enum defaults expect: %+v, got: %+v", expected, got)
+	}
+}
+
+func TestResolveEnumDefaults(t *testing.T) {
+	// const names of shared type are numbered by merged values, in-progress is InProgress2 after in progress of orders
+	orders := &QueryStructMeta{Generated: true, EnumTypes: []*EnumType{{Name: "Status", Values: []string{"in progress", "in-progress"}}},
+		EnumDefaults: []EnumDefault{{Field: "Status", Const: "StatusInProgress", Type: "Status", Value: "in progress"}}}
+	tasks := &QueryStructMeta{Generated: true, EnumTypes: []*EnumType{{Name: "Status", Values: []string{"in-progress", "done"}}},
+		EnumDefaults: []EnumDefault{{Field: "Status", Const: "StatusInProgress", Type: "Status", Value: "in-progress"},
+			{Field: "Next", Const: "StatusDone", Type: "Status", Value: "done"}}}
+	ResolveEnumDefaults(map[string]*QueryStructMeta{"orders": orders, "tasks": tasks})
+
+	if expected := "StatusInProgress"; orders.EnumDefaults[0].Const != expected {
+		t.Errorf("enum default of orders expect: %s, got: %s", expected, orders.EnumDefaults[0].Const)
+	}
+	var got []string
+	for _, d := range tasks.EnumDefaults {
+		got = append(got, d.Const)
+	}
+	if expected := []string{"StatusInProgress2", "StatusDone"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("enum defaults of tasks expect: %v, got: %v", expected, got)
+	}
+}
@@ -31,12 +31,12 @@ func GetQueryStructMeta(db *gorm.DB, conf *model.Config) (*QueryStructMeta, erro
 		return nil, fmt.Errorf("model name %q is invalid: %w", structName, err)
 	}
 
-	columns, err := getTableColumns(db, conf.GetSchemaName(db), tableName, conf.FieldWithIndexTag)
+	columns, err := getTableColumns(db, conf.GetSchemaName(db), tableName, &conf.FieldConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	return (&QueryStructMeta{
+	meta := (&QueryStructMeta{
 		db:              db,
 		Source:          model.Table,
 		Generated:       true,
@@ -49,7 +49,62 @@ func GetQueryStructMeta(db *gorm.DB, conf *model.Config) (*QueryStructMeta, erro
 		StructInfo:      parser.Param{Type: structName, Package: conf.ModelPkg},
 		ImportPkgPaths:  conf.ImportPkgPaths,
 		Fields:          getFields(db, conf, columns),
-	}).addMethodFromAddMethodOpt(conf.GetModelMethods()...), nil
+		tableCommentDoc: conf.WithTableCommentDoc,
+		docCommentName:  conf.WithDocCommentName,
+	}).addMethodFromAddMethodOpt(conf.GetModelMethods()...)
+	if meta.Fields, err = resolveEmbeddedConflicts(meta.Fields, conf.FieldEmbeddedConflict, tableName); err != nil {
+		return nil, err
+	}
+	if conf.FieldForeignKeyRelations {
+		meta.setForeignKeys(columns)
+	}
+	if conf.FieldArrayType == model.ArrayTypePQ {
+		meta.addImportPkgPaths(model.PQPkgPath) // removed when formatting if unused
+	}
+	if conf.WithAfterFindHook {
+		meta.addAfterFindHook()
+	}
+	if conf.WithGoDefaultHook {
+		meta.addGoDefaultHook()
+	}
+	if conf.WithValidateMethod {
+		meta.addValidateMethod()
+	}
+	if conf.WithModelInterface {
+		meta.addGetterMethods()
+	}
+	if conf.WithSoftDeletable {
+		meta.addSoftDeletableMethod()
+	}
+	if conf.WithCloneMethod {
+		meta.addCloneMethod()
+	}
+	if conf.WithIndexesMethod {
+		meta.addIndexesMethod(columns)
+	}
+	if conf.EntityInterface != nil {
+		meta.addEntityMethod(conf.EntityInterface)
+	}
+	if conf.WithSchemaTableName {
+		meta.SchemaName = getTableSchema(db, conf, tableName)
+	}
+	if conf.WithUpdatableColumns {
+		if !conf.FieldReadOnlyGenerated && len(columns) > 0 { // generated columns are not marked yet
+			fillTableGenerated(db, conf.GetSchemaName(db), tableName, columns)
+		}
+		meta.UpdatableColumns = getUpdatableColumns(columns, meta.Fields)
+	}
+	if conf.WithFunctionalIndexes {
+		meta.addFunctionalIndexesMethod(getFunctionalIndexes(db, conf.GetSchemaName(db), tableName))
+	}
+	if conf.WithEnumScanner || conf.FieldEnumType {
+		meta.EnumTypes = getEnumTypes(columns, meta.Fields, conf.WithEnumInteger)
+	}
+	if conf.WithEnumDefault && conf.FieldEnumType {
+		meta.EnumDefaults = getEnumDefaults(db, columns, meta.Fields, meta.EnumTypes)
+	}
+	meta.schemaHash = schemaHash(tableName, meta.TableComment, columns)
+	return meta, nil
 }
 
 // GetQueryStructMetaFromObject generate base struct from object
@@ -1,6 +1,7 @@
 package generate
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 
+	"gorm.io/gen/field"
 	"gorm.io/gen/internal/model"
 )
 
@@ -17,16 +19,68 @@ import (
  */
 
 func getFields(db *gorm.DB, conf *model.Config, columns []*model.Column) (fields []*model.Field) {
+	singlePK := countPrimaryKey(columns) == 1
 	for _, col := range columns {
 		col.SetDataTypeMap(conf.DataTypeMap)
+		col.SetBoolScanType(conf.FieldBoolScanType)
+		col.SetEnumType(conf.FieldEnumType)
+		col.SetColumnTypeTagOverride(conf.TypeTagOverride)
+		col.SetAutoIncrementOverride(conf.AutoIncrementOverride)
+		col.SetColumnNameOverride(conf.ColumnNameOverride)
+		col.SetTypeTagDialect(conf.TypeTagDialect)
+		col.SetGormTagOrder(conf.GormTagOrder)
+		col.SetTimePrecisionTag(conf.FieldTimePrecisionTag)
+		col.SetFloatPrecisionTag(conf.FieldFloatPrecisionTag)
 		col.WithNS(conf.FieldJSONTagNS)
+		col.WithTagNS(conf.FieldTagNS)
+		col.SetOmitemptyPolicies(conf.FieldTagOmitempty)
+		col.SetCommentSource(conf.FieldCommentSource)
+		col.SetBinaryDefaultMode(conf.FieldBinaryDefault)
+		col.SetSharedPKMode(conf.FieldSharedPrimaryKey)
+		col.SetEmptyDefaultModes(conf.FieldEmptyDefault)
+		col.SetUTCTimeSerializer(conf.FieldUTCTimeSerializer, conf.FieldUTCTimeDialects)
+		col.SetTimestampType(conf.FieldTimestampType, conf.FieldTimestampColumns)
+		col.SetUnixTimeRules(conf.FieldUnixTimeRules)
+		col.SetBindingOmitempty(conf.FieldBindingOmitempty)
+		col.SetJSONDirective(conf.FieldJSONDirective)
+		col.SetCheckDirective(conf.FieldCheckDirective)
+		col.SetDetectIdentity(conf.FieldDetectIdentity)
+		col.SetUUIDBinding(conf.FieldUUIDBinding)
+		col.SetNetType(conf.FieldNetType)
+		col.SetSignMappedType(conf.FieldSignMapped)
+		col.SetUnsignedAutoIncrement(conf.FieldUnsignedPK && singlePK)
+		col.SetUnsignedDecimal(conf.FieldUnsignedDecimal, conf.FieldUnsignedDecimalType)
+		col.SetBigIntDecimal(conf.FieldBigIntDecimal, conf.FieldBigIntDecimalType)
+		col.SetFullTextReadOnly(conf.FieldFullTextReadOnly)
+		col.SetReadOnly(conf.FieldReadOnlyGenerated, conf.FieldReadOnlyColumns)
+		col.SetTriggerColumns(conf.FieldTriggerColumns)
+		col.SetUniqueAsIndex(conf.FieldUniqueAsIndex)
+		col.SetIndexDBOrder(conf.FieldIndexDBOrder)
+		col.SetJSONType(conf.FieldJSONType, conf.FieldJSONArrayColumns)
+		col.SetJSONStructTypes(conf.FieldJSONStructTypes)
+		col.SetGoDefaults(conf.FieldGoDefaults)
+		col.SetXMLType(conf.FieldXMLType, conf.FieldXMLStructType)
+		col.SetArrayType(conf.FieldArrayType)
+		col.SetYesNoColumns(conf.FieldYesNoColumns)
+		col.SetNumericBool(conf.FieldNumericBool)
+		col.SetPointerDefaultMode(conf.FieldPointerDefault)
+		col.SetSkipZeroDefault(conf.FieldSkipZeroDefault)
+		col.SetEnumDefault(conf.FieldEnumDefault)
+		col.SetNullableStrategy(conf.FieldNullableStrategy, conf.FieldNullableSelector)
+		col.SetNullableBoolMode(conf.FieldNullableBool)
+		col.SetPointerPolicy(conf.FieldPointerPolicy, conf.FieldColumnPointerPolicy)
+		col.SetExampleTag(conf.FieldExampleTag)
+		col.SetProtobufTag(conf.FieldProtobufTag, conf.FieldProtobufNumbers)
 
 		m := col.ToField(conf.FieldNullable, conf.FieldCoverable, conf.FieldSignable)
 
 		if filterField(m, conf.FilterOpts) == nil {
 			continue
 		}
-		if _, ok := col.ColumnType.ColumnType(); ok && !conf.FieldWithTypeTag { // remove type tag if FieldWithTypeTag == false
+		if _, ok := col.TypeTag(); !ok && conf.FieldWithTypeTag {
+			db.Logger.Warn(context.Background(), "type tag %s of %s.%s has no equivalent in %s, passed through", col.ColumnType.DatabaseTypeName(), col.TableName, col.Name(), conf.TypeTagDialect)
+		}
+		if _, ok := col.ColumnType.ColumnType(); ok && !conf.FieldWithTypeTag && !col.IsFullTextSearch() && !col.IsXMLMapped() && !col.IsBigIntDecimal() && !col.IsArrayMapped() && !col.IsNetType() { // remove type tag if FieldWithTypeTag == false
 			m.GORMTag.Remove("type")
 		}
 
@@ -37,6 +91,12 @@ func getFields(db *gorm.DB, conf *model.Config, columns []*model.Column) (fields
 		} else if db.NamingStrategy != nil {
 			m.Name = db.NamingStrategy.SchemaName(m.Name)
 		}
+		if conf.FieldCommentFallback != nil && len(m.GORMTag[field.TagKeyGormComment]) == 0 {
+			if comment := conf.FieldCommentFallback(col.TableName, col.Name(), m.Name); comment != "" {
+				m.GORMTag.Set(field.TagKeyGormComment, comment)
+			}
+		}
+		col.ApplyTagGenerators(m, conf.FieldTagGenerators)
 
 		fields = append(fields, m)
 	}
@@ -58,6 +118,63 @@ func getFields(db *gorm.DB, conf *model.Config, columns []*model.Column) (fields
 	return fields
 }
 
+// resolveEmbeddedConflicts handle table column fields colliding with embedded struct fields by mode, a field collides
+// if its column is provided by embedded struct or its name is the name of embedded field, e.g. id or Model of gorm.Model
+func resolveEmbeddedConflicts(fields []*model.Field, mode model.EmbeddedConflictMode, tableName string) ([]*model.Field, error) {
+	if mode == model.EmbeddedConflictAsIs {
+		return fields, nil
+	}
+	embeddedColumns, embeddedNames := make(map[string]string), make(map[string]string)
+	for _, f := range fields {
+		if len(f.EmbeddedColumns) == 0 {
+			continue
+		}
+		name := f.Name
+		if name == "" {
+			name = embeddedFieldName(f.Type)
+		}
+		embeddedNames[name] = f.Type
+		for _, column := range f.EmbeddedColumns {
+			embeddedColumns[column] = f.Type
+		}
+	}
+	if len(embeddedColumns) == 0 {
+		return fields, nil
+	}
+
+	resolved := make([]*model.Field, 0, len(fields))
+	for _, f := range fields {
+		embeddedType, ok := embeddedColumns[f.ColumnName]
+		if !ok {
+			embeddedType, ok = embeddedNames[f.Name]
+		}
+		if !ok || f.ColumnName == "" || len(f.EmbeddedColumns) > 0 {
+			resolved = append(resolved, f)
+			continue
+		}
+		switch mode {
+		case model.EmbeddedConflictPreferEmbedded:
+			continue
+		case model.EmbeddedConflictRename:
+			f.Name += "Column"
+		case model.EmbeddedConflictError:
+			return nil, fmt.Errorf("field %s of column %s.%s collides with embedded %s", f.Name, tableName, f.ColumnName, embeddedType)
+		}
+		resolved = append(resolved, f)
+	}
+	return resolved, nil
+}
+
+// countPrimaryKey count primary key columns, more than one means composite primary key
+func countPrimaryKey(columns []*model.Column) (count int) {
+	for _, col := range columns {
+		if pk, ok := col.PrimaryKey(); ok && pk {
+			count++
+		}
+	}
+	return count
+}
+
 func filterField(m *model.Field, opts []model.FieldOption) *model.Field {
 	for _, opt := range opts {
 		if opt.Operator()(m) == nil {
@@ -0,0 +1,306 @@
+package generate
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen/field"
+	"gorm.io/gen/internal/model"
+	"gorm.io/gen/internal/parser"
+)
+
+func TestGetFields_CommentFallback(t *testing.T) {
+	column := func(name, comment string) *model.Column {
+		return &model.Column{TableName: "users", ColumnType: migrator.ColumnType{
+			NameValue:       sql.NullString{String: name, Valid: true},
+			DataTypeValue:   sql.NullString{String: "varchar", Valid: true},
+			ColumnTypeValue: sql.NullString{String: "varchar(64)", Valid: true},
+			CommentValue:    sql.NullString{String: comment, Valid: comment != ""},
+			NullableValue:   sql.NullBool{Bool: true, Valid: true},
+		}}
+	}
+	db := &gorm.DB{Config: &gorm.Config{NamingStrategy: schema.NamingStrategy{}, Logger: logger.Discard}}
+	conf := &model.Config{FieldConfig: model.FieldConfig{
+		FieldCommentFallback: func(table, column, fieldName string) string {
+			if column == "remark" {
+				return ""
+			}
+			return fieldName + " of " + table
+		},
+	}}
+	fields := getFields(db, conf, []*model.Column{column("nick_name", ""), column("email", "login email"), column("remark", "")})
+	expected := [][]string{{"NickName of users"}, {"login email"}, nil}
+	for i, f := range fields {
+		if got := f.GORMTag[field.TagKeyGormComment]; !reflect.DeepEqual(got, expected[i]) {
+			t.Errorf("field %s comment tag expect: %v, got: %v", f.Name, expected[i], got)
+		}
+	}
+}
+
+func TestGetFields_TagGenerators(t *testing.T) {
+	column := func(name string, nullable bool) *model.Column {
+		return &model.Column{TableName: "users", ColumnType: migrator.ColumnType{
+			NameValue:       sql.NullString{String: name, Valid: true},
+			DataTypeValue:   sql.NullString{String: "varchar", Valid: true},
+			ColumnTypeValue: sql.NullString{String: "varchar(64)", Valid: true},
+			LengthValue:     sql.NullInt64{Int64: 64, Valid: true},
+			NullableValue:   sql.NullBool{Bool: nullable, Valid: true},
+		}}
+	}
+	db := &gorm.DB{Config: &gorm.Config{NamingStrategy: schema.NamingStrategy{}, Logger: logger.Discard}}
+	conf := &model.Config{FieldConfig: model.FieldConfig{
+		FieldTagGenerators: []model.TagGenerator{
+			func(col gorm.ColumnType, f *model.Field) map[string]string {
+				rule := "required"
+				if nullable, _ := col.Nullable(); nullable {
+					rule = "omitempty"
+				}
+				length, _ := col.Length()
+				return map[string]string{field.TagKeyValidate: fmt.Sprintf("%s,max=%d", rule, length), "xml": f.Name, field.TagKeyGorm: "-"}
+			},
+			func(col gorm.ColumnType, f *model.Field) map[string]string {
+				if col.Name() == "secret" {
+					return map[string]string{field.TagKeyJson: "", "xml": "-"}
+				}
+				return nil
+			},
+		},
+	}}
+	fields := getFields(db, conf, []*model.Column{column("nick_name", true), column("secret", false)})
+	expected := []string{
+		`gorm:"column:nick_name" json:"nick_name" validate:"omitempty,max=64" xml:"NickName"`,
+		`gorm:"column:secret;not null" validate:"required,max=64" xml:"-"`,
+	}
+	for i, f := range fields {
+		if got := f.Tags(); got != expected[i] {
+			t.Errorf("field %s tags expect: %s, got: %s", f.Name, expected[i], got)
+		}
+	}
+}
+
+func TestResolveEmbeddedConflicts(t *testing.T) {
+	newFields := func() []*model.Field {
+		return []*model.Field{
+			{Name: "ID", Type: "int64", ColumnName: "id"},
+			{Name: "Name", Type: "string", ColumnName: "name"},
+			{Name: "Model", Type: "string", ColumnName: "model"},
+			{Name: "CreatedAt", Type: "time.Time", ColumnName: "created_at"},
+			{Name: "", Type: "gorm.Model", EmbeddedColumns: []string{"id", "created_at", "updated_at", "deleted_at"}},
+			{Name: "Author", Type: "Author", EmbeddedColumns: []string{"author_name"}},
+		}
+	}
+	names := func(fields []*model.Field) (names []string) {
+		for _, f := range fields {
+			names = append(names, f.Name)
+		}
+		return names
+	}
+	testcases := []struct {
+		mode     model.EmbeddedConflictMode
+		expected []string
+	}{
+		{model.EmbeddedConflictAsIs, []string{"ID", "Name", "Model", "CreatedAt", "", "Author"}},
+		{model.EmbeddedConflictPreferEmbedded, []string{"Name", "", "Author"}},
+		{model.EmbeddedConflictRename, []string{"IDColumn", "Name", "ModelColumn", "CreatedAtColumn", "", "Author"}},
+	}
+	for _, testcase := range testcases {
+		fields, err := resolveEmbeddedConflicts(newFields(), testcase.mode, "users")
+		if err != nil {
+			t.Fatalf("resolve embedded conflicts fail: %s", err)
+		}
+		if got := names(fields); !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("mode %d fields expect: %v, got: %v", testcase.mode, testcase.expected, got)
+		}
+	}
+
+	_, err := resolveEmbeddedConflicts(newFields(), model.EmbeddedConflictError, "users")
+	if err == nil || !strings.Contains(err.Error(), "users.id") || !strings.Contains(err.Error(), "gorm.Model") {
+		t.Errorf("expect error of users.id colliding with gorm.Model, got: %v", err)
+	}
+
+	fields := []*model.Field{{Name: "ID", Type: "int64", ColumnName: "id"}, {Name: "AuthorName", Type: "string", ColumnName: "author_name"}}
+	if got, _ := resolveEmbeddedConflicts(fields, model.EmbeddedConflictError, "users"); len(got) != 2 {
+		t.Errorf("fields without embedded struct expect kept, got: %v", names(got))
+	}
+}
+
+func TestInferViewNullable(t *testing.T) {
+	bases := []viewBaseColumn{
+		{ColumnName: "email", IsNullable: "NO"},
+		{ColumnName: "nick_name", IsNullable: "YES"},
+		{ColumnName: "id", IsNullable: "NO"}, // id of users
+		{ColumnName: "id", IsNullable: "NO"}, // id of orders joined
+		{ColumnName: "deleted_at", IsNullable: "YES"},
+	}
+	expected := map[string]bool{"email": false, "nick_name": true, "deleted_at": true}
+	if got := inferViewNullable(bases); !reflect.DeepEqual(got, expected) {
+		t.Errorf("view nullable expect: %v, got: %v", expected, got)
+	}
+}
+
+func TestRelateForeignKeys(t *testing.T) {
+	pk := field.GormTag{field.TagKeyGormPrimaryKey: nil}
+	users := &QueryStructMeta{Source: model.Table, TableName: "users", ModelStructName: "User",
+		StructInfo: parser.Param{Type: "User", Package: "model"},
+		Fields:     []*model.Field{{Name: "ID", Type: "int64", ColumnName: "id", GORMTag: pk}}}
+	posts := &QueryStructMeta{Source: model.Table, TableName: "posts", ModelStructName: "Post",
+		StructInfo: parser.Param{Type: "Post", Package: "model"},
+		Fields:     []*model.Field{{Name: "ID", Type: "int64", ColumnName: "id", GORMTag: pk}, {Name: "AuthorID", Type: "int64", ColumnName: "author_id"}},
+		foreignKeys: []*model.ForeignKey{{Name: "fk_posts_author", Column: "author_id", RefTable: "users", RefColumn: "id"},
+			{Name: "fk_posts_tag", Column: "tag_id", RefTable: "tags", RefColumn: "id"}}}
+	profiles := &QueryStructMeta{Source: model.Table, TableName: "profiles", ModelStructName: "Profile",
+		StructInfo:    parser.Param{Type: "Profile", Package: "model"},
+		Fields:        []*model.Field{{Name: "UserID", Type: "int64", ColumnName: "user_id", GORMTag: pk}, {Name: "User", Type: "string", ColumnName: "user"}},
+		foreignKeys:   []*model.ForeignKey{{Name: "fk_profiles_user", Column: "user_id", RefTable: "users"}},
+		uniqueColumns: map[string]bool{"user_id": true}}
+	RelateForeignKeys([]*QueryStructMeta{users, posts, profiles})
+
+	expected := map[*QueryStructMeta][]string{
+		users: {"AuthorPosts []Post has_many foreignKey:AuthorID;references:ID", "Profile *Profile has_one foreignKey:UserID;references:ID"},
+		posts: {"Author *User belongs_to foreignKey:AuthorID;references:ID"},
+		// User field is taken by column, belongs-to is skipped
+		profiles: nil,
+	}
+	for meta, relations := range expected {
+		var got []string
+		for _, f := range meta.Fields {
+			if f.IsRelation() {
+				got = append(got, strings.Join([]string{f.Name, f.Type, string(f.Relation.Relationship()), f.GORMTag.Build()}, " "))
+			}
+		}
+		if !reflect.DeepEqual(got, relations) {
+			t.Errorf("relations of %s expect: %v, got: %v", meta.ModelStructName, relations, got)
+		}
+	}
+}
+
+func TestRelateForeignKeys_FieldMethods(t *testing.T) {
+	pk := field.GormTag{field.TagKeyGormPrimaryKey: nil}
+	users := (&QueryStructMeta{Source: model.Table, TableName: "users", ModelStructName: "User", S: "u",
+		StructInfo: parser.Param{Type: "User", Package: "model"},
+		Fields:     []*model.Field{{Name: "ID", Type: "int64", ColumnName: "id", GORMTag: pk}}}).addGetterMethods().addCloneMethod()
+	orders := &QueryStructMeta{Source: model.Table, TableName: "orders", ModelStructName: "Order", S: "o",
+		StructInfo:  parser.Param{Type: "Order", Package: "model"},
+		Fields:      []*model.Field{{Name: "ID", Type: "int64", ColumnName: "id", GORMTag: pk}, {Name: "UserID", Type: "int64", ColumnName: "user_id"}},
+		foreignKeys: []*model.ForeignKey{{Name: "fk_orders_user", Column: "user_id", RefTable: "users", RefColumn: "id"}}}
+	orders.addGetterMethods()
+	hash := users.Hash()
+	RelateForeignKeys([]*QueryStructMeta{users, orders})
+
+	if !users.hasModelMethod("GetOrders") || !orders.hasModelMethod("GetUser") {
+		t.Errorf("getters of association fields expect generated, got: %v %v", users.ModelMethods, orders.ModelMethods)
+	}
+	if !strings.Contains(users.clone.Body, "cloned.Orders = append(u.Orders[:0:0], u.Orders...)") {
+		t.Errorf("Clone expect copying association field, got: %s", users.clone.Body)
+	}
+	var clones int
+	for _, m := range users.ModelMethods {
+		if m.MethodName == "Clone" {
+			clones++
+		}
+	}
+	if clones != 1 {
+		t.Errorf("Clone expect rebuilt in place, got %d", clones)
+	}
+	if orders.hasModelMethod("Clone") {
+		t.Errorf("Clone of model without it expect not generated")
+	}
+	if users.Hash() == hash {
+		t.Errorf("hash expect changed by association fields")
+	}
+}
+
+func TestQueryStructMeta_AddValidateMethod(t *testing.T) {
+	testcases := []struct {
+		fields       []*model.Field
+		methods      []*parser.Method
+		expectedBody string // empty means no Validate generated
+	}{
+		{[]*model.Field{{Name: "Name", Type: "string", Tag: field.Tag{field.TagKeyJson: "name"}}}, nil, ""},
+		{[]*model.Field{{Name: "Name", Type: "string", Tag: field.Tag{field.TagKeyBinding: "required"}}}, nil,
+			"{\n\treturn BindingValidator.Struct(u)\n} "},
+		// validate tag takes precedence over binding tag declared before
+		{[]*model.Field{{Name: "Name", Type: "string", Tag: field.Tag{field.TagKeyBinding: "required"}},
+			{Name: "Email", Type: "string", Tag: field.Tag{field.TagKeyValidate: "email"}}}, nil,
+			"{\n\treturn Validator.Struct(u)\n} "},
+		// user declared Validate is kept
+		{[]*model.Field{{Name: "Email", Type: "string", Tag: field.Tag{field.TagKeyValidate: "email"}}},
+			[]*parser.Method{{MethodName: "Validate", Body: "{}"}}, "{}"},
+	}
+	for i, testcase := range testcases {
+		meta := (&QueryStructMeta{ModelStructName: "User", S: "u", Fields: testcase.fields, ModelMethods: testcase.methods}).addValidateMethod()
+		var body string
+		for _, m := range meta.ModelMethods {
+			if m.MethodName == "Validate" {
+				body = m.Body
+			}
+		}
+		if body != testcase.expectedBody {
+			t.Errorf("case %d Validate body expect: %q, got: %q", i, testcase.expectedBody, body)
+		}
+		if generated := testcase.expectedBody != "" && testcase.methods == nil; (meta.ValidateTag != "") != generated {
+			t.Errorf("case %d validate tag expect recorded: %t, got: %q", i, generated, meta.ValidateTag)
+		}
+		if len(meta.ImportPkgPaths) != 0 {
+			t.Errorf("case %d model expect no import for shared validator, got: %q", i, meta.ImportPkgPaths)
+		}
+	}
+}
+
+func TestQueryStructMeta_AddSoftDeletableMethod(t *testing.T) {
+	testcases := []struct {
+		fields   []*model.Field
+		expected bool
+	}{
+		{[]*model.Field{{Name: "DeletedAt", Type: "gorm.DeletedAt", ColumnName: "deleted_at"}}, true},
+		// custom named soft delete column
+		{[]*model.Field{{Name: "RemovedAt", Type: "gorm.DeletedAt", ColumnName: "removed_at"}}, true},
+		{[]*model.Field{{Name: "IsDel", Type: "soft_delete.DeletedAt", ColumnName: "is_del"}}, true},
+		{[]*model.Field{{Name: "DeletedAt", Type: "*time.Time", ColumnName: "deleted_at"}}, false},
+		{[]*model.Field{{Name: "DeletedAt", Type: "gorm.DeletedAt", GORMTag: field.GormTag{"-": nil}}}, false},
+	}
+	for _, testcase := range testcases {
+		meta := (&QueryStructMeta{ModelStructName: "User", S: "u", Fields: testcase.fields}).addSoftDeletableMethod()
+		if generated := len(meta.ModelMethods) == 1 && meta.ModelMethods[0].MethodName == "IsSoftDeletable"; generated != testcase.expected {
+			t.Errorf("field %s(%s) IsSoftDeletable expect: %t, got: %t", testcase.fields[0].Name, testcase.fields[0].Type, testcase.expected, generated)
+		}
+	}
+}
+
+func TestQueryStructMeta_AddEntityMethodWithGetters(t *testing.T) {
+	pk := field.GormTag{field.TagKeyGormPrimaryKey: nil}
+	fields := []*model.Field{{Name: "ID", Type: "int64", ColumnName: "id", GORMTag: pk}, {Name: "Name", Type: "string", ColumnName: "name"}}
+	spec := &model.EntityInterface{Name: "repo.Entity", IDType: "uint64"}
+
+	// generated getter of same name is replaced, getter interface declares entity method
+	meta := (&QueryStructMeta{ModelStructName: "User", TableName: "users", S: "u", Fields: fields}).
+		addGetterMethods().addEntityMethod(spec)
+	if len(meta.ModelMethods) != 2 {
+		t.Fatalf("getters with entity method expect 2 methods, got: %d", len(meta.ModelMethods))
+	}
+	for _, methods := range [][]*parser.Method{meta.ModelMethods, meta.Getters()} {
+		if method := methods[0]; method.MethodName != "GetID" || method.Result[0].Type != "uint64" {
+			t.Errorf("GetID expect entity method returning uint64, got: %s() %s", method.MethodName, method.Result[0].Type)
+		}
+	}
+	meta.refreshFieldMethods()
+	if len(meta.ModelMethods) != 2 {
+		t.Errorf("refreshed getters expect 2 methods, got: %d", len(meta.ModelMethods))
+	}
+
+	// getter declared by user is kept
+	userGetter := parser.DefaultMethodGetter("User", "u", "ID", "int64")
+	meta = (&QueryStructMeta{ModelStructName: "User", TableName: "users", S: "u", Fields: fields, ModelMethods: []*parser.Method{userGetter}}).
+		addGetterMethods().addEntityMethod(spec)
+	if len(meta.ModelMethods) != 2 || meta.ModelMethods[0] != userGetter || meta.Getters()[0].Result[0].Type != "int64" {
+		t.Errorf("user declared GetID expect kept, got: %d methods, %s", len(meta.ModelMethods), meta.ModelMethods[0].Result[0].Type)
+	}
+}
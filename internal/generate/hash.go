@@ -0,0 +1,107 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gorm.io/gen/field"
+	"gorm.io/gen/internal/model"
+)
+
+// schemaHash hash of table comment and column, index, check and foreign key metadata read from database
+func schemaHash(tableName, tableComment string, columns []*model.Column) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "table %q %q\n", tableName, tableComment)
+	for _, c := range columns {
+		ct := c.ColumnType
+		typ, _ := ct.ColumnType()
+		nullable, _ := ct.Nullable()
+		pk, _ := ct.PrimaryKey()
+		autoIncr, _ := ct.AutoIncrement()
+		unique, _ := ct.Unique()
+		def, hasDefault := ct.DefaultValue()
+		comment, _ := ct.Comment()
+		fmt.Fprintf(h, "column %q %q %q %t %t %t %t %t %q %q %d %t %t %d %v\n", ct.Name(), ct.DatabaseTypeName(), typ,
+			nullable, pk, autoIncr, unique, hasDefault, def, comment, c.Ordinal, c.Generated, c.Identity, c.Increment, c.ViewNull)
+		for _, idx := range c.Indexes {
+			if idx.Index == nil {
+				continue
+			}
+			idxPK, _ := idx.PrimaryKey()
+			idxUnique, _ := idx.Unique()
+			fmt.Fprintf(h, "index %q %q %t %t %q %d %t %q %t\n", idx.Name(), idx.Columns(), idxPK, idxUnique, idx.Option(),
+				idx.Priority, idx.Composite, idx.TagName, idx.NullsNotDistinct)
+		}
+		for _, check := range c.Checks {
+			fmt.Fprintf(h, "check %q %q\n", check.Name, check.Constraint)
+		}
+		for _, fk := range c.ForeignKeys {
+			fmt.Fprintf(h, "foreign key %q %q %q %q\n", fk.Name, fk.Column, fk.RefTable, fk.RefColumn)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Hash hash of table schema and generated struct(fields, tags, methods, enums etc.), equal hash means generated
+// code is unchanged given the same templates and options, used by incremental generation
+func (b *QueryStructMeta) Hash() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "schema %s\n", b.schemaHash)
+	fmt.Fprintf(h, "struct %q %q %q %q %q %q %q %q %t %t %t\n", b.ModelStructName, b.QueryStructName, b.FileName, b.TableName,
+		b.TableComment, b.SchemaName, b.StructInfo.Package, b.EntityInterface, b.interfaceMode, b.tableCommentDoc, b.docCommentName)
+	fmt.Fprintf(h, "imports %q\nupdatable %q\n", b.ImportPkgPaths, b.UpdatableColumns)
+	for _, f := range b.Fields {
+		fmt.Fprintf(h, "field %q %q %q %q %q %t %q %q %q %q\n", f.Name, f.Type, f.ColumnName, f.ColumnComment, f.CustomGenType,
+			f.MultilineComment, fieldTags(f), f.GORMTag.BuildWithOrder(f.GORMTagOrder), f.EmbeddedColumns, f.GoDefault)
+		if f.Relation != nil {
+			fmt.Fprintf(h, "relation %q %q %q %q\n", f.Relation.Name(), f.Relation.Path(), f.Relation.Type(), f.Relation.RelationshipName())
+		}
+	}
+	for _, m := range b.ModelMethods {
+		fmt.Fprintf(h, "method %q %q %q %q\n", m.Receiver.Type, m.Doc, m.FuncSign(), m.Body)
+	}
+	for _, e := range b.EnumTypes {
+		writeEnumType(h, e)
+	}
+	for _, d := range b.EnumDefaults {
+		fmt.Fprintf(h, "enum default %q %q\n", d.Field, d.Const)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fieldTags tags of field as rendered by Field.Tags, without setting gorm tag on field
+func fieldTags(f *model.Field) string {
+	tag := make(field.Tag, len(f.Tag)+1)
+	for k, v := range f.Tag {
+		tag[k] = v
+	}
+	if _, ok := tag[field.TagKeyGorm]; !ok {
+		if gormTag := strings.TrimSpace(f.GORMTag.BuildWithOrder(f.GORMTagOrder)); gormTag != "" {
+			tag.Set(field.TagKeyGorm, gormTag)
+		}
+	}
+	return tag.Build()
+}
+
+func writeEnumType(w io.Writer, e *EnumType) {
+	labels := make([]string, 0, len(e.Labels))
+	for v, label := range e.Labels {
+		labels = append(labels, v+"="+label)
+	}
+	sort.Strings(labels)
+	fmt.Fprintf(w, "enum %q %q %t %t %q\n", e.Name, e.Values, e.Integer, e.Native, labels)
+}
+
+// HashInterfaceMethods hash of interface methods implemented by query struct, used by incremental generation
+func HashInterfaceMethods(methods []*InterfaceMethod) string {
+	h := sha256.New()
+	for _, m := range methods {
+		fmt.Fprintf(h, "method %q %q %q %q %q %q %q %q\n", m.Package, m.InterfaceName, m.TargetStruct, m.Doc,
+			m.FuncSign(), m.SQLString, m.GormOption, m.Table)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,109 @@
+package generate
+
+import (
+	"strings"
+
+	"gorm.io/gen/field"
+	"gorm.io/gen/internal/model"
+)
+
+// CreateParamFields fields of create param struct,
+// database generated primary key, auto-managed timestamps and read-only columns are excluded
+func (b *QueryStructMeta) CreateParamFields() (fields []*model.Field) {
+	for _, f := range b.paramFields() {
+		if f.IsPrimaryKey() && f.IsDBGenerated() {
+			continue
+		}
+		fields = append(fields, paramField(f, f.Type, f.Tag[field.TagKeyBinding]))
+	}
+	return fields
+}
+
+// UpdateParamFields fields of update param struct, all fields are optional pointers except primary key,
+// auto-managed timestamps and read-only columns are excluded
+func (b *QueryStructMeta) UpdateParamFields() (fields []*model.Field) {
+	for _, f := range b.paramFields() {
+		if f.IsPrimaryKey() {
+			fields = append(fields, paramField(f, strings.TrimPrefix(f.Type, "*"), "required"))
+			continue
+		}
+		fields = append(fields, paramField(f, optionalType(f.Type), optionalBinding(f.Tag[field.TagKeyBinding])))
+	}
+	return fields
+}
+
+// getUpdatableColumns column names which can be updated, primary key(including composite),
+// database generated and generated(STORED or VIRTUAL) columns are excluded besides those excluded by param structs
+func getUpdatableColumns(columns []*model.Column, fields []*model.Field) (names []string) {
+	generated := make(map[string]bool)
+	for _, col := range columns {
+		if col.Generated {
+			generated[col.Name()] = true
+		}
+	}
+	b := &QueryStructMeta{Fields: fields}
+	for _, f := range b.paramFields() {
+		if f.IsPrimaryKey() || f.IsDBGenerated() || generated[f.ColumnName] {
+			continue
+		}
+		names = append(names, f.ColumnName)
+	}
+	return names
+}
+
+// paramFields column fields which can be written by client
+func (b *QueryStructMeta) paramFields() (fields []*model.Field) {
+	for _, f := range b.Fields {
+		if f.ColumnName == "" || f.IsRelation() || f.IsTransient() || f.IsAutoManaged() || f.IsReadOnly() {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// paramField copy field with param type and binding, gorm tag is dropped
+func paramField(f *model.Field, typ, binding string) *model.Field {
+	tag := make(field.Tag, len(f.Tag))
+	for k, v := range f.Tag {
+		if k != field.TagKeyGorm && k != field.TagKeyBinding {
+			tag.Set(k, v)
+		}
+	}
+	if binding != "" {
+		tag.Set(field.TagKeyBinding, binding)
+	}
+	return &model.Field{
+		Name:             f.Name,
+		Type:             typ,
+		ColumnName:       f.ColumnName,
+		ColumnComment:    f.ColumnComment,
+		MultilineComment: f.MultilineComment,
+		Tag:              tag,
+	}
+}
+
+// optionalType pointer type of field, slice/map/pointer type is kept as is
+func optionalType(typ string) string {
+	if strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") {
+		return typ
+	}
+	return "*" + typ
+}
+
+// optionalBinding binding of optional field, required rule is dropped
+func optionalBinding(binding string) string {
+	if binding == "" {
+		return ""
+	}
+	rules := []string{"omitempty"}
+	for _, rule := range strings.Split(binding, ",") {
+		if rule != "required" && rule != "omitempty" && rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 1 {
+		return ""
+	}
+	return strings.Join(rules, ",")
+}
@@ -0,0 +1,72 @@
+package generate
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm/migrator"
+
+	"gorm.io/gen/field"
+	"gorm.io/gen/internal/model"
+)
+
+func TestParamFields(t *testing.T) {
+	b := &QueryStructMeta{Fields: []*model.Field{
+		{Name: "ID", Type: "int64", ColumnName: "id", GORMTag: field.GormTag{field.TagKeyGormPrimaryKey: nil, field.TagKeyGormAutoIncrement: {"true"}}},
+		{Name: "Code", Type: "string", ColumnName: "code", GORMTag: field.GormTag{field.TagKeyGormPrimaryKey: nil}, Tag: field.Tag{field.TagKeyGorm: "column:code"}},
+		{Name: "Name", Type: "string", ColumnName: "name", Tag: field.Tag{field.TagKeyJson: "name", field.TagKeyBinding: "required,max=64"}},
+		{Name: "Age", Type: "*int32", ColumnName: "age", Tag: field.Tag{field.TagKeyBinding: "omitempty,min=0"}},
+		{Name: "Tags", Type: "[]string", ColumnName: "tags"},
+		{Name: "Search", Type: "string", ColumnName: "search", GORMTag: field.GormTag{field.TagKeyGormReadOnly: nil}},
+		{Name: "Version", Type: "int64", ColumnName: "version", GORMTag: field.GormTag{field.TagKeyGormReadOnly: nil, field.TagKeyGormWrite: {"false"}}},
+		{Name: "Score", Type: "int64", ColumnName: "score", GORMTag: field.GormTag{field.TagKeyGormReadOnly: nil, field.TagKeyGormWrite: nil}},
+		{Name: "CreatedAt", Type: "time.Time", ColumnName: "created_at"},
+		{Name: "DeletedAt", Type: "gorm.DeletedAt", ColumnName: "removed_at"},
+		{Name: "FullName", Type: "string", Tag: field.Tag{field.TagKeyGorm: "-"}},
+	}}
+	build := func(fields []*model.Field) (got []string) {
+		for _, f := range fields {
+			got = append(got, strings.TrimSpace(f.Name+" "+f.Type+" "+f.Tag[field.TagKeyBinding]))
+		}
+		return got
+	}
+
+	// auto increment primary key is generated by database, other primary key is given by client
+	expected := []string{"Code string", "Name string required,max=64", "Age *int32 omitempty,min=0", "Tags []string", "Score int64"}
+	if got := build(b.CreateParamFields()); !reflect.DeepEqual(got, expected) {
+		t.Errorf("create param fields expect: %q, got: %q", expected, got)
+	}
+	// primary key is required to locate record, other fields are optional
+	expected = []string{"ID int64 required", "Code string required", "Name *string omitempty,max=64", "Age *int32 omitempty,min=0",
+		"Tags []string", "Score *int64"}
+	if got := build(b.UpdateParamFields()); !reflect.DeepEqual(got, expected) {
+		t.Errorf("update param fields expect: %q, got: %q", expected, got)
+	}
+	if got := b.UpdateParamFields()[2].Tag[field.TagKeyJson]; got != "name" {
+		t.Errorf("update param field json tag expect: name, got: %s", got)
+	}
+	if _, ok := b.CreateParamFields()[0].Tag[field.TagKeyGorm]; ok {
+		t.Errorf("param field expect gorm tag dropped")
+	}
+}
+
+func TestGetUpdatableColumns(t *testing.T) {
+	columns := []*model.Column{
+		{Generated: true, ColumnType: migrator.ColumnType{NameValue: sql.NullString{String: "full_name", Valid: true}}},
+	}
+	fields := []*model.Field{
+		{Name: "TenantID", Type: "int64", ColumnName: "tenant_id", GORMTag: field.GormTag{field.TagKeyGormPrimaryKey: nil}},
+		{Name: "ID", Type: "int64", ColumnName: "id", GORMTag: field.GormTag{field.TagKeyGormPrimaryKey: nil}},
+		{Name: "Name", Type: "string", ColumnName: "name"},
+		{Name: "FullName", Type: "string", ColumnName: "full_name"},
+		{Name: "Search", Type: "string", ColumnName: "search", GORMTag: field.GormTag{field.TagKeyGormReadOnly: nil}},
+		{Name: "Age", Type: "*int32", ColumnName: "age"},
+		{Name: "UpdatedAt", Type: "time.Time", ColumnName: "updated_at"},
+		{Name: "Extra", Type: "string", Tag: field.Tag{field.TagKeyGorm: "-"}},
+	}
+	if got, expected := getUpdatableColumns(columns, fields), []string{"name", "age"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("updatable columns expect: %v, got: %v", expected, got)
+	}
+}
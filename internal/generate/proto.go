@@ -0,0 +1,298 @@
+package generate
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gen/field"
+	"gorm.io/gen/internal/model"
+)
+
+// ProtoMessage protobuf message of model generated from table
+type ProtoMessage struct {
+	Name    string
+	GoName  string // struct name generated by protoc-gen-go
+	Comment string
+	Fields  []*ProtoField
+}
+
+// ProtoField protobuf message field of model field
+type ProtoField struct {
+	Name     string // column name of model field
+	GoName   string // struct field name generated by protoc-gen-go
+	Type     string // scalar, well-known wrapper or timestamp type
+	Number   int
+	Optional bool // proto3 optional
+	Comment  string
+
+	ToProto   string // statements assigning model field(m) to message field(p)
+	FromProto string // statements assigning message field(p) to model field(m)
+}
+
+// protoScalar protobuf type and protoc-gen-go Go type of model field base type, wrapper is used for nullable field by ProtoNullableWrapper
+type protoScalar struct {
+	proto   string
+	goType  string
+	wrapper string
+}
+
+var protoScalars = map[string]protoScalar{
+	"bool":      {"bool", "bool", "BoolValue"},
+	"int":       {"int64", "int64", "Int64Value"},
+	"int8":      {"int32", "int32", "Int32Value"},
+	"int16":     {"int32", "int32", "Int32Value"},
+	"int32":     {"int32", "int32", "Int32Value"},
+	"int64":     {"int64", "int64", "Int64Value"},
+	"uint":      {"uint64", "uint64", "UInt64Value"},
+	"uint8":     {"uint32", "uint32", "UInt32Value"},
+	"uint16":    {"uint32", "uint32", "UInt32Value"},
+	"uint32":    {"uint32", "uint32", "UInt32Value"},
+	"uint64":    {"uint64", "uint64", "UInt64Value"},
+	"float32":   {"float", "float32", "FloatValue"},
+	"float64":   {"double", "float64", "DoubleValue"},
+	"string":    {"string", "string", "StringValue"},
+	"[]byte":    {"bytes", "[]byte", "BytesValue"},
+	"time.Time": {"google.protobuf.Timestamp", "*timestamppb.Timestamp", ""},
+
+	"datatypes.JSON":  {"bytes", "[]byte", "BytesValue"},
+	"json.RawMessage": {"bytes", "[]byte", "BytesValue"},
+}
+
+// protoJSONValue well-known message of decoded json field(JSONTypeMap), constructor and conversion back of structpb
+type protoJSONValue struct {
+	proto string
+	new   string
+	as    string
+}
+
+var protoJSONValues = map[string]protoJSONValue{
+	"map[string]interface{}": {"google.protobuf.Struct", "structpb.NewStruct", "AsMap"},
+	"map[string]any":         {"google.protobuf.Struct", "structpb.NewStruct", "AsMap"},
+	"[]interface{}":          {"google.protobuf.ListValue", "structpb.NewList", "AsSlice"},
+	"[]any":                  {"google.protobuf.ListValue", "structpb.NewList", "AsSlice"},
+}
+
+// protoNullTypes base type and value field of nullable struct types
+var protoNullTypes = map[string][2]string{
+	"sql.NullBool":    {"bool", "Bool"},
+	"sql.NullByte":    {"uint8", "Byte"},
+	"sql.NullInt16":   {"int16", "Int16"},
+	"sql.NullInt32":   {"int32", "Int32"},
+	"sql.NullInt64":   {"int64", "Int64"},
+	"sql.NullFloat64": {"float64", "Float64"},
+	"sql.NullString":  {"string", "String"},
+	"sql.NullTime":    {"time.Time", "Time"},
+	"gorm.DeletedAt":  {"time.Time", "Time"},
+}
+
+var protoIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ProtoMessage protobuf message of model, fields are named by column and numbered by protobuf tag(FieldProtobufTag),
+// column ordinal position or position among columns, so that skipping or adding a column does not renumber others,
+// enum fields are declared by backing type, relation, transient and unsupported type(e.g. custom type) fields are skipped with warning
+func (b *QueryStructMeta) ProtoMessage(nullable model.ProtoNullableMode) *ProtoMessage {
+	msg := &ProtoMessage{Name: b.ModelStructName, GoName: goCamelCase(b.ModelStructName), Comment: b.TableComment}
+	if msg.Comment == "" {
+		msg.Comment = b.ModelStructName + " mapped from table <" + b.TableName + ">"
+	}
+
+	scalars := make(map[string]protoScalar, len(b.EnumTypes))
+	for _, e := range b.EnumTypes {
+		if e.Integer || e.Native {
+			scalars[e.Name] = protoScalar{"int32", "int32", "Int32Value"}
+		} else {
+			scalars[e.Name] = protoScalar{"string", "string", "StringValue"}
+		}
+	}
+
+	used := make(map[int]bool)
+	var unnumbered []*ProtoField
+	position := 0
+	for _, f := range b.Fields {
+		if f.IsRelation() || f.IsTransient() || f.ColumnName == "" {
+			continue
+		}
+		position++
+		if !protoIdent.MatchString(f.ColumnName) {
+			b.warn("skip protobuf field %s.%s: column name is not a valid identifier", b.TableName, f.ColumnName)
+			continue
+		}
+		pf := newProtoField(f, nullable, scalars)
+		if pf == nil {
+			b.warn("skip protobuf field %s.%s: unsupported type %s", b.TableName, f.ColumnName, f.Type)
+			continue
+		}
+		msg.Fields = append(msg.Fields, pf)
+
+		number := f.Ordinal
+		if pb := strings.Split(f.Tag[field.TagKeyProtobuf], ","); len(pb) > 1 {
+			if n, err := strconv.Atoi(pb[1]); err == nil && n > 0 {
+				number = n
+			}
+		}
+		if number <= 0 {
+			number = position
+		}
+		if used[number] {
+			unnumbered = append(unnumbered, pf)
+			continue
+		}
+		pf.Number, used[number] = number, true
+	}
+
+	next := 1
+	for _, pf := range unnumbered { // number conflicting with others
+		for used[next] {
+			next++
+		}
+		pf.Number, used[next] = next, true
+	}
+	return msg
+}
+
+// Imports well-known proto files used by message fields
+func (m *ProtoMessage) Imports() (imports []string) {
+	var timestamp, structs, wrapper bool
+	for _, f := range m.Fields {
+		switch {
+		case f.Type == "google.protobuf.Timestamp":
+			timestamp = true
+		case f.Type == "google.protobuf.Struct" || f.Type == "google.protobuf.ListValue":
+			structs = true
+		case strings.HasPrefix(f.Type, "google.protobuf."):
+			wrapper = true
+		}
+	}
+	if structs {
+		imports = append(imports, "google/protobuf/struct.proto")
+	}
+	if timestamp {
+		imports = append(imports, "google/protobuf/timestamp.proto")
+	}
+	if wrapper {
+		imports = append(imports, "google/protobuf/wrappers.proto")
+	}
+	return imports
+}
+
+// CommentLines lines of message comment
+func (m *ProtoMessage) CommentLines() []string { return commentLines(m.Comment) }
+
+// CommentLines lines of field comment
+func (f *ProtoField) CommentLines() []string { return commentLines(f.Comment) }
+
+func commentLines(comment string) []string {
+	if comment = strings.TrimSpace(comment); comment == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(comment, "\r\n", "\n"), "\n")
+}
+
+// newProtoField protobuf field of model field with conversion statements, nil if type is unsupported,
+// pointer and sql.Null* field is nullable, types of scalars(e.g. enum types) are supported besides builtin ones
+func newProtoField(f *model.Field, nullable model.ProtoNullableMode, scalars map[string]protoScalar) *ProtoField {
+	mf, pf := "m."+f.Name, "p."+goCamelCase(f.ColumnName)
+	if v, ok := protoJSONValues[f.Type]; ok { // message field, nil means null
+		return &ProtoField{Name: f.ColumnName, GoName: goCamelCase(f.ColumnName), Type: v.proto, Comment: f.ColumnComment,
+			ToProto:   ifBlock(mf+" != nil", ifBlock("v, err := "+v.new+"("+mf+"); err == nil", pf+" = v")),
+			FromProto: ifBlock(pf+" != nil", mf+" = "+pf+"."+v.as+"()"),
+		}
+	}
+
+	typ, nullType, present, value := f.Type, "", "", mf
+	if null, ok := protoNullTypes[typ]; ok {
+		typ, nullType, present, value = null[0], f.Type, mf+".Valid", mf+"."+null[1]
+	} else if strings.HasPrefix(typ, "*") {
+		typ, present, value = typ[1:], mf+" != nil", "*"+mf
+	}
+	scalar, ok := protoScalars[typ]
+	if !ok {
+		if scalar, ok = scalars[typ]; !ok {
+			return nil
+		}
+	}
+
+	toProto := func(v string) string {
+		switch {
+		case typ == "time.Time":
+			return "timestamppb.New(" + v + ")"
+		case typ != scalar.goType:
+			return scalar.goType + "(" + v + ")"
+		}
+		return v
+	}
+	fromProto := func(v string) string {
+		switch {
+		case typ == "time.Time":
+			return v + ".AsTime()"
+		case typ != scalar.goType:
+			return typ + "(" + v + ")"
+		}
+		return v
+	}
+
+	p := &ProtoField{Name: f.ColumnName, GoName: goCamelCase(f.ColumnName), Type: scalar.proto, Comment: f.ColumnComment}
+	var assign, pPresent, pValue string
+	switch {
+	case typ == "time.Time": // message field, nil means null
+		assign, pPresent, pValue = pf+" = "+toProto(value), pf+" != nil", pf
+	case present == "":
+		assign, pValue = pf+" = "+toProto(value), pf
+	case nullable == model.ProtoNullableWrapper:
+		p.Type = "google.protobuf." + scalar.wrapper
+		assign = pf + " = wrapperspb." + strings.TrimSuffix(scalar.wrapper, "Value") + "(" + toProto(value) + ")"
+		pPresent, pValue = pf+" != nil", pf+".GetValue()"
+	case scalar.goType == "[]byte": // optional bytes is generated as []byte, nil means null
+		p.Optional = true
+		assign, pPresent, pValue = pf+" = "+toProto(value), pf+" != nil", pf
+	default:
+		p.Optional = true
+		assign = "v := " + toProto(value) + "\n" + pf + " = &v"
+		pPresent, pValue = pf+" != nil", "*"+pf
+	}
+	p.ToProto = ifBlock(present, assign)
+
+	switch {
+	case nullType != "":
+		p.FromProto = ifBlock(pPresent, mf+" = "+nullType+"{"+protoNullTypes[nullType][1]+": "+fromProto(pValue)+", Valid: true}")
+	case present != "":
+		p.FromProto = ifBlock(pPresent, "v := "+fromProto(pValue)+"\n"+mf+" = &v")
+	default:
+		p.FromProto = ifBlock(pPresent, mf+" = "+fromProto(pValue))
+	}
+	return p
+}
+
+func ifBlock(cond, stmt string) string {
+	if cond == "" {
+		return stmt
+	}
+	return "if " + cond + " {\n" + stmt + "\n}"
+}
+
+// goCamelCase Go name of protobuf identifier generated by protoc-gen-go, e.g. user_id => UserId, _key => XKey
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+		case c >= '0' && c <= '9':
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool { return c >= 'a' && c <= 'z' }
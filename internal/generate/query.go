@@ -33,13 +33,39 @@ type QueryStructMeta struct {
 	ModelStructName string // origin/model struct name
 	TableName       string // table name in db server
 	TableComment    string // table comment in db server
+	SchemaName      string // schema qualifying table name in TableName(), empty means unqualified
 	StructInfo      parser.Param
 	Fields          []*model.Field
 	Source          model.SourceCode
 	ImportPkgPaths  []string
 	ModelMethods    []*parser.Method // user custom method bind to db base struct
+	EnumTypes       []*EnumType      // named types of enum columns
+	EnumDefaults    []EnumDefault    // enum fields initialized by typed consts of column defaults
+	EntityInterface string           // interface implemented by model with ID getter, e.g. repo.Entity
+	ValidateTag     string           // tag validated by generated Validate method, validator is shared in model package
 
-	interfaceMode bool
+	UpdatableColumns []string // updatable column names used by partial update
+
+	interfaceMode   bool
+	tableCommentDoc bool
+	docCommentName  bool // prefix doc comment from table comment with struct name
+
+	foreignKeys   []*model.ForeignKey // foreign keys of table, used to infer association fields
+	uniqueColumns map[string]bool     // columns being single primary key or unique, foreign key on them is has-one
+
+	schemaHash string // hash of table metadata read from database, see Hash
+
+	getters map[string]*parser.Method // generated getters of fields by name, added for association fields too
+	clone   *parser.Method            // generated Clone, rebuilt when association fields are added
+	entity  *parser.Method            // generated ID getter of entity interface, replaces getter of same name
+}
+
+// QualifiedTableName table name qualified with schema, e.g. sales.orders
+func (b *QueryStructMeta) QualifiedTableName() string {
+	if b.SchemaName == "" {
+		return b.TableName
+	}
+	return b.SchemaName + "." + b.TableName
 }
 
 // parseStruct get all elements of struct with gorm's Parse, ignore unexported elements
@@ -161,15 +187,40 @@ func (b *QueryStructMeta) StructComment() string {
 	return `mapped from object`
 }
 
-// QueryStructComment query struct comment
+// StructDocComment struct doc comment, each line starts with //
+func (b *QueryStructMeta) StructDocComment() string {
+	if !b.tableCommentDoc || b.TableComment == "" {
+		return fmt.Sprintf("// %s %s", b.ModelStructName, b.StructComment())
+	}
+
+	comment := model.StripCommentDirectives(b.TableComment)
+	if comment == "" {
+		return fmt.Sprintf("// %s %s", b.ModelStructName, b.StructComment())
+	}
+	if b.docCommentName && !strings.HasPrefix(comment, b.ModelStructName+" ") { // godoc convention: start with struct name
+		comment = b.ModelStructName + " " + comment
+	}
+	return lineComment(comment)
+}
+
+// QueryStructComment query struct comment, each line starts with //
 func (b *QueryStructMeta) QueryStructComment() string {
 	if b.TableComment != "" {
-		return fmt.Sprintf(`// %s %s`, b.QueryStructName, b.TableComment)
+		return lineComment(b.QueryStructName + " " + b.TableComment)
 	}
 
 	return ``
 }
 
+// lineComment prefix each line of comment with //
+func lineComment(comment string) string {
+	lines := strings.Split(strings.ReplaceAll(comment, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("// "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
 // ReviseDIYMethod check diy method duplication name
 func (b *QueryStructMeta) ReviseDIYMethod() error {
 	var duplicateMethodName []string
@@ -205,6 +256,292 @@ func (b *QueryStructMeta) ReviseDIYMethod() error {
 	return nil
 }
 
+// addAfterFindHook add empty AfterFind hook for model with transient fields, keep user's AfterFind if exists
+func (b *QueryStructMeta) addAfterFindHook() *QueryStructMeta {
+	if !b.hasTransientField() || b.hasModelMethod("AfterFind") {
+		return b
+	}
+	b.ModelMethods = append(b.ModelMethods, parser.DefaultMethodAfterFind(b.ModelStructName))
+	return b
+}
+
+// addGoDefaultHook add BeforeCreate hook assigning Go-managed defaults to zero fields, all of them share one hook,
+// keep user's BeforeCreate if exists
+func (b *QueryStructMeta) addGoDefaultHook() *QueryStructMeta {
+	if b.hasModelMethod("BeforeCreate") {
+		return b
+	}
+	var assigns []string
+	for _, f := range b.Fields {
+		if f.GoDefault == "" {
+			continue
+		}
+		target := b.S + "." + f.Name
+		assigns = append(assigns, fmt.Sprintf("if %s {\n\t\t%s = %s\n\t}", zeroCondition(target, f.Type), target, f.GoDefault))
+	}
+	if len(assigns) == 0 {
+		return b
+	}
+	b.ModelMethods = append(b.ModelMethods, parser.DefaultMethodBeforeCreate(b.ModelStructName, b.S, assigns))
+	return b
+}
+
+// zeroCondition condition of value being zero value of type, e.g. u.Name == "", u.Nick == nil, u.CreatedAt.IsZero()
+func zeroCondition(value, typ string) string {
+	switch {
+	case zeroConstValue(typ) != "":
+		return value + " == " + zeroConstValue(typ)
+	case strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map["):
+		return value + " == nil"
+	case typ == "time.Time":
+		return value + ".IsZero()"
+	}
+	return fmt.Sprintf("%s == (%s{})", value, typ)
+}
+
+// addValidateMethod add Validate method for model with validate(or binding) tag, keep user's Validate if exists
+func (b *QueryStructMeta) addValidateMethod() *QueryStructMeta {
+	if b.hasModelMethod("Validate") {
+		return b
+	}
+	var tagName string
+	for _, f := range b.Fields {
+		if _, ok := f.Tag[field.TagKeyValidate]; ok {
+			tagName = field.TagKeyValidate
+			break
+		}
+		if _, ok := f.Tag[field.TagKeyBinding]; ok {
+			tagName = field.TagKeyBinding
+		}
+	}
+	if tagName == "" {
+		return b
+	}
+	b.ValidateTag = tagName
+	b.ModelMethods = append(b.ModelMethods, parser.DefaultMethodValidate(b.ModelStructName, b.S, tagName))
+	return b
+}
+
+// addSoftDeletableMethod add IsSoftDeletable marker for model with soft delete field, column name is not checked
+// so custom named soft delete column(e.g. removed_at mapped to gorm.DeletedAt) is detected
+func (b *QueryStructMeta) addSoftDeletableMethod() *QueryStructMeta {
+	if b.hasModelMethod("IsSoftDeletable") {
+		return b
+	}
+	for _, f := range b.Fields {
+		if f.IsSoftDelete() {
+			b.ModelMethods = append(b.ModelMethods, parser.DefaultMethodSoftDeletable(b.ModelStructName, f.Name))
+			break
+		}
+	}
+	return b
+}
+
+// clonedSliceTypes named slice types of column mapping copied by Clone
+var clonedSliceTypes = map[string]bool{
+	"datatypes.JSON":  true,
+	"json.RawMessage": true,
+	"sql.RawBytes":    true,
+	"pq.BoolArray":    true,
+	"pq.ByteaArray":   true,
+	"pq.Float32Array": true,
+	"pq.Float64Array": true,
+	"pq.Int32Array":   true,
+	"pq.Int64Array":   true,
+	"pq.StringArray":  true,
+}
+
+// isClonedSlice type is slice or named slice type copied by Clone
+func isClonedSlice(typ string) bool {
+	return strings.HasPrefix(typ, "[]") || clonedSliceTypes[typ]
+}
+
+// addCloneMethod add Clone deep copy method, pointer, slice and map fields are copied one level deep(slice pointed
+// to is copied too), their elements and custom types(may contain channel, mutex etc.) are shallow copied
+func (b *QueryStructMeta) addCloneMethod() *QueryStructMeta {
+	if b.hasModelMethod("Clone") {
+		return b
+	}
+	b.clone = parser.DefaultMethodClone(b.ModelStructName, b.S, b.cloneCopies())
+	b.ModelMethods = append(b.ModelMethods, b.clone)
+	return b
+}
+
+// cloneCopies statements copying pointer, slice and map fields in Clone
+func (b *QueryStructMeta) cloneCopies() (copies []string) {
+	for _, f := range b.Fields {
+		src, dst := b.S+"."+f.Name, "cloned."+f.Name
+		switch typ := f.Type; {
+		case typ == "*big.Int": // copy of big.Int shares its words
+			copies = append(copies, fmt.Sprintf("if %s != nil {\n\t\t%s = new(big.Int).Set(%s)\n\t}", src, dst, src))
+		case strings.HasPrefix(typ, "*") && isClonedSlice(typ[1:]):
+			copies = append(copies, fmt.Sprintf("if %s != nil {\n\t\tv := append((*%s)[:0:0], (*%s)...)\n\t\t%s = &v\n\t}", src, src, src, dst))
+		case strings.HasPrefix(typ, "*"):
+			copies = append(copies, fmt.Sprintf("if %s != nil {\n\t\tv := *%s\n\t\t%s = &v\n\t}", src, src, dst))
+		case isClonedSlice(typ):
+			copies = append(copies, fmt.Sprintf("if %s != nil {\n\t\t%s = append(%s[:0:0], %s...)\n\t}", src, dst, src, src))
+		case strings.HasPrefix(typ, "map["):
+			copies = append(copies, fmt.Sprintf("if %s != nil {\n\t\t%s = make(%s, len(%s))\n\t\tfor k, v := range %s {\n\t\t\t%s[k] = v\n\t\t}\n\t}", src, dst, typ, src, src, dst))
+		}
+	}
+	return copies
+}
+
+// refreshFieldMethods rebuild generated methods depending on fields after association fields are added,
+// getters of new fields are added and Clone copies them
+func (b *QueryStructMeta) refreshFieldMethods() {
+	if b.getters != nil {
+		b.addGetterMethods()
+	}
+	for i, method := range b.ModelMethods {
+		if b.clone != nil && method == b.clone {
+			b.clone = parser.DefaultMethodClone(b.ModelStructName, b.S, b.cloneCopies())
+			b.ModelMethods[i] = b.clone
+		}
+	}
+}
+
+// addFunctionalIndexesMethod add FunctionalIndexes returning DDL of functional indexes, skipped if table has none
+func (b *QueryStructMeta) addFunctionalIndexesMethod(ddls []string) *QueryStructMeta {
+	if len(ddls) == 0 || b.hasModelMethod("FunctionalIndexes") {
+		return b
+	}
+	b.ModelMethods = append(b.ModelMethods, parser.DefaultMethodFunctionalIndexes(b.ModelStructName, b.TableName, ddls))
+	return b
+}
+
+// addIndexesMethod add Indexes returning index definitions collected from columns, skipped if table has no index
+func (b *QueryStructMeta) addIndexesMethod(columns []*model.Column) *QueryStructMeta {
+	if b.hasModelMethod("Indexes") {
+		return b
+	}
+	var defs []string
+	for _, def := range model.IndexDefs(columns) {
+		defs = append(defs, fmt.Sprintf("{Name: %q, Columns: %#v, Unique: %t, Type: %q}", def.Name, def.Columns, def.Unique, def.Type))
+	}
+	if len(defs) == 0 {
+		return b
+	}
+	b.ModelMethods = append(b.ModelMethods, parser.DefaultMethodIndexes(b.ModelStructName, b.TableName, defs))
+	return b
+}
+
+// addEntityMethod add ID getter implementing entity interface, keep user's getter if exists,
+// generated field getter of same name is replaced as its result type may differ from ID type,
+// model without detectable ID field(e.g. composite primary key) is skipped with warning
+func (b *QueryStructMeta) addEntityMethod(spec *model.EntityInterface) *QueryStructMeta {
+	idField := b.entityIDField(spec.IDColumns[b.TableName])
+	if idField == nil {
+		b.db.Logger.Warn(context.Background(), "model %s has no single primary key, specify ID column of table %s to implement %s",
+			b.ModelStructName, b.TableName, spec.Name)
+		return b
+	}
+	methodName := spec.Method
+	if methodName == "" {
+		methodName = "GetID"
+	}
+	method := parser.DefaultMethodEntityID(b.ModelStructName, b.S, methodName, idField.Name, idField.Type, spec.IDType)
+	switch i := b.modelMethodIndex(methodName); {
+	case i < 0:
+		b.ModelMethods = append(b.ModelMethods, method)
+		b.entity = method
+	case b.ModelMethods[i] == b.getters[methodName]:
+		b.ModelMethods[i] = method
+		b.entity = method
+	}
+	b.EntityInterface = spec.Name
+	return b
+}
+
+// entityIDField field of ID column, single primary key field if column is empty, nil for composite primary key
+func (b *QueryStructMeta) entityIDField(column string) (idField *model.Field) {
+	for _, f := range b.Fields {
+		switch {
+		case column != "":
+			if f.ColumnName == column {
+				return f
+			}
+		case f.IsPrimaryKey():
+			if idField != nil {
+				return nil
+			}
+			idField = f
+		}
+	}
+	return idField
+}
+
+// addImportPkgPaths add import paths for model file, ImportPkgPaths may be shared with other models so copy it
+func (b *QueryStructMeta) addImportPkgPaths(paths ...string) {
+	b.ImportPkgPaths = append(append(make([]string, 0, len(b.ImportPkgPaths)+len(paths)), b.ImportPkgPaths...), paths...)
+}
+
+func (b *QueryStructMeta) hasTransientField() bool {
+	for _, f := range b.Fields {
+		if f.IsTransient() {
+			return true
+		}
+	}
+	return false
+}
+
+// Getters getter methods of model fields named as Get{Field}, embedded field is named by its type, e.g. GetModel() gorm.Model,
+// getter replaced by ID getter of entity interface is returned as it is
+func (b *QueryStructMeta) Getters() []*parser.Method {
+	getters := make([]*parser.Method, 0, len(b.Fields))
+	for _, f := range b.Fields {
+		name := f.Name
+		if name == "" { // embedded field
+			name = embeddedFieldName(f.Type)
+		}
+		if name == "" {
+			continue
+		}
+		if b.entity != nil && b.entity.MethodName == "Get"+name {
+			getters = append(getters, b.entity)
+			continue
+		}
+		getters = append(getters, parser.DefaultMethodGetter(b.ModelStructName, b.S, name, f.Type))
+	}
+	return getters
+}
+
+// addGetterMethods add getter of each field, keep user's getter if exists
+func (b *QueryStructMeta) addGetterMethods() *QueryStructMeta {
+	if b.getters == nil {
+		b.getters = make(map[string]*parser.Method)
+	}
+	for _, getter := range b.Getters() {
+		if !b.hasModelMethod(getter.MethodName) {
+			b.ModelMethods = append(b.ModelMethods, getter)
+			b.getters[getter.MethodName] = getter
+		}
+	}
+	return b
+}
+
+// embeddedFieldName field name of embedded type, e.g. *gorm.Model -> Model, Base[int] -> Base
+func embeddedFieldName(typ string) string {
+	name := strings.TrimLeft(typ, "*")
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func (b *QueryStructMeta) hasModelMethod(name string) bool {
+	return b.modelMethodIndex(name) >= 0
+}
+
+func (b *QueryStructMeta) modelMethodIndex(name string) int {
+	for i, method := range b.ModelMethods {
+		if method.MethodName == name {
+			return i
+		}
+	}
+	return -1
+}
+
 func (b *QueryStructMeta) addMethodFromAddMethodOpt(methods ...interface{}) *QueryStructMeta {
 	for _, method := range methods {
 		modelMethods, err := parser.GetModelMethod(method)
@@ -0,0 +1,244 @@
+package generate
+
+import (
+	"fmt"
+	"go/parser"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"gorm.io/gen/field"
+	"gorm.io/gen/internal/model"
+)
+
+func TestQueryStructMeta_AddCloneMethod(t *testing.T) {
+	meta := (&QueryStructMeta{ModelStructName: "Customer", S: "c", Fields: []*model.Field{
+		{Name: "ID", Type: "int64"},
+		{Name: "Name", Type: "*string"},
+		{Name: "Data", Type: "datatypes.JSON"},
+		{Name: "Tags", Type: "[]string"},
+		{Name: "Attrs", Type: "map[string]interface{}"},
+		{Name: "Labels", Type: "pq.StringArray"},
+		{Name: "Body", Type: "*datatypes.JSON"},
+		{Name: "Balance", Type: "*big.Int"},
+	}}).addCloneMethod()
+	if len(meta.ModelMethods) != 1 {
+		t.Fatalf("clone method expect: 1, got: %d", len(meta.ModelMethods))
+	}
+	body := meta.ModelMethods[0].Body
+	if _, err := parser.ParseExpr("func() *Customer " + body); err != nil {
+		t.Fatalf("clone method body is invalid: %s\n%s", err, body)
+	}
+	for _, expected := range []string{"cloned.Name = &v", "cloned.Data = append(c.Data[:0:0], c.Data...)",
+		"cloned.Tags = append(c.Tags[:0:0], c.Tags...)", "cloned.Attrs = make(map[string]interface{}, len(c.Attrs))",
+		"cloned.Labels = append(c.Labels[:0:0], c.Labels...)", "v := append((*c.Body)[:0:0], (*c.Body)...)\n\t\tcloned.Body = &v",
+		"cloned.Balance = new(big.Int).Set(c.Balance)"} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("clone method body expect contains: %q, got: %s", expected, body)
+		}
+	}
+}
+
+func TestQueryStructMeta_DocComment(t *testing.T) {
+	testcases := []struct {
+		comment, expectedDoc, expectedQuery string
+		tableCommentDoc, docCommentName     bool
+	}{
+		{"", "// User mapped from table <users>", "", true, true},
+		{"users of app", "// User users of app", "// user users of app", false, false},
+		{"users of app\nsecond line", "// users of app\n// second line", "// user users of app\n// second line", true, false},
+		{"users of app\r\n\r\n{{enum:status}}second line", "// User users of app\n//\n// second line", "// user users of app\n//\n// {{enum:status}}second line", true, true},
+		{"User of app", "// User of app", "// user User of app", true, true},
+		{"Users of app", "// User Users of app", "// user Users of app", true, true},
+	}
+	for _, testcase := range testcases {
+		meta := &QueryStructMeta{ModelStructName: "User", QueryStructName: "user", TableName: "users", TableComment: testcase.comment,
+			tableCommentDoc: testcase.tableCommentDoc, docCommentName: testcase.docCommentName}
+		if doc := meta.StructDocComment(); doc != testcase.expectedDoc {
+			t.Errorf("struct doc comment of %q expect: %q, got: %q", testcase.comment, testcase.expectedDoc, doc)
+		}
+		if doc := meta.QueryStructComment(); doc != testcase.expectedQuery {
+			t.Errorf("query struct comment of %q expect: %q, got: %q", testcase.comment, testcase.expectedQuery, doc)
+		}
+	}
+}
+
+func TestQueryStructMeta_AddEntityMethod(t *testing.T) {
+	pk := field.GormTag{field.TagKeyGormPrimaryKey: nil}
+	db := &gorm.DB{Config: &gorm.Config{Logger: logger.Discard}}
+	testcases := []struct {
+		fields       []*model.Field
+		spec         model.EntityInterface
+		expectedType string
+		expectedBody string
+	}{
+		{[]*model.Field{{Name: "ID", Type: "int64", ColumnName: "id", GORMTag: pk}, {Name: "Name", Type: "string", ColumnName: "name"}},
+			model.EntityInterface{Name: "repo.Entity"}, "int64", "return u.ID"},
+		{[]*model.Field{{Name: "ID", Type: "int32", ColumnName: "id", GORMTag: pk}},
+			model.EntityInterface{Name: "repo.Entity", Method: "EntityID", IDType: "int64"}, "int64", "return int64(u.ID)"},
+		{[]*model.Field{{Name: "TenantID", Type: "int64", ColumnName: "tenant_id", GORMTag: pk}, {Name: "UserNo", Type: "string", ColumnName: "user_no", GORMTag: pk}},
+			model.EntityInterface{Name: "repo.Entity", IDColumns: map[string]string{"users": "user_no"}}, "string", "return u.UserNo"},
+		{[]*model.Field{{Name: "TenantID", Type: "int64", ColumnName: "tenant_id", GORMTag: pk}, {Name: "UserNo", Type: "string", ColumnName: "user_no", GORMTag: pk}},
+			model.EntityInterface{Name: "repo.Entity"}, "", ""},
+	}
+	for _, testcase := range testcases {
+		meta := (&QueryStructMeta{db: db, ModelStructName: "User", TableName: "users", S: "u", Fields: testcase.fields}).
+			addEntityMethod(&testcase.spec)
+		if testcase.expectedType == "" {
+			if len(meta.ModelMethods) != 0 || meta.EntityInterface != "" {
+				t.Errorf("model without single primary key expect no entity method, got: %d methods, interface %q",
+					len(meta.ModelMethods), meta.EntityInterface)
+			}
+			continue
+		}
+		if len(meta.ModelMethods) != 1 || meta.EntityInterface != testcase.spec.Name {
+			t.Fatalf("entity method expect: 1 method of %s, got: %d methods of %q", testcase.spec.Name, len(meta.ModelMethods), meta.EntityInterface)
+		}
+		method := meta.ModelMethods[0]
+		if method.Result[0].Type != testcase.expectedType || !strings.Contains(method.Body, testcase.expectedBody) {
+			t.Errorf("entity method expect: %s %q, got: %s %q", testcase.expectedType, testcase.expectedBody, method.Result[0].Type, method.Body)
+		}
+	}
+}
+
+func TestQueryStructMeta_AddGoDefaultHook(t *testing.T) {
+	fields := []*model.Field{
+		{Name: "ID", Type: "int64", ColumnName: "id"},
+		{Name: "UUID", Type: "string", ColumnName: "uuid", GoDefault: "uuid.NewString()"},
+		{Name: "Token", Type: "*string", ColumnName: "token", GoDefault: "newToken()"},
+		{Name: "ExpiredAt", Type: "time.Time", ColumnName: "expired_at", GoDefault: "time.Now().AddDate(0, 1, 0)"},
+		{Name: "TraceID", Type: "uuid.UUID", ColumnName: "trace_id", GoDefault: "uuid.New()"},
+	}
+	meta := (&QueryStructMeta{ModelStructName: "User", S: "u", Fields: fields}).addGoDefaultHook()
+	if len(meta.ModelMethods) != 1 || meta.ModelMethods[0].MethodName != "BeforeCreate" {
+		t.Fatalf("go default hook expect: 1 BeforeCreate, got: %d methods", len(meta.ModelMethods))
+	}
+	expected := "{\n" +
+		"\tif u.UUID == \"\" {\n\t\tu.UUID = uuid.NewString()\n\t}\n" +
+		"\tif u.Token == nil {\n\t\tu.Token = newToken()\n\t}\n" +
+		"\tif u.ExpiredAt.IsZero() {\n\t\tu.ExpiredAt = time.Now().AddDate(0, 1, 0)\n\t}\n" +
+		"\tif u.TraceID == (uuid.UUID{}) {\n\t\tu.TraceID = uuid.New()\n\t}\n" +
+		"\treturn nil\n} "
+	if got := meta.ModelMethods[0].Body; got != expected {
+		t.Errorf("go default hook body expect: %q, got: %q", expected, got)
+	}
+
+	if meta.addGoDefaultHook(); len(meta.ModelMethods) != 1 {
+		t.Errorf("declared BeforeCreate expect kept, got: %d methods", len(meta.ModelMethods))
+	}
+	if none := (&QueryStructMeta{ModelStructName: "User", S: "u", Fields: fields[:1]}).addGoDefaultHook(); len(none.ModelMethods) != 0 {
+		t.Errorf("model without go default expect no hook, got: %d methods", len(none.ModelMethods))
+	}
+}
+
+func TestQueryStructMeta_ProtoMessage(t *testing.T) {
+	meta := &QueryStructMeta{ModelStructName: "User", TableName: "users", Fields: []*model.Field{
+		{Name: "ID", Type: "int64", ColumnName: "id"},
+		{Name: "Name", Type: "*string", ColumnName: "name", ColumnComment: "user name", Tag: field.Tag{field.TagKeyProtobuf: "bytes,10,opt,name=name"}},
+		{Name: "Age", Type: "sql.NullInt16", ColumnName: "age"},
+		{Name: "Price", Type: "decimal.Decimal", ColumnName: "price"},
+		{Name: "Status", Type: "UsersStatus", ColumnName: "status"},
+		{Name: "Level", Type: "*UsersLevel", ColumnName: "level"},
+		{Name: "CreatedAt", Type: "time.Time", ColumnName: "created_at"},
+		{Name: "Data", Type: "datatypes.JSON", ColumnName: "data", Ordinal: 9},
+		{Name: "Attrs", Type: "map[string]interface{}", ColumnName: "attrs"},
+		{Name: "Extra", Type: "string", GORMTag: field.GormTag{"-": nil}},
+		{Name: "Posts", Type: "[]Post", Relation: field.NewRelationWithType(field.HasMany, "Posts", "model.Post")},
+	}, EnumTypes: []*EnumType{{Name: "UsersStatus"}, {Name: "UsersLevel", Native: true}}}
+
+	msg := meta.ProtoMessage(model.ProtoNullableOptional)
+	if msg.Comment != "User mapped from table <users>" {
+		t.Errorf("message comment expect table fallback, got: %s", msg.Comment)
+	}
+	var fields []string
+	conversions := make(map[string]string)
+	for _, f := range msg.Fields {
+		fields = append(fields, fmt.Sprintf("%t %s %s = %d", f.Optional, f.Type, f.Name, f.Number))
+		conversions[f.Name] = f.ToProto + " | " + f.FromProto
+	}
+	expected := []string{"false int64 id = 1", "true string name = 10", "true int32 age = 3", "false string status = 5",
+		"true int32 level = 6", "false google.protobuf.Timestamp created_at = 7", "false bytes data = 9", "false google.protobuf.Struct attrs = 2"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("fields expect: %q, got: %q", expected, fields)
+	}
+	for name, expected := range map[string]string{
+		"age":    "if m.Age.Valid {\nv := int32(m.Age.Int16)\np.Age = &v\n} | if p.Age != nil {\nm.Age = sql.NullInt16{Int16: int16(*p.Age), Valid: true}\n}",
+		"status": "p.Status = string(m.Status) | m.Status = UsersStatus(p.Status)",
+		"level":  "if m.Level != nil {\nv := int32(*m.Level)\np.Level = &v\n} | if p.Level != nil {\nv := UsersLevel(*p.Level)\nm.Level = &v\n}",
+		"data":   "p.Data = []byte(m.Data) | m.Data = datatypes.JSON(p.Data)",
+		"attrs": "if m.Attrs != nil {\nif v, err := structpb.NewStruct(m.Attrs); err == nil {\np.Attrs = v\n}\n} | " +
+			"if p.Attrs != nil {\nm.Attrs = p.Attrs.AsMap()\n}",
+	} {
+		if conversions[name] != expected {
+			t.Errorf("conversion of %s expect: %q, got: %q", name, expected, conversions[name])
+		}
+	}
+	if imports := msg.Imports(); !reflect.DeepEqual(imports, []string{"google/protobuf/struct.proto", "google/protobuf/timestamp.proto"}) {
+		t.Errorf("imports expect struct and timestamp, got: %q", imports)
+	}
+
+	msg = meta.ProtoMessage(model.ProtoNullableWrapper)
+	if name := msg.Fields[1]; name.Optional || name.Type != "google.protobuf.StringValue" ||
+		name.ToProto != "if m.Name != nil {\np.Name = wrapperspb.String(*m.Name)\n}" ||
+		name.FromProto != "if p.Name != nil {\nv := p.Name.GetValue()\nm.Name = &v\n}" {
+		t.Errorf("wrapper field of *string unexpected: %+v", name)
+	}
+	if goName := goCamelCase("_user_id2x"); goName != "XUserId2X" {
+		t.Errorf("go name expect: XUserId2X, got: %s", goName)
+	}
+}
+
+func TestQueryStructMeta_ProtoMessageNumbers(t *testing.T) {
+	numbers := func(fields ...*model.Field) map[string]int {
+		result := make(map[string]int)
+		for _, f := range (&QueryStructMeta{ModelStructName: "User", TableName: "users", Fields: fields}).ProtoMessage(model.ProtoNullableOptional).Fields {
+			result[f.Name] = f.Number
+		}
+		return result
+	}
+	id := &model.Field{Name: "ID", Type: "int64", ColumnName: "id"}
+	name := &model.Field{Name: "Name", Type: "string", ColumnName: "name"}
+	email := &model.Field{Name: "Email", Type: "string", ColumnName: "email"}
+	if got := numbers(id, name, email); !reflect.DeepEqual(got, map[string]int{"id": 1, "name": 2, "email": 3}) {
+		t.Errorf("numbers expect by position, got: %v", got)
+	}
+
+	// column skipped by unsupported type keeps its number reserved
+	if got := numbers(id, &model.Field{Name: "Name", Type: "custom.Name", ColumnName: "name"}, email); !reflect.DeepEqual(got, map[string]int{"id": 1, "email": 3}) {
+		t.Errorf("numbers expect unchanged after skipping column, got: %v", got)
+	}
+
+	// column added before others keeps numbers of existing columns by ordinal position
+	id.Ordinal, name.Ordinal, email.Ordinal = 1, 2, 3
+	tenant := &model.Field{Name: "TenantID", Type: "int64", ColumnName: "tenant_id", Ordinal: 4}
+	if got := numbers(id, tenant, name, email); !reflect.DeepEqual(got, map[string]int{"id": 1, "tenant_id": 4, "name": 2, "email": 3}) {
+		t.Errorf("numbers expect by ordinal after adding column, got: %v", got)
+	}
+}
+
+func TestQueryStructMeta_Hash(t *testing.T) {
+	newMeta := func() *QueryStructMeta {
+		return &QueryStructMeta{ModelStructName: "User", TableName: "users", schemaHash: "s1", Fields: []*model.Field{
+			{Name: "ID", Type: "int64", ColumnName: "id", Tag: field.Tag{}, GORMTag: field.GormTag{}.Set(field.TagKeyGormColumn, "id")},
+		}}
+	}
+	meta := newMeta()
+	hash := meta.Hash()
+	if meta.Fields[0].Tags(); meta.Hash() != hash {
+		t.Errorf("hash expect stable after tags rendered")
+	}
+
+	meta = newMeta()
+	meta.Fields[0].GORMTag.Set(field.TagKeyGormPrimaryKey, "")
+	if meta.Hash() == hash {
+		t.Errorf("hash expect changed by field tag")
+	}
+	meta = newMeta()
+	meta.schemaHash = "s2"
+	if meta.Hash() == hash {
+		t.Errorf("hash expect changed by table schema")
+	}
+}
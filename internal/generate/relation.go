@@ -0,0 +1,148 @@
+package generate
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+
+	"gorm.io/gen/field"
+	"gorm.io/gen/internal/model"
+)
+
+// setForeignKeys keep foreign keys of columns and columns being single primary key or unique for relation inference
+func (b *QueryStructMeta) setForeignKeys(columns []*model.Column) {
+	singlePK := countPrimaryKey(columns) == 1
+	b.foreignKeys, b.uniqueColumns = nil, make(map[string]bool)
+	for _, c := range columns {
+		b.foreignKeys = append(b.foreignKeys, c.ForeignKeys...)
+		if pk, ok := c.PrimaryKey(); ok && pk && singlePK {
+			b.uniqueColumns[c.Name()] = true
+		} else if unique, ok := c.Unique(); ok && unique {
+			b.uniqueColumns[c.Name()] = true
+		}
+	}
+}
+
+// RelateForeignKeys add association fields inferred from foreign keys between models generated from tables,
+// belongs-to on referencing model, has-one(foreign key on single unique column) or has-many on referenced model,
+// foreign key referencing table not generated is skipped, field colliding with existing one is skipped with warning,
+// getters and Clone generated for models are rebuilt to cover association fields
+func RelateForeignKeys(models []*QueryStructMeta) {
+	sort.SliceStable(models, func(i, j int) bool { return models[i].ModelStructName < models[j].ModelStructName })
+	byTable := make(map[string]*QueryStructMeta, len(models))
+	for _, m := range models {
+		if m.Source == model.Table {
+			byTable[m.TableName] = m
+		}
+	}
+	for _, m := range models {
+		for _, fks := range groupForeignKeys(m.foreignKeys) {
+			ref := byTable[fks[0].RefTable]
+			if ref == nil {
+				continue
+			}
+			fkFields, refFields := m.relationKeys(ref, fks)
+			if fkFields == nil {
+				m.warn("skip relation of foreign key %s.%s: referenced columns of %s not found", m.TableName, fks[0].Name, ref.TableName)
+				continue
+			}
+			belongsTo := ref.ModelStructName
+			if len(fkFields) == 1 {
+				if name := strings.TrimSuffix(strings.TrimSuffix(fkFields[0], "ID"), "Id"); name != "" && name != fkFields[0] {
+					belongsTo = name
+				}
+			}
+			m.addRelationField(field.BelongsTo, belongsTo, "*", ref, relationTag(fkFields, refFields))
+
+			relationship, name, prefix := field.HasMany, inflection.Plural(m.ModelStructName), "[]"
+			if len(fks) == 1 && m.uniqueColumns[fks[0].Column] {
+				relationship, name, prefix = field.HasOne, m.ModelStructName, "*"
+			}
+			if belongsTo != ref.ModelStructName { // e.g. posts.author_id referencing users => User.AuthorPosts
+				name = belongsTo + name
+			}
+			ref.addRelationField(relationship, name, prefix, m, relationTag(fkFields, refFields))
+		}
+	}
+	for _, m := range models {
+		m.refreshFieldMethods()
+	}
+}
+
+// relationTag gorm tag of association field, e.g. foreignKey:AuthorID;references:ID
+func relationTag(fkFields, refFields []string) field.GormTag {
+	return field.GormTag{}.
+		Set(field.TagKeyGormForeignKey, strings.Join(fkFields, ",")).
+		Set(field.TagKeyGormReferences, strings.Join(refFields, ","))
+}
+
+// groupForeignKeys group columns of foreign keys by constraint name, in order of first appearance
+func groupForeignKeys(foreignKeys []*model.ForeignKey) (groups [][]*model.ForeignKey) {
+	index := make(map[string]int, len(foreignKeys))
+	for _, fk := range foreignKeys {
+		key := fk.Name + "\x00" + fk.RefTable
+		if i, ok := index[key]; ok {
+			groups[i] = append(groups[i], fk)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, []*model.ForeignKey{fk})
+	}
+	return groups
+}
+
+// relationKeys field names of referencing columns and referenced columns(primary key of ref if unknown), nil if any is missing
+func (b *QueryStructMeta) relationKeys(ref *QueryStructMeta, fks []*model.ForeignKey) (fkFields, refFields []string) {
+	var refPKs []string
+	for _, f := range ref.Fields {
+		if _, ok := f.GORMTag[field.TagKeyGormPrimaryKey]; ok {
+			refPKs = append(refPKs, f.Name)
+		}
+	}
+	for i, fk := range fks {
+		fkField := b.fieldNameOfColumn(fk.Column)
+		refField := ref.fieldNameOfColumn(fk.RefColumn)
+		if fk.RefColumn == "" && i < len(refPKs) { // sqlite implicitly references primary key
+			refField = refPKs[i]
+		}
+		if fkField == "" || refField == "" {
+			return nil, nil
+		}
+		fkFields, refFields = append(fkFields, fkField), append(refFields, refField)
+	}
+	return fkFields, refFields
+}
+
+func (b *QueryStructMeta) fieldNameOfColumn(columnName string) string {
+	for _, f := range b.Fields {
+		if columnName != "" && f.ColumnName == columnName {
+			return f.Name
+		}
+	}
+	return ""
+}
+
+// addRelationField add association field of related model, skipped with warning if field name is taken
+func (b *QueryStructMeta) addRelationField(relationship field.RelationshipType, name, prefix string, related *QueryStructMeta, tag field.GormTag) {
+	for _, f := range b.Fields {
+		if f.Name == name {
+			b.warn("skip %s relation %s.%s: field name is taken", relationship, b.ModelStructName, name)
+			return
+		}
+	}
+	b.Fields = append(b.Fields, &model.Field{
+		Name:     name,
+		Type:     prefix + related.StructInfo.Type,
+		Tag:      (&field.RelateConfig{}).GetTag(name),
+		GORMTag:  tag,
+		Relation: field.NewRelationWithType(relationship, name, related.StructInfo.Package+"."+related.StructInfo.Type),
+	})
+}
+
+func (b *QueryStructMeta) warn(format string, args ...interface{}) {
+	if b.db != nil {
+		b.db.Logger.Warn(context.Background(), format, args...)
+	}
+}
@@ -2,10 +2,15 @@ package generate
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 
 	"gorm.io/gorm"
 
+	"gorm.io/gen/internal/ddl"
 	"gorm.io/gen/internal/model"
 )
 
@@ -20,6 +25,13 @@ func getTableInfo(db *gorm.DB) ITableInfo {
 	return &tableInfo{db}
 }
 
+// isOffline whether tables are read from DDL files without database connection, metadata queried from
+// database(e.g. information_schema) is not available and not queried
+func isOffline(db *gorm.DB) bool {
+	_, ok := db.Dialector.(ddl.Dialector)
+	return ok
+}
+
 func getTableComment(db *gorm.DB, tableName string) string {
 	table, err := getTableType(db, tableName)
 	if err != nil || table == nil {
@@ -38,7 +50,7 @@ func getTableType(db *gorm.DB, tableName string) (result gorm.TableType, err err
 	return db.Migrator().TableType(tableName)
 }
 
-func getTableColumns(db *gorm.DB, schemaName string, tableName string, indexTag bool) (result []*model.Column, err error) {
+func getTableColumns(db *gorm.DB, schemaName string, tableName string, conf *model.FieldConfig) (result []*model.Column, err error) {
 	if db == nil {
 		return nil, errors.New("gorm db is nil")
 	}
@@ -48,26 +60,172 @@ func getTableColumns(db *gorm.DB, schemaName string, tableName string, indexTag
 	if err != nil {
 		return nil, err
 	}
-	if !indexTag || len(result) == 0 {
+	if conf.FieldOrdinalOrder && len(result) > 0 {
+		result = sortByOrdinal(db, schemaName, tableName, result)
+	} else if (conf.FieldProtobufTag || conf.FieldOrdinals) && len(result) > 0 {
+		fillTableOrdinals(db, schemaName, tableName, result)
+	}
+	if conf.FieldReadOnlyGenerated && len(result) > 0 {
+		fillTableGenerated(db, schemaName, tableName, result)
+	}
+	if conf.FieldDetectIdentity && len(result) > 0 {
+		fillTableIdentities(db, tableName, result)
+	}
+	if conf.FieldWithCheckTag && len(result) > 0 {
+		fillTableChecks(db, schemaName, tableName, result)
+	}
+	if (conf.FieldSharedPrimaryKey != model.SharedPKAsIs || conf.FieldForeignKeyRelations) && len(result) > 0 {
+		fillTableForeignKeys(db, schemaName, tableName, result)
+	}
+	if (conf.FieldViewNullable || len(conf.ViewNullableOverride) > 0) && len(result) > 0 && isView(db, tableName) {
+		fillViewNullable(db, schemaName, tableName, result, conf.FieldViewNullable, conf.ViewNullableOverride)
+	}
+	compositeIndexes := getCompositeIndexes(conf.FieldCompositeIndexes, tableName, result)
+	if (!conf.FieldWithIndexTag && len(compositeIndexes) == 0) || len(result) == 0 {
 		return result, nil
 	}
 
-	index, err := mt.GetTableIndex(schemaName, tableName)
-	if err != nil { //ignore find index err
-		db.Logger.Warn(context.Background(), "GetTableIndex for %s,err=%s", tableName, err.Error())
-		return result, nil
+	var index []gorm.Index
+	if conf.FieldWithIndexTag {
+		index, err = mt.GetTableIndex(schemaName, tableName)
+		if err != nil { //ignore find index err
+			db.Logger.Warn(context.Background(), "GetTableIndex for %s,err=%s", tableName, err.Error())
+			index = nil
+		}
 	}
+	index = model.MergeIndexes(index, compositeIndexes, conf.FieldIndexPrecedence)
 	if len(index) == 0 {
 		return result, nil
 	}
 
 	im := model.GroupByColumn(index)
+	if conf.FieldIndexNormalize {
+		model.NormalizeIndexPriorities(im)
+	}
+	names := model.ShortenIndexNames(index, indexNameMaxLen(db, conf))
+	nullsNotDistinct := getNullsNotDistinctIndexes(db, tableName)
 	for _, c := range result {
 		c.Indexes = im[c.Name()]
+		for _, idx := range c.Indexes {
+			idx.TagName = names[idx.Name()]
+			idx.NullsNotDistinct = nullsNotDistinct[idx.Name()]
+		}
 	}
 	return result, nil
 }
 
+// getNullsNotDistinctIndexes get unique indexes treating nulls as equal(NULLS NOT DISTINCT), only postgres 15+ is supported
+func getNullsNotDistinctIndexes(db *gorm.DB, tableName string) map[string]bool {
+	if db.Dialector.Name() != "postgres" || isOffline(db) {
+		return nil
+	}
+	var version int
+	if err := db.Raw("SELECT current_setting('server_version_num')::int").Scan(&version).Error; err != nil || version < 150000 {
+		return nil
+	}
+
+	var names []string
+	err := db.Raw("SELECT i.relname FROM pg_index x JOIN pg_class i ON i.oid = x.indexrelid JOIN pg_class t ON t.oid = x.indrelid "+
+		"WHERE t.relname = ? AND t.relnamespace = current_schema()::regnamespace AND x.indnullsnotdistinct", tableName).Scan(&names).Error
+	if err != nil { //ignore find index err
+		db.Logger.Warn(context.Background(), "get nulls not distinct indexes for %s,err=%s", tableName, err.Error())
+		return nil
+	}
+	result := make(map[string]bool, len(names))
+	for _, name := range names {
+		result[name] = true
+	}
+	return result
+}
+
+// getFunctionalIndexes get DDL of functional(expression) indexes which can't be tied to tag of single column,
+// only mysql(8.0.13+) and postgres are supported
+func getFunctionalIndexes(db *gorm.DB, schemaName string, tableName string) (ddls []string) {
+	if isOffline(db) {
+		return nil
+	}
+	var err error
+	switch db.Dialector.Name() {
+	case "mysql":
+		var parts []indexKeyPart
+		err = db.Raw("SELECT INDEX_NAME, NON_UNIQUE, COLUMN_NAME, EXPRESSION FROM information_schema.STATISTICS "+
+			"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY INDEX_NAME, SEQ_IN_INDEX", schemaName, tableName).Scan(&parts).Error
+		ddls = mysqlFunctionalIndexes(tableName, parts)
+	case "postgres":
+		err = db.Raw("SELECT pg_get_indexdef(x.indexrelid) FROM pg_index x JOIN pg_class i ON i.oid = x.indexrelid JOIN pg_class t ON t.oid = x.indrelid "+
+			"WHERE t.relname = ? AND t.relnamespace = current_schema()::regnamespace AND x.indexprs IS NOT NULL ORDER BY i.relname", tableName).Scan(&ddls).Error
+	default:
+		return nil
+	}
+	if err != nil { //ignore find index err
+		db.Logger.Warn(context.Background(), "get functional indexes for %s,err=%s", tableName, err.Error())
+		return nil
+	}
+	return ddls
+}
+
+// indexKeyPart key part of mysql index, column or expression
+type indexKeyPart struct {
+	IndexName  string         `gorm:"column:INDEX_NAME"`
+	NonUnique  bool           `gorm:"column:NON_UNIQUE"`
+	ColumnName sql.NullString `gorm:"column:COLUMN_NAME"`
+	Expression sql.NullString `gorm:"column:EXPRESSION"`
+}
+
+// mysqlFunctionalIndexes build DDL of indexes having expression key part, parts are ordered by index name and sequence,
+// column key parts of multi-column functional index are kept in order
+func mysqlFunctionalIndexes(tableName string, parts []indexKeyPart) (ddls []string) {
+	for i, j := 0, 0; i < len(parts); i = j {
+		var keys []string
+		functional := false
+		for j = i; j < len(parts) && parts[j].IndexName == parts[i].IndexName; j++ {
+			if parts[j].Expression.String != "" {
+				functional = true
+				keys = append(keys, "("+parts[j].Expression.String+")")
+			} else {
+				keys = append(keys, "`"+parts[j].ColumnName.String+"`")
+			}
+		}
+		if !functional {
+			continue
+		}
+		unique := ""
+		if !parts[i].NonUnique {
+			unique = "UNIQUE "
+		}
+		ddls = append(ddls, fmt.Sprintf("CREATE %sINDEX `%s` ON `%s` (%s)", unique, parts[i].IndexName, tableName, strings.Join(keys, ", ")))
+	}
+	return ddls
+}
+
+// indexNameMaxLen max length of index name in tag, 0 means no limit
+func indexNameMaxLen(db *gorm.DB, conf *model.FieldConfig) int {
+	if !conf.FieldIndexNameLimit {
+		return 0
+	}
+	if conf.FieldIndexNameMaxLen > 0 {
+		return conf.FieldIndexNameMaxLen
+	}
+	return model.IdentifierMaxLen[db.Dialector.Name()]
+}
+
+// getCompositeIndexes get config declared composite indexes applied to table
+func getCompositeIndexes(indexes []*model.CompositeIndex, tableName string, columns []*model.Column) (result []*model.CompositeIndex) {
+	if len(indexes) == 0 {
+		return nil
+	}
+	columnNames := make([]string, 0, len(columns))
+	for _, c := range columns {
+		columnNames = append(columnNames, c.Name())
+	}
+	for _, idx := range indexes {
+		if applied := idx.ApplyTo(tableName, columnNames); applied != nil {
+			result = append(result, applied)
+		}
+	}
+	return result
+}
+
 type tableInfo struct{ *gorm.DB }
 
 // GetTableColumns  struct
@@ -77,7 +235,7 @@ func (t *tableInfo) GetTableColumns(schemaName string, tableName string) (result
 		return nil, err
 	}
 	for _, column := range types {
-		result = append(result, &model.Column{ColumnType: column, TableName: tableName, UseScanType: t.Dialector.Name() != "mysql" && t.Dialector.Name() != "sqlite"})
+		result = append(result, &model.Column{ColumnType: column, TableName: tableName, Dialect: t.Dialector.Name(), UseScanType: t.Dialector.Name() != "mysql" && t.Dialector.Name() != "sqlite"})
 	}
 	return result, nil
 }
@@ -86,3 +244,309 @@ func (t *tableInfo) GetTableColumns(schemaName string, tableName string) (result
 func (t *tableInfo) GetTableIndex(schemaName string, tableName string) (indexes []gorm.Index, err error) {
 	return t.Migrator().GetIndexes(tableName)
 }
+
+// fillTableChecks attach check constraints to columns, only mysql(8.0.16+) and postgres are supported
+func fillTableChecks(db *gorm.DB, schemaName string, tableName string, columns []*model.Column) {
+	if isOffline(db) {
+		return
+	}
+	var checks []*model.Check
+	var err error
+	switch db.Dialector.Name() {
+	case "mysql":
+		err = db.Raw("SELECT cc.CONSTRAINT_NAME, cc.CHECK_CLAUSE FROM information_schema.TABLE_CONSTRAINTS tc "+
+			"JOIN information_schema.CHECK_CONSTRAINTS cc ON tc.CONSTRAINT_SCHEMA = cc.CONSTRAINT_SCHEMA AND tc.CONSTRAINT_NAME = cc.CONSTRAINT_NAME "+
+			"WHERE tc.TABLE_SCHEMA = ? AND tc.TABLE_NAME = ? AND tc.CONSTRAINT_TYPE = 'CHECK'", schemaName, tableName).Scan(&checks).Error
+	case "postgres":
+		err = db.Raw("SELECT con.conname AS \"CONSTRAINT_NAME\", pg_get_constraintdef(con.oid) AS \"CHECK_CLAUSE\" FROM pg_constraint con "+
+			"JOIN pg_class rel ON rel.oid = con.conrelid WHERE con.contype = 'c' AND rel.relname = ? AND rel.relnamespace = to_regnamespace(current_schema())::oid",
+			tableName).Scan(&checks).Error
+		for _, check := range checks {
+			check.Constraint = strings.TrimPrefix(check.Constraint, "CHECK ")
+		}
+	default:
+		return
+	}
+	if err != nil { //ignore find check err
+		db.Logger.Warn(context.Background(), "get check constraints for %s,err=%s", tableName, err.Error())
+		return
+	}
+
+	columnNames := make([]string, len(columns))
+	for i, c := range columns {
+		columnNames[i] = c.Name()
+	}
+	cm := model.GroupCheckByColumn(checks, columnNames)
+	for _, c := range columns {
+		c.Checks = cm[c.Name()]
+	}
+}
+
+// foreignKeyReader migrator reading foreign keys without query, e.g. migrator of tables declared by DDL files
+type foreignKeyReader interface {
+	GetForeignKeys(tableName string) ([]*model.ForeignKey, error)
+}
+
+// fillTableForeignKeys attach foreign keys to referencing columns, only mysql, postgres, sqlite and DDL files are supported
+func fillTableForeignKeys(db *gorm.DB, schemaName string, tableName string, columns []*model.Column) {
+	var foreignKeys []*model.ForeignKey
+	var err error
+	reader, offline := db.Migrator().(foreignKeyReader)
+	switch {
+	case offline:
+		foreignKeys, err = reader.GetForeignKeys(tableName)
+	case db.Dialector.Name() == "mysql":
+		err = db.Raw("SELECT CONSTRAINT_NAME, COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME FROM information_schema.KEY_COLUMN_USAGE "+
+			"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL ORDER BY CONSTRAINT_NAME, ORDINAL_POSITION",
+			schemaName, tableName).Scan(&foreignKeys).Error
+	case db.Dialector.Name() == "postgres":
+		err = db.Raw("SELECT con.conname AS \"CONSTRAINT_NAME\", a.attname AS \"COLUMN_NAME\", ref.relname AS \"REFERENCED_TABLE_NAME\", "+
+			"ra.attname AS \"REFERENCED_COLUMN_NAME\" FROM pg_constraint con JOIN pg_class rel ON rel.oid = con.conrelid "+
+			"JOIN pg_class ref ON ref.oid = con.confrelid CROSS JOIN LATERAL unnest(con.conkey, con.confkey) AS k(attnum, refnum) "+
+			"JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum JOIN pg_attribute ra ON ra.attrelid = con.confrelid AND ra.attnum = k.refnum "+
+			"WHERE con.contype = 'f' AND rel.relname = ? AND rel.relnamespace = to_regnamespace(current_schema())::oid ORDER BY con.conname",
+			tableName).Scan(&foreignKeys).Error
+	case db.Dialector.Name() == "sqlite":
+		err = db.Raw("SELECT CAST(id AS TEXT) AS CONSTRAINT_NAME, \"from\" AS COLUMN_NAME, \"table\" AS REFERENCED_TABLE_NAME, "+
+			"COALESCE(\"to\", '') AS REFERENCED_COLUMN_NAME FROM pragma_foreign_key_list(?) ORDER BY id, seq", tableName).Scan(&foreignKeys).Error
+	default:
+		return
+	}
+	if err != nil { //ignore find foreign key err
+		db.Logger.Warn(context.Background(), "get foreign keys for %s,err=%s", tableName, err.Error())
+		return
+	}
+
+	columnNames := make([]string, len(columns))
+	for i, c := range columns {
+		columnNames[i] = c.Name()
+	}
+	fm := model.GroupForeignKeyByColumn(foreignKeys, columnNames)
+	for _, c := range columns {
+		c.ForeignKeys = fm[c.Name()]
+	}
+}
+
+// isView whether table is a view
+func isView(db *gorm.DB, tableName string) bool {
+	if db.Dialector.Name() == "sqlite" && !isOffline(db) {
+		var typ string
+		if err := db.Raw("SELECT type FROM sqlite_master WHERE name = ?", tableName).Scan(&typ).Error; err != nil {
+			return false
+		}
+		return typ == "view"
+	}
+	table, err := getTableType(db, tableName)
+	if err != nil || table == nil {
+		return false
+	}
+	return strings.Contains(strings.ToUpper(table.Type()), "VIEW")
+}
+
+// viewBaseColumn base table column referenced by view
+type viewBaseColumn struct {
+	ColumnName string `gorm:"column:COLUMN_NAME"`
+	IsNullable string `gorm:"column:IS_NULLABLE"`
+}
+
+// fillViewNullable fill nullable of view columns, inferred from base table columns(only mysql 8.0.13+ and postgres are supported)
+// when infer is on, and then overridden by overrides keyed by view.column
+func fillViewNullable(db *gorm.DB, schemaName string, viewName string, columns []*model.Column, infer bool, overrides map[string]bool) {
+	if infer && !isOffline(db) {
+		var bases []viewBaseColumn
+		var err error
+		switch db.Dialector.Name() {
+		case "mysql":
+			err = db.Raw("SELECT u.COLUMN_NAME, c.IS_NULLABLE FROM information_schema.VIEW_COLUMN_USAGE u "+
+				"JOIN information_schema.COLUMNS c ON c.TABLE_SCHEMA = u.TABLE_SCHEMA AND c.TABLE_NAME = u.TABLE_NAME AND c.COLUMN_NAME = u.COLUMN_NAME "+
+				"WHERE u.VIEW_SCHEMA = ? AND u.VIEW_NAME = ?", schemaName, viewName).Scan(&bases).Error
+		case "postgres":
+			err = db.Raw("SELECT u.column_name AS \"COLUMN_NAME\", c.is_nullable AS \"IS_NULLABLE\" FROM information_schema.view_column_usage u "+
+				"JOIN information_schema.columns c ON c.table_schema = u.table_schema AND c.table_name = u.table_name AND c.column_name = u.column_name "+
+				"WHERE u.view_schema = current_schema() AND u.view_name = ?", viewName).Scan(&bases).Error
+		}
+		if err != nil { //ignore find view base column err
+			db.Logger.Warn(context.Background(), "get base columns of view %s,err=%s", viewName, err.Error())
+		}
+		inferred := inferViewNullable(bases)
+		for _, c := range columns {
+			if nullable, ok := inferred[c.Name()]; ok {
+				c.ViewNull = sql.NullBool{Bool: nullable, Valid: true}
+			}
+		}
+	}
+	for _, c := range columns {
+		if nullable, ok := overrides[viewName+"."+c.Name()]; ok {
+			c.ViewNull = sql.NullBool{Bool: nullable, Valid: true}
+		}
+	}
+}
+
+// inferViewNullable nullable of view columns keyed by name, inferred only from the only base column of the same name,
+// column named after several base columns(e.g. id of joined tables) and computed column are left as reported
+func inferViewNullable(bases []viewBaseColumn) map[string]bool {
+	count := make(map[string]int, len(bases))
+	for _, base := range bases {
+		count[base.ColumnName]++
+	}
+	result := make(map[string]bool, len(bases))
+	for _, base := range bases {
+		if count[base.ColumnName] == 1 {
+			result[base.ColumnName] = !strings.EqualFold(base.IsNullable, "NO")
+		}
+	}
+	return result
+}
+
+// sortByOrdinal sort columns by ordinal position, keep driver's returned order if any column's ordinal is missing
+func sortByOrdinal(db *gorm.DB, schemaName string, tableName string, columns []*model.Column) []*model.Column {
+	fillTableOrdinals(db, schemaName, tableName, columns)
+	for _, c := range columns {
+		if _, ok := c.OrdinalPosition(); !ok {
+			return columns
+		}
+	}
+	sort.SliceStable(columns, func(i, j int) bool {
+		oi, _ := columns[i].OrdinalPosition()
+		oj, _ := columns[j].OrdinalPosition()
+		return oi < oj
+	})
+	return columns
+}
+
+// fillTableOrdinals fill columns' ordinal position, only mysql, postgres, sqlite and DDL files(declaration order) are supported
+func fillTableOrdinals(db *gorm.DB, schemaName string, tableName string, columns []*model.Column) {
+	if isOffline(db) {
+		for i, c := range columns {
+			c.Ordinal = i + 1
+		}
+		return
+	}
+	var ordinals []struct {
+		ColumnName      string `gorm:"column:COLUMN_NAME"`
+		OrdinalPosition int    `gorm:"column:ORDINAL_POSITION"`
+	}
+	var err error
+	switch db.Dialector.Name() {
+	case "mysql":
+		err = db.Raw("SELECT COLUMN_NAME, ORDINAL_POSITION FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?",
+			schemaName, tableName).Scan(&ordinals).Error
+	case "postgres":
+		err = db.Raw("SELECT column_name AS \"COLUMN_NAME\", ordinal_position AS \"ORDINAL_POSITION\" FROM information_schema.columns "+
+			"WHERE table_schema = current_schema() AND table_name = ?", tableName).Scan(&ordinals).Error
+	case "sqlite":
+		err = db.Raw("SELECT name AS COLUMN_NAME, cid + 1 AS ORDINAL_POSITION FROM pragma_table_info(?)", tableName).Scan(&ordinals).Error
+	default:
+		return
+	}
+	if err != nil { //ignore find ordinal err
+		db.Logger.Warn(context.Background(), "get column ordinal position for %s,err=%s", tableName, err.Error())
+		return
+	}
+
+	om := make(map[string]int, len(ordinals))
+	for _, o := range ordinals {
+		om[o.ColumnName] = o.OrdinalPosition
+	}
+	for _, c := range columns {
+		if ordinal, ok := om[c.Name()]; ok {
+			c.Ordinal = ordinal
+		}
+	}
+}
+
+// defaultSchemas default schema of dialects, omitted in schema qualified table name
+var defaultSchemas = map[string]string{
+	"postgres":  "public",
+	"sqlserver": "dbo",
+	"sqlite":    "main",
+}
+
+// getTableSchema get schema qualifying table name, empty if table name is qualified already or schema is default
+func getTableSchema(db *gorm.DB, conf *model.Config, tableName string) (schemaName string) {
+	if strings.Contains(tableName, ".") {
+		return ""
+	}
+	if conf.TableSchemaNS != nil {
+		schemaName = conf.TableSchemaNS(tableName)
+	} else {
+		var err error
+		offline := isOffline(db)
+		switch {
+		case db.Dialector.Name() == "postgres" && !offline:
+			err = db.Raw("SELECT current_schema()").Scan(&schemaName).Error
+		case db.Dialector.Name() == "sqlserver" && !offline:
+			err = db.Raw("SELECT SCHEMA_NAME()").Scan(&schemaName).Error
+		default:
+			schemaName = conf.GetSchemaName(db)
+		}
+		if err != nil { //ignore find schema err
+			db.Logger.Warn(context.Background(), "get schema for %s,err=%s", tableName, err.Error())
+			return ""
+		}
+	}
+	if schemaName == defaultSchemas[db.Dialector.Name()] {
+		return ""
+	}
+	return schemaName
+}
+
+// fillTableIdentities fill identity columns and their increment, only sqlserver is supported
+func fillTableIdentities(db *gorm.DB, tableName string, columns []*model.Column) {
+	if db.Dialector.Name() != "sqlserver" || isOffline(db) {
+		return
+	}
+	var identities []struct {
+		Name           string `gorm:"column:name"`
+		IncrementValue int64  `gorm:"column:increment_value"`
+	}
+	err := db.Raw("SELECT name, CAST(increment_value AS BIGINT) AS increment_value FROM sys.identity_columns WHERE object_id = OBJECT_ID(?)",
+		tableName).Scan(&identities).Error
+	if err != nil { //ignore find identity column err
+		db.Logger.Warn(context.Background(), "get identity columns for %s,err=%s", tableName, err.Error())
+		return
+	}
+
+	for _, c := range columns {
+		for _, identity := range identities {
+			if c.Name() == identity.Name {
+				c.Identity, c.Increment = true, identity.IncrementValue
+				break
+			}
+		}
+	}
+}
+
+// fillTableGenerated mark generated columns(STORED or VIRTUAL), only mysql(5.7+), postgres(12+) and sqlite are supported
+func fillTableGenerated(db *gorm.DB, schemaName string, tableName string, columns []*model.Column) {
+	if isOffline(db) {
+		return
+	}
+	var generated []string
+	var err error
+	switch db.Dialector.Name() {
+	case "mysql":
+		err = db.Raw("SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND GENERATION_EXPRESSION <> ''",
+			schemaName, tableName).Scan(&generated).Error
+	case "postgres":
+		err = db.Raw("SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? AND is_generated = 'ALWAYS'",
+			tableName).Scan(&generated).Error
+	case "sqlite":
+		err = db.Raw("SELECT name FROM pragma_table_xinfo(?) WHERE hidden IN (2, 3)", tableName).Scan(&generated).Error
+	default:
+		return
+	}
+	if err != nil { //ignore find generated column err
+		db.Logger.Warn(context.Background(), "get generated columns for %s,err=%s", tableName, err.Error())
+		return
+	}
+
+	for _, c := range columns {
+		for _, name := range generated {
+			if c.Name() == name {
+				c.Generated = true
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package generate
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/utils/tests"
+
+	"gorm.io/gen/internal/model"
+)
+
+func TestMysqlFunctionalIndexes(t *testing.T) {
+	column := func(index string, nonUnique bool, name string) indexKeyPart {
+		return indexKeyPart{IndexName: index, NonUnique: nonUnique, ColumnName: sql.NullString{String: name, Valid: true}}
+	}
+	expression := func(index string, nonUnique bool, expr string) indexKeyPart {
+		return indexKeyPart{IndexName: index, NonUnique: nonUnique, Expression: sql.NullString{String: expr, Valid: true}}
+	}
+	parts := []indexKeyPart{
+		column("PRIMARY", false, "id"),
+		expression("idx_lower_email", false, "lower(`email`)"),
+		column("idx_name", true, "name"),
+		column("idx_tenant_day", true, "tenant_id"),
+		expression("idx_tenant_day", true, "cast(`created_at` as date)"),
+	}
+	expected := []string{
+		"CREATE UNIQUE INDEX `idx_lower_email` ON `users` ((lower(`email`)))",
+		"CREATE INDEX `idx_tenant_day` ON `users` (`tenant_id`, (cast(`created_at` as date)))",
+	}
+	if got := mysqlFunctionalIndexes("users", parts); !reflect.DeepEqual(got, expected) {
+		t.Errorf("functional indexes expect: %v, got: %v", expected, got)
+	}
+}
+
+func TestSortByOrdinal(t *testing.T) {
+	db := &gorm.DB{Config: &gorm.Config{Dialector: tests.DummyDialector{}}}
+	newColumns := func(ordinals ...int) []*model.Column {
+		columns := make([]*model.Column, len(ordinals))
+		for i, ordinal := range ordinals {
+			name := string(rune('a' + i))
+			columns[i] = &model.Column{ColumnType: migrator.ColumnType{NameValue: sql.NullString{String: name, Valid: true}}, Ordinal: ordinal}
+		}
+		return columns
+	}
+	names := func(columns []*model.Column) (result string) {
+		for _, c := range columns {
+			result += c.Name()
+		}
+		return result
+	}
+	testcases := []struct {
+		ordinals []int
+		expected string
+	}{
+		{[]int{3, 1, 2}, "bca"},
+		{[]int{1, 2, 3}, "abc"},
+		{[]int{2, 2, 1}, "cab"}, // duplicated ordinals keep driver's order
+		{[]int{3, 0, 1}, "abc"}, // missing ordinal falls back to driver's order
+		{[]int{}, ""},
+	}
+	for _, testcase := range testcases {
+		if got := names(sortByOrdinal(db, "", "users", newColumns(testcase.ordinals...))); got != testcase.expected {
+			t.Errorf("columns of ordinals %v expect: %s, got: %s", testcase.ordinals, testcase.expected, got)
+		}
+	}
+}
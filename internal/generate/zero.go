@@ -0,0 +1,41 @@
+package generate
+
+// ZeroValue zero value of model field, typed constant for basic types and var for others
+type ZeroValue struct {
+	Name  string
+	Type  string
+	Value string // constant value, empty means var
+}
+
+// ZeroConsts zero values of model column fields expressed as typed constants, named as {Model}Zero{Field}
+func (b *QueryStructMeta) ZeroConsts() []ZeroValue { return b.zeroValues(true) }
+
+// ZeroVars zero values of model column fields cannot be constants(pointer, slice, struct etc.), named as {Model}Zero{Field}
+func (b *QueryStructMeta) ZeroVars() []ZeroValue { return b.zeroValues(false) }
+
+func (b *QueryStructMeta) zeroValues(constant bool) (values []ZeroValue) {
+	for _, f := range b.Fields {
+		if f.ColumnName == "" || f.IsRelation() {
+			continue
+		}
+		value := ZeroValue{Name: b.ModelStructName + "Zero" + f.Name, Type: f.Type, Value: zeroConstValue(f.Type)}
+		if (value.Value != "") == constant {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// zeroConstValue zero value of type expressed as constant, empty if type cannot be constant(pointer, slice, struct etc.)
+func zeroConstValue(typ string) string {
+	switch typ {
+	case "string":
+		return `""`
+	case "bool":
+		return "false"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64", "complex64", "complex128", "byte", "rune":
+		return "0"
+	}
+	return ""
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"bytes"
+	"regexp"
 	"strings"
 
 	"gorm.io/gen/field"
@@ -134,6 +135,8 @@ var (
 		"longblob":   func(string) string { return "[]byte" },
 		"text":       func(string) string { return "string" },
 		"json":       func(string) string { return "string" },
+		"tsvector":   func(string) string { return "string" },
+		"tsquery":    func(string) string { return "string" },
 		"enum":       func(string) string { return "string" },
 		"time":       func(string) string { return "time.Time" },
 		"date":       func(string) string { return "time.Time" },
@@ -173,6 +176,11 @@ type Field struct {
 	GORMTag          field.GormTag
 	CustomGenType    string
 	Relation         *field.Relation
+	GORMTagOrder     []string // gorm tag key order, empty means default order
+	Rendered         string   // field line rendered by custom field template, empty means built-in rendering
+	EmbeddedColumns  []string // column names of embedded struct fields(prefix included), used to detect collision
+	GoDefault        string   // Go expression of Go-managed default, assigned in generated BeforeCreate hook when field is zero
+	Ordinal          int      // ordinal position of column in table, 0 means unknown, used to number protobuf message field
 }
 
 // Tags ...
@@ -181,7 +189,7 @@ func (m *Field) Tags() string {
 		return m.Tag.Build()
 	}
 
-	if gormTag := strings.TrimSpace(m.GORMTag.Build()); gormTag != "" {
+	if gormTag := strings.TrimSpace(m.GORMTag.BuildWithOrder(m.GORMTagOrder)); gormTag != "" {
 		m.Tag.Set(field.TagKeyGorm, gormTag)
 	}
 	return m.Tag.Build()
@@ -190,6 +198,60 @@ func (m *Field) Tags() string {
 // IsRelation ...
 func (m *Field) IsRelation() bool { return m.Relation != nil }
 
+// IsTransient field is ignored by gorm(gorm:"-"), e.g. derived/computed field
+func (m *Field) IsTransient() bool {
+	if m.IsRelation() {
+		return false
+	}
+	if gt, ok := m.Tag[field.TagKeyGorm]; ok {
+		return gt == "-"
+	}
+	_, ok := m.GORMTag["-"]
+	return ok
+}
+
+// IsPrimaryKey field is primary key
+func (m *Field) IsPrimaryKey() bool {
+	_, ok := m.GORMTag[field.TagKeyGormPrimaryKey]
+	return ok
+}
+
+// IsDBGenerated field value is generated by database, e.g. auto increment primary key
+func (m *Field) IsDBGenerated() bool {
+	if vs := m.GORMTag[field.TagKeyGormAutoIncrement]; len(vs) > 0 && vs[0] == "true" {
+		return true
+	}
+	_, ok := m.GORMTag[field.TagKeyGormDefault]
+	return ok && m.IsPrimaryKey()
+}
+
+// IsAutoManaged field is maintained by gorm, e.g. created_at, updated_at, deleted_at
+func (m *Field) IsAutoManaged() bool {
+	switch m.ColumnName {
+	case "created_at", "updated_at", "deleted_at":
+		return true
+	}
+	return m.Type == "gorm.DeletedAt"
+}
+
+// IsReadOnly field has read-only permission(->) without write permission(<-), <-:false disables write permission
+func (m *Field) IsReadOnly() bool {
+	if _, read := m.GORMTag[field.TagKeyGormReadOnly]; !read {
+		return false
+	}
+	write, ok := m.GORMTag[field.TagKeyGormWrite]
+	return !ok || len(write) > 0 && write[0] == "false"
+}
+
+// IsSoftDelete field is soft delete field of gorm or gorm.io/plugin/soft_delete
+func (m *Field) IsSoftDelete() bool {
+	switch strings.TrimPrefix(m.Type, "*") {
+	case "gorm.DeletedAt", "soft_delete.DeletedAt":
+		return !m.IsTransient()
+	}
+	return false
+}
+
 // GenType ...
 func (m *Field) GenType() string {
 	if m.IsRelation() {
@@ -232,6 +294,24 @@ func (m *Field) EscapeKeywordFor(keywords KeyWord) *Field {
 	return m
 }
 
+var commentDirectiveReg = regexp.MustCompile(`\{\{(?:[^{}]|\{[^{}]*})*}}`) // single braces allowed in value, e.g. regex quantifier {3}
+
+// StripCommentDirectives remove directives like {{key:value}} from comment
+func StripCommentDirectives(comment string) string {
+	return strings.TrimSpace(commentDirectiveReg.ReplaceAllString(comment, ""))
+}
+
+// CommentDirective get value of directive {{key:value}} in comment and comment with the directive stripped
+func CommentDirective(comment, key string) (value string, stripped string, ok bool) {
+	for _, directive := range commentDirectiveReg.FindAllString(comment, -1) {
+		k, v, found := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(directive, "{{"), "}}"), ":")
+		if found && strings.TrimSpace(k) == key {
+			return strings.TrimSpace(v), strings.TrimSpace(strings.Replace(comment, directive, "", 1)), true
+		}
+	}
+	return "", comment, false
+}
+
 // SQLBuffer sql buffer
 type SQLBuffer struct{ bytes.Buffer }
 
@@ -17,6 +17,17 @@ type Config struct {
 	ImportPkgPaths []string
 	ModelOpts      []Option
 
+	WithTableCommentDoc bool // generate struct doc comment from full table comment
+	WithDocCommentName  bool // prefix struct doc comment with struct name
+	WithSchemaTableName bool // qualify table name in TableName() with schema, default schema omitted
+	WithEnumScanner     bool // collect enum columns mapped to named types for Scanner/Valuer generation
+	WithEnumInteger     bool // back enum type with int when all values are integers
+	WithEnumDefault     bool // initialize enum fields with typed consts of column defaults in constructor
+
+	WithUpdatableColumns bool // collect updatable column names for partial update
+
+	WithFunctionalIndexes bool // collect DDL of functional indexes not representable by field tags
+
 	NameStrategy
 	FieldConfig
 	MethodConfig
@@ -29,19 +40,119 @@ type NameStrategy struct {
 	TableNameNS func(tableName string) string
 	ModelNameNS func(tableName string) string
 	FileNameNS  func(tableName string) string
+
+	TableSchemaNS func(tableName string) string // schema qualifying table name
 }
 
 // FieldConfig field configuration
 type FieldConfig struct {
-	DataTypeMap map[string]func(columnType gorm.ColumnType) (dataType string)
+	DataTypeMap     map[string]func(columnType gorm.ColumnType) (dataType string)
+	TypeTagOverride map[string]string // gorm type tag of column(table.column), empty value means omit
+	TypeTagDialect  string            // target dialect type tag translated to
+	GormTagOrder    []string          // gorm tag key order, empty means default order
+
+	AutoIncrementOverride map[string]bool // autoIncrement of column(table.column) overriding database reported
+
+	ColumnNameOverride map[string]string // gorm column tag of column(table.column), field name and json tag are not affected
+
+	ViewNullableOverride map[string]bool // nullable of view column(view.column) overriding inferred or database reported
+
+	FieldGoDefaults map[string]string // Go-managed default expression of column(table.column), default tag is suppressed
+
+	FieldTimePrecisionTag  bool // generate fractional seconds precision of time column as precision tag
+	FieldFloatPrecisionTag bool // generate precision and scale of float/double column as tags
 
 	FieldNullable     bool // generate pointer when field is nullable
 	FieldCoverable    bool // generate pointer when field has default value
 	FieldSignable     bool // detect integer field's unsigned type, adjust generated data type
+	FieldSignMapped   bool // detect unsigned type for data type from DataTypeMap too
+	FieldUnsignedPK   bool // generate unsigned Go type for auto increment primary key
+	FieldBoolScanType bool // prefer bool scan type over name based mapping for integer column
+	FieldViewNullable bool // infer nullable of view column from base table column
+	FieldEnumType     bool // map enum(set, annotated integer) column to named type derived from table and column name
 	FieldWithIndexTag bool // generate with gorm index tag
 	FieldWithTypeTag  bool // generate with gorm column type tag
+	FieldWithCheckTag bool // generate with gorm check tag
+	FieldOrdinalOrder bool // sort fields by column ordinal position
+	FieldExampleTag   bool // generate example tag from comment directive or default value
+	FieldProtobufTag  bool // generate protobuf tag numbered by column ordinal position
+	FieldOrdinals     bool // read column ordinal position, used to number protobuf message field
+
+	FieldUniqueAsIndex bool // generate unique index as index:name,unique
+	FieldIndexDBOrder  bool // generate index tags in database reported index order
+
+	FieldCompositeIndexes []*CompositeIndex // composite indexes declared in config
+	FieldIndexPrecedence  IndexPrecedence   // precedence between config declared and database reported index
+	FieldIndexNameLimit   bool              // shorten index name exceeding identifier length limit
+	FieldIndexNameMaxLen  int               // identifier length limit of index name, 0 means dialect default
+	FieldIndexNormalize   bool              // normalize priorities of each index to start at 1
+
+	FieldUnsignedDecimal     bool   // constrain unsigned decimal column with gte=0 binding or custom type
+	FieldUnsignedDecimalType string // custom Go type of unsigned decimal column, empty means gte=0 binding
+	FieldBigIntDecimal       bool   // map zero scale decimal overflowing int64 to big integer type
+	FieldBigIntDecimalType   string // Go type of big integer decimal, empty means *big.Int with bigint serializer
+
+	FieldBindingOmitempty  bool     // generate binding omitempty for pointer field
+	FieldJSONDirective     bool     // generate json tag from {{json:name}} directive in column comment
+	FieldCheckDirective    bool     // generate check tag from {{check:expr}} directive in column comment
+	FieldDetectIdentity    bool     // detect identity column not reported as auto increment by driver
+	FieldUUIDBinding       string   // binding rule of uuid column, e.g. uuid, uuid4
+	FieldNetType           bool     // generate network address column(inet, cidr, macaddr) with type tag and normalized default
+	FieldFullTextReadOnly  bool     // generate read-only permission tag for full text search(tsvector) column
+	FieldReadOnlyGenerated bool     // generate read-only permission tag for generated column
+	FieldReadOnlyColumns   []string // read-only columns(table.column), path.Match syntax
+	FieldTriggerColumns    []string // columns(table.column) written only by triggers, path.Match syntax
+	FieldSkipZeroDefault   bool     // skip default tag equal to the Go zero value
+	FieldEnumDefault       bool     // normalize enum default to quoted member string
+
+	FieldBinaryDefault  BinaryDefaultMode           // how to generate default tag for binary(bytea/blob) column
+	FieldEmptyDefault   map[string]EmptyDefaultMode // how to generate empty string default tag of column(table.column)
+	FieldPointerDefault PointerDefaultMode          // how to generate default tag for pointer field
+
+	FieldPointerPolicy       map[string]PointerPolicy // pointer policy of Go type or type family(number, custom)
+	FieldColumnPointerPolicy map[string]PointerPolicy // pointer policy of column(table.column), overriding FieldPointerPolicy
+
+	FieldJSONType         JSONType // Go type of json column
+	FieldJSONArrayColumns []string // json array columns(table.column) for JSONTypeMap
+
+	FieldJSONStructTypes map[string]string // struct type of json columns(table.column) with json serializer
+
+	FieldXMLType       XMLType // Go type of xml column
+	FieldXMLStructType string  // struct type of xml column with xml serializer, overriding FieldXMLType
+
+	FieldArrayType ArrayType // Go type of postgres array column
+
+	FieldYesNoColumns []string // single character columns(table.column) storing 'Y'/'N' mapped to bool with yesno serializer
+	FieldNumericBool  []string // column names of zero scale single digit numeric(numeric(1,0)) mapped to bool
+
+	FieldUTCTimeSerializer string   // serializer for time column without zone info
+	FieldUTCTimeDialects   []string // dialects FieldUTCTimeSerializer work for, empty means all
+
+	FieldTimestampType    string   // custom time type of audit time columns
+	FieldTimestampColumns []string // audit time columns(table.column) mapped to FieldTimestampType, path.Match syntax
+
+	FieldUnixTimeRules []UnixTimeRule // integer unix timestamp columns matched by name pattern
 
 	FieldJSONTagNS func(columnName string) string
+	FieldTagNS     map[string]func(columnName string) string // additional tags
+
+	FieldTagOmitempty map[string]OmitemptyPolicy // omitempty policy of tags keyed by tag key
+
+	FieldTagGenerators []TagGenerator // user tag generators applied in order after built-in tags, gorm tag excluded
+
+	FieldCommentSource func(table, column string) (comment string, ok bool) // comment source used when driver's comment is empty
+
+	FieldCommentFallback func(table, column, fieldName string) string // gorm comment tag of column without comment
+
+	FieldNullableStrategy NullableStrategy         // Go type of nullable field, pointer or sql.Null*
+	FieldNullableSelector NullableStrategySelector // nullable strategy of column overriding FieldNullableStrategy
+	FieldNullableBool     NullableBoolMode         // how to generate nullable bool column
+	FieldEmbeddedConflict EmbeddedConflictMode     // how to handle table column field colliding with embedded struct field
+	FieldSharedPrimaryKey SharedPKMode             // how to generate primary key column which is also foreign key
+
+	FieldForeignKeyRelations bool // read foreign keys to infer association fields between generated models
+
+	FieldProtobufNumbers func(table, column string) (number int, ok bool) // protobuf field numbers overriding ordinal position
 
 	ModifyOpts []FieldOption
 	FilterOpts []FieldOption
@@ -51,6 +162,24 @@ type FieldConfig struct {
 // MethodConfig method configuration
 type MethodConfig struct {
 	MethodOpts []MethodOption
+
+	WithAfterFindHook  bool // generate empty AfterFind hook when model has transient(gorm:"-") fields
+	WithGoDefaultHook  bool // generate BeforeCreate hook assigning Go-managed defaults to zero fields
+	WithValidateMethod bool // generate Validate method when model has validate or binding tag
+	WithModelInterface bool // generate getter of each field, user declared getter is kept
+	WithSoftDeletable  bool // generate IsSoftDeletable marker method when model has soft delete field
+	WithCloneMethod    bool // generate Clone deep copy method
+	WithIndexesMethod  bool // generate Indexes method returning index definitions
+
+	EntityInterface *EntityInterface // generate ID getter implementing interface and compile-time assertion
+}
+
+// EntityInterface interface implemented by model with ID getter, e.g. repo.Entity declaring GetID() int64
+type EntityInterface struct {
+	Name      string            // interface type qualified by package, e.g. repo.Entity
+	Method    string            // ID getter method name, default GetID
+	IDType    string            // result type of ID getter, ID field is converted to it, default type of ID field
+	IDColumns map[string]string // ID column of table(table name to column name), default single primary key column
 }
 
 // Preprocess revise invalid field
@@ -60,7 +189,12 @@ func (cfg *Config) Preprocess() *Config {
 	}
 	cfg.ModelPkg = filepath.Base(cfg.ModelPkg)
 
-	cfg.ModifyOpts, cfg.FilterOpts, cfg.CreateOpts, cfg.MethodOpts = sortOptions(cfg.ModelOpts)
+	var configOpts []FieldConfigOpt
+	configOpts, cfg.ModifyOpts, cfg.FilterOpts, cfg.CreateOpts, cfg.MethodOpts = sortOptions(cfg.ModelOpts)
+	// table options are in front of global options, apply in reverse order to make table options take precedence
+	for i := len(configOpts) - 1; i >= 0; i-- {
+		configOpts[i](&cfg.FieldConfig)
+	}
 
 	return cfg
 }
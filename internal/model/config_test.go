@@ -0,0 +1,28 @@
+package model
+
+import "testing"
+
+func TestConfig_FieldConfigOpt(t *testing.T) {
+	nullable := func(on bool) FieldConfigOpt { return func(cfg *FieldConfig) { cfg.FieldNullable = on } }
+	signable := func(on bool) FieldConfigOpt { return func(cfg *FieldConfig) { cfg.FieldSignable = on } }
+	global := FieldConfig{FieldNullable: true, FieldCoverable: true}
+
+	testcases := []struct {
+		opts     []Option
+		expected FieldConfig
+	}{
+		{nil, FieldConfig{FieldNullable: true, FieldCoverable: true}},
+		{[]Option{nullable(false)}, FieldConfig{FieldNullable: false, FieldCoverable: true}},
+		{[]Option{signable(true)}, FieldConfig{FieldNullable: true, FieldCoverable: true, FieldSignable: true}},
+		// table option is in front of global option
+		{[]Option{nullable(false), nullable(true)}, FieldConfig{FieldNullable: false, FieldCoverable: true}},
+	}
+	for _, testcase := range testcases {
+		cfg := (&Config{FieldConfig: global, ModelOpts: testcase.opts}).Preprocess()
+		if cfg.FieldNullable != testcase.expected.FieldNullable ||
+			cfg.FieldCoverable != testcase.expected.FieldCoverable ||
+			cfg.FieldSignable != testcase.expected.FieldSignable {
+			t.Errorf("field config expect: %+v, got: %+v", testcase.expected, cfg.FieldConfig)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TagNameStrategies built-in tag name strategies, convert column name to tag content
+var TagNameStrategies = map[string]func(columnName string) string{
+	"snake":  func(n string) string { return strings.Join(lowerWords(n), "_") },
+	"kebab":  func(n string) string { return strings.Join(lowerWords(n), "-") },
+	"camel":  func(n string) string { return camelCase(n, false) },
+	"pascal": func(n string) string { return camelCase(n, true) },
+}
+
+// camelCase acronym is treated as a normal word, e.g. user_ID => userId, ID => id
+func camelCase(name string, upperFirst bool) string {
+	var b strings.Builder
+	for i, w := range lowerWords(name) {
+		if i == 0 && !upperFirst {
+			b.WriteString(w)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(w)
+		b.WriteRune(unicode.ToUpper(r))
+		b.WriteString(w[size:])
+	}
+	return b.String()
+}
+
+// lowerWords split name into lower case words by separator(_ - space .) and case boundary,
+// consecutive upper case letters are treated as one word, e.g. HTTPServerID => http server id
+func lowerWords(name string) (words []string) {
+	runes := []rune(name)
+	start := -1
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' || r == '.' {
+			if start >= 0 {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+			}
+			start = -1
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, strings.ToLower(string(runes[start:])))
+	}
+	return words
+}
@@ -0,0 +1,30 @@
+package model
+
+import "testing"
+
+func TestTagNameStrategies(t *testing.T) {
+	testcases := []struct {
+		name     string
+		expected map[string]string
+	}{
+		{"user_name", map[string]string{"snake": "user_name", "kebab": "user-name", "camel": "userName", "pascal": "UserName"}},
+		{"UserName", map[string]string{"snake": "user_name", "kebab": "user-name", "camel": "userName", "pascal": "UserName"}},
+		{"user-ID", map[string]string{"snake": "user_id", "kebab": "user-id", "camel": "userId", "pascal": "UserId"}},
+		{"HTTPServerID", map[string]string{"snake": "http_server_id", "kebab": "http-server-id", "camel": "httpServerId", "pascal": "HttpServerId"}},
+		{"ID", map[string]string{"snake": "id", "kebab": "id", "camel": "id", "pascal": "Id"}},
+		{"__user  name.", map[string]string{"snake": "user_name", "kebab": "user-name", "camel": "userName", "pascal": "UserName"}},
+		{"用户_名称", map[string]string{"snake": "用户_名称", "kebab": "用户-名称", "camel": "用户名称", "pascal": "用户名称"}},
+		{"ÉTAT_civil", map[string]string{"snake": "état_civil", "kebab": "état-civil", "camel": "étatCivil", "pascal": "ÉtatCivil"}},
+		{"", map[string]string{"snake": "", "kebab": "", "camel": "", "pascal": ""}},
+	}
+	for _, testcase := range testcases {
+		for strategy, expected := range testcase.expected {
+			if got := TagNameStrategies[strategy](testcase.name); got != expected {
+				t.Errorf("%s of %q expect: %q, got: %q", strategy, testcase.name, expected, got)
+			}
+		}
+	}
+	if len(TagNameStrategies) != 4 {
+		t.Errorf("tag name strategies expect tested: 4, got: %d", len(TagNameStrategies))
+	}
+}
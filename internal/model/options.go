@@ -28,7 +28,11 @@ type MethodOption interface {
 	Methods() (methods []interface{})
 }
 
+const configType = "config"
+
 var (
+	_ Option = FieldConfigOpt(nil)
+
 	_ Option = ModifyFieldOpt(nil)
 	_ Option = FilterFieldOpt(nil)
 	_ Option = CreateFieldOpt(nil)
@@ -36,6 +40,12 @@ var (
 	_ Option = AddMethodOpt(nil)
 )
 
+// FieldConfigOpt override field configuration for table, e.g. FieldNullable
+type FieldConfigOpt func(*FieldConfig)
+
+// OptionType implement for interface Option
+func (FieldConfigOpt) OptionType() string { return configType }
+
 // ModifyFieldOpt modify field option
 type ModifyFieldOpt func(*Field) *Field
 
@@ -72,9 +82,11 @@ func (AddMethodOpt) OptionType() string { return methodType }
 // Methods ...
 func (o AddMethodOpt) Methods() []interface{} { return o() }
 
-func sortOptions(opts []Option) (modifyOpts []FieldOption, filterOpts []FieldOption, createOpts []FieldOption, methodOpt []MethodOption) {
+func sortOptions(opts []Option) (configOpts []FieldConfigOpt, modifyOpts []FieldOption, filterOpts []FieldOption, createOpts []FieldOption, methodOpt []MethodOption) {
 	for _, opt := range opts {
 		switch opt := opt.(type) {
+		case FieldConfigOpt:
+			configOpts = append(configOpts, opt)
 		case ModifyFieldOpt:
 			modifyOpts = append(modifyOpts, opt)
 		case FilterFieldOpt:
@@ -0,0 +1,11 @@
+package model
+
+// ProtoNullableMode how to declare protobuf message field of nullable model field(pointer or sql.Null*)
+type ProtoNullableMode int
+
+const (
+	// ProtoNullableOptional proto3 optional scalar, e.g. optional string name = 2
+	ProtoNullableOptional ProtoNullableMode = iota
+	// ProtoNullableWrapper well-known wrapper type, e.g. google.protobuf.StringValue name = 2
+	ProtoNullableWrapper
+)
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Check table check constraint info
+type Check struct {
+	Name       string `gorm:"column:CONSTRAINT_NAME"`
+	Constraint string `gorm:"column:CHECK_CLAUSE"`
+}
+
+var identifierReg = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// GroupCheckByColumn group checks by the first column(in table order) referenced by the constraint,
+// checks spanning multiple columns is attached to the first one, checks referencing no column are ignored
+func GroupCheckByColumn(checks []*Check, columnNames []string) map[string][]*Check {
+	columnCheckMap := make(map[string][]*Check, len(checks))
+	for _, check := range checks {
+		if check == nil {
+			continue
+		}
+		referenced := make(map[string]bool)
+		for _, word := range identifierReg.FindAllString(check.Constraint, -1) {
+			referenced[strings.ToLower(word)] = true
+		}
+		for _, name := range columnNames {
+			if referenced[strings.ToLower(name)] {
+				columnCheckMap[name] = append(columnCheckMap[name], check)
+				break
+			}
+		}
+	}
+	return columnCheckMap
+}
+
+// TagValue check tag value: name,constraint
+// backquote is removed and double quote is escaped to keep struct tag valid
+func (c *Check) TagValue() string {
+	constraint := strings.ReplaceAll(c.Constraint, "`", "")
+	constraint = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(constraint)
+	if c.Name == "" {
+		return constraint
+	}
+	return c.Name + "," + constraint
+}
+
+// regexMatchOperators regex match operator of dialects supporting check directive
+var regexMatchOperators = map[string]string{
+	"postgres": "~",
+	"mysql":    "REGEXP",
+}
+
+// directiveCheck check constraint declared by {{check:expr}} directive in comment,
+// {{check:~ 'pattern'}} is shorthand of matching column with regex in current dialect
+func (c *Column) directiveCheck() *Check {
+	if !c.checkDirective {
+		return nil
+	}
+	operator, ok := regexMatchOperators[c.Dialect]
+	if !ok { // check with regex syntax varies between dialects
+		return nil
+	}
+	comment, ok := c.Comment()
+	if !ok {
+		return nil
+	}
+	expr, _, ok := CommentDirective(comment, "check")
+	if !ok || expr == "" {
+		return nil
+	}
+	if pattern := strings.TrimPrefix(expr, "~"); pattern != expr {
+		expr = c.Name() + " " + operator + " " + strings.TrimSpace(pattern)
+	}
+	// semicolon separates gorm tag settings, escape it to keep the pattern intact
+	return &Check{Constraint: strings.ReplaceAll(expr, ";", `\;`)}
+}
+
+// hasCheck whether check constraint read from db is the same as constraint
+func (c *Column) hasCheck(constraint string) bool {
+	for _, check := range c.Checks {
+		if check != nil && strings.TrimSpace(check.Constraint) == constraint {
+			return true
+		}
+	}
+	return false
+}
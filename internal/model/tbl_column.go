@@ -1,9 +1,15 @@
 package model
 
 import (
+	"database/sql"
+	"encoding/hex"
 	"fmt"
+	"go/token"
+	"path"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"gorm.io/gen/field"
@@ -15,25 +21,1043 @@ type Column struct {
 	gorm.ColumnType
 	TableName   string                                                        `gorm:"column:TABLE_NAME"`
 	Indexes     []*Index                                                      `gorm:"-"`
+	Checks      []*Check                                                      `gorm:"-"`
+	ForeignKeys []*ForeignKey                                                 `gorm:"-"`
 	UseScanType bool                                                          `gorm:"-"`
+	Dialect     string                                                        `gorm:"-"`
+	Ordinal     int                                                           `gorm:"-"` // ordinal position in table, 0 means unknown
+	Generated   bool                                                          `gorm:"-"` // generated column(STORED or VIRTUAL)
+	Identity    bool                                                          `gorm:"-"` // identity column(sqlserver)
+	Increment   int64                                                         `gorm:"-"` // increment of identity column, 0 means unknown
+	ViewNull    sql.NullBool                                                  `gorm:"-"` // nullable of view column inferred from base table column or overridden, invalid means as reported
 	dataTypeMap map[string]func(columnType gorm.ColumnType) (dataType string) `gorm:"-"`
+	typeTagMap  map[string]string                                             `gorm:"-"`
+	autoIncrMap map[string]bool                                               `gorm:"-"`
+	colNameMap  map[string]string                                             `gorm:"-"`
+	typeTagTo   string                                                        `gorm:"-"`
 	jsonTagNS   func(columnName string) string                                `gorm:"-"`
+	tagNS       map[string]func(columnName string) string                     `gorm:"-"`
+	omitempty   map[string]OmitemptyPolicy                                    `gorm:"-"`
+	commentFrom func(table, column string) (string, bool)                     `gorm:"-"`
+
+	binaryDefaultMode BinaryDefaultMode `gorm:"-"`
+	sharedPKMode      SharedPKMode      `gorm:"-"`
+	utcTimeSerializer string            `gorm:"-"`
+	utcTimeDialects   []string          `gorm:"-"`
+	timestampType     string            `gorm:"-"`
+	timestampColumns  []string          `gorm:"-"`
+	unixTimeRules     []UnixTimeRule    `gorm:"-"`
+	boolScanType      bool              `gorm:"-"`
+	bindingOmitempty  bool              `gorm:"-"`
+	signMappedType    bool              `gorm:"-"`
+	fullTextReadOnly  bool              `gorm:"-"`
+	readOnlyGenerated bool              `gorm:"-"`
+	readOnlyColumns   []string          `gorm:"-"`
+	triggerColumns    []string          `gorm:"-"`
+	uuidBinding       string            `gorm:"-"`
+	netType           bool              `gorm:"-"`
+	uniqueAsIndex     bool              `gorm:"-"`
+	indexDBOrder      bool              `gorm:"-"`
+	jsonType          JSONType          `gorm:"-"`
+	jsonArrayColumns  []string          `gorm:"-"`
+	jsonStructTypes   map[string]string `gorm:"-"`
+	goDefaults        map[string]string `gorm:"-"`
+	xmlType           XMLType           `gorm:"-"`
+	xmlStructType     string            `gorm:"-"`
+	arrayType         ArrayType         `gorm:"-"`
+	yesNoColumns      []string          `gorm:"-"`
+	numericBoolNames  []string          `gorm:"-"`
+
+	timePrecisionTag   bool               `gorm:"-"`
+	floatPrecisionTag  bool               `gorm:"-"`
+	pointerDefaultMode PointerDefaultMode `gorm:"-"`
+	skipZeroDefault    bool               `gorm:"-"`
+	enumDefault        bool               `gorm:"-"`
+
+	unsignedAutoIncrement bool `gorm:"-"`
+
+	emptyDefaultMap map[string]EmptyDefaultMode `gorm:"-"`
+	gormTagOrder    []string                    `gorm:"-"`
+	jsonDirective   bool                        `gorm:"-"`
+	checkDirective  bool                        `gorm:"-"`
+	enumType        bool                        `gorm:"-"`
+	exampleTag      bool                        `gorm:"-"`
+	detectIdentity  bool                        `gorm:"-"`
+
+	nullableStrategy NullableStrategy         `gorm:"-"`
+	nullableSelector NullableStrategySelector `gorm:"-"`
+	nullableBool     NullableBoolMode         `gorm:"-"`
+
+	typePointerPolicy   map[string]PointerPolicy `gorm:"-"`
+	columnPointerPolicy map[string]PointerPolicy `gorm:"-"`
+
+	protobufTag     bool                                   `gorm:"-"`
+	protobufNumbers func(table, column string) (int, bool) `gorm:"-"`
+
+	unsignedDecimal     bool   `gorm:"-"`
+	unsignedDecimalType string `gorm:"-"`
+	bigIntDecimal       bool   `gorm:"-"`
+	bigIntDecimalType   string `gorm:"-"`
+}
+
+// UnixTimePrecision precision of integer unix timestamp column, value is serializer name
+type UnixTimePrecision string
+
+const (
+	// UnixTimeSecond unix seconds, gorm built-in unixtime serializer
+	UnixTimeSecond UnixTimePrecision = "unixtime"
+	// UnixTimeMilli unix milliseconds
+	UnixTimeMilli UnixTimePrecision = field.UnixMilliSerializerName
+	// UnixTimeNano unix nanoseconds
+	UnixTimeNano UnixTimePrecision = field.UnixNanoSerializerName
+)
+
+// UnixTimeRule map integer column matching name pattern(path.Match syntax) to time.Time with unix serializer
+type UnixTimeRule struct {
+	Pattern   string
+	Precision UnixTimePrecision
+}
+
+// JSONType Go type of json column without user's data type mapping
+type JSONType int
+
+const (
+	// JSONTypeAsIs keep data type as is(string)
+	JSONTypeAsIs JSONType = iota
+	// JSONTypeRaw datatypes.JSON
+	JSONTypeRaw
+	// JSONTypeMap map[string]interface{} with json serializer, []interface{} for json array column
+	JSONTypeMap
+)
+
+// XMLType Go type of xml column without user's data type mapping
+type XMLType int
+
+const (
+	// XMLTypeAsIs keep data type as is(string)
+	XMLTypeAsIs XMLType = iota
+	// XMLTypeBytes []byte, preferred for large documents
+	XMLTypeBytes
+)
+
+// ArrayType Go type of postgres array column without user's data type mapping
+type ArrayType int
+
+const (
+	// ArrayTypeAsIs keep data type as is(string)
+	ArrayTypeAsIs ArrayType = iota
+	// ArrayTypePQ array types of github.com/lib/pq, e.g. pq.StringArray, pq.Int64Array
+	ArrayTypePQ
+	// ArrayTypeJSON slice of element type with json serializer, e.g. []string, driver-agnostic
+	ArrayTypeJSON
+)
+
+// EmbeddedConflictMode how to handle table column field colliding with embedded struct field, e.g. id of gorm.Model
+type EmbeddedConflictMode int
+
+const (
+	// EmbeddedConflictAsIs keep fields as is without detection
+	EmbeddedConflictAsIs EmbeddedConflictMode = iota
+	// EmbeddedConflictPreferEmbedded drop table column field, embedded struct provides the column
+	EmbeddedConflictPreferEmbedded
+	// EmbeddedConflictRename keep table column field renamed with Column suffix, gorm prefers it over embedded field
+	EmbeddedConflictRename
+	// EmbeddedConflictError fail generation with colliding table, column and embedded type
+	EmbeddedConflictError
+)
+
+// PQPkgPath import path of pq array types
+const PQPkgPath = `"github.com/lib/pq"`
+
+// PointerDefaultMode how to generate default tag for pointer field with default value
+type PointerDefaultMode int
+
+const (
+	// PointerDefaultAsIs generate default:<value>
+	PointerDefaultAsIs PointerDefaultMode = iota
+	// PointerDefaultComment generate default:<value> and note that nil means database default in field comment
+	PointerDefaultComment
+	// PointerDefaultDBManaged generate default:(-), nil means database default and value is not declared in tag
+	PointerDefaultDBManaged
+)
+
+// PointerPolicy whether generate pointer for field of Go type or type family, overriding FieldNullable and FieldCoverable
+type PointerPolicy int
+
+const (
+	// PointerPolicyDefault pointer decided by FieldNullable and FieldCoverable
+	PointerPolicyDefault PointerPolicy = iota
+	// PointerPolicyValue never pointer, NULL is scanned as zero value
+	PointerPolicyValue
+	// PointerPolicyNullable pointer when column is nullable
+	PointerPolicyNullable
+	// PointerPolicyAlways always pointer
+	PointerPolicyAlways
+)
+
+const (
+	// TypeFamilyNumber type family of integer and float types
+	TypeFamilyNumber = "number"
+	// TypeFamilyCustom type family of types other than builtin types and time.Time, e.g. decimal.Decimal, datatypes.JSON
+	TypeFamilyCustom = "custom"
+)
+
+// NullableStrategy Go type of nullable field
+type NullableStrategy int
+
+const (
+	// NullablePointer generate pointer, e.g. *string
+	NullablePointer NullableStrategy = iota
+	// NullableSQLNull generate sql.Null* type, e.g. sql.NullString, type without sql.Null* equivalent falls back to pointer
+	NullableSQLNull
+)
+
+// NullableBoolMode how to generate nullable bool column, which is ambiguous between default tag and NULL
+type NullableBoolMode int
+
+const (
+	// NullableBoolAsIs type and default tag depend on nullable, coverable and nullable strategy as other columns
+	NullableBoolAsIs NullableBoolMode = iota
+	// NullableBoolDefault generate *bool with default tag, nil means database default(NULL if no default)
+	NullableBoolDefault
+	// NullableBoolNoDefault generate *bool without default tag, nil means NULL
+	NullableBoolNoDefault
+)
+
+// NullableStrategySelector select nullable strategy of column, return false to use default strategy
+type NullableStrategySelector func(table string, columnType gorm.ColumnType, goType string) (strategy NullableStrategy, ok bool)
+
+// OmitemptyPolicy decide whether append omitempty option to tag of column, goType is Go type of field
+type OmitemptyPolicy func(table string, columnType gorm.ColumnType, goType string) bool
+
+// TagGenerator generate tags of field from column metadata, e.g. validate rules by type, nullability and length,
+// empty content removes the tag
+type TagGenerator func(columnType gorm.ColumnType, f *Field) (tags map[string]string)
+
+// OmitemptyAlways append omitempty to tag of every field
+func OmitemptyAlways(string, gorm.ColumnType, string) bool { return true }
+
+// OmitemptyNullable append omitempty to tag of nullable column's field
+func OmitemptyNullable(_ string, columnType gorm.ColumnType, _ string) bool {
+	nullable, ok := columnType.Nullable()
+	return ok && nullable
+}
+
+// sqlNullTypes sql.Null* equivalent of Go types
+var sqlNullTypes = map[string]string{
+	"string":    "sql.NullString",
+	"bool":      "sql.NullBool",
+	"byte":      "sql.NullByte",
+	"uint8":     "sql.NullByte",
+	"int16":     "sql.NullInt16",
+	"int32":     "sql.NullInt32",
+	"int64":     "sql.NullInt64",
+	"float64":   "sql.NullFloat64",
+	"time.Time": "sql.NullTime",
+}
+
+// EmptyDefaultMode how to generate default tag for empty or whitespace-only string default
+type EmptyDefaultMode int
+
+const (
+	// EmptyDefaultQuoted generate quoted default, e.g. default:''
+	EmptyDefaultQuoted EmptyDefaultMode = iota
+	// EmptyDefaultUnquoted generate default value as database reported without quotes, e.g. default
+	EmptyDefaultUnquoted
+	// EmptyDefaultDrop drop default tag
+	EmptyDefaultDrop
+)
+
+// BinaryDefaultMode how to generate default tag for binary(bytea/blob) column
+type BinaryDefaultMode int
+
+const (
+	// BinaryDefaultAsIs keep default value as database reported
+	BinaryDefaultAsIs BinaryDefaultMode = iota
+	// BinaryDefaultDrop drop default tag
+	BinaryDefaultDrop
+	// BinaryDefaultHex generate default tag as hex literal, empty bytes default generate no tag
+	BinaryDefaultHex
+	// BinaryDefaultComment generate default tag as BinaryDefaultHex and note default as Go byte literal in field comment,
+	// e.g. database default: []byte{0x12, 0x34}, bytes beyond binaryDefaultCommentMax are truncated
+	BinaryDefaultComment
+)
+
+// binaryDefaultCommentMax max bytes of binary default noted in field comment
+const binaryDefaultCommentMax = 16
+
+// OrdinalPosition column's ordinal position in table(starts from 1),
+// read from Ordinal or driver's column type implementing OrdinalPosition() (int, bool)
+func (c *Column) OrdinalPosition() (int, bool) {
+	if c.Ordinal > 0 {
+		return c.Ordinal, true
+	}
+	if ct, ok := c.ColumnType.(interface{ OrdinalPosition() (int, bool) }); ok {
+		return ct.OrdinalPosition()
+	}
+	return 0, false
+}
+
+// tagIndexes indexes emitted in tag, primary key and duplicated indexes are dropped, database reported order is kept
+// if SetIndexDBOrder is on, otherwise unique indexes come first and then ordered by name for deterministic output
+func (c *Column) tagIndexes() []*Index {
+	indexes := make([]*Index, 0, len(c.Indexes))
+	seen := make(map[string]bool, len(c.Indexes))
+	for _, idx := range c.Indexes {
+		if idx == nil {
+			continue
+		}
+		if pk, _ := idx.PrimaryKey(); pk { //ignore PrimaryKey
+			continue
+		}
+		key := fmt.Sprintf("%t:%s", idx.Composite, idx.Name())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		indexes = append(indexes, idx)
+	}
+	if c.indexDBOrder {
+		return indexes
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		ui, _ := indexes[i].Unique()
+		uj, _ := indexes[j].Unique()
+		if ui != uj {
+			return ui
+		}
+		return indexes[i].Name() < indexes[j].Name()
+	})
+	return indexes
+}
+
+// Nullable column nullable, nullable of view column inferred from base table column(ViewNull) takes precedence
+func (c *Column) Nullable() (nullable bool, ok bool) {
+	if c.ViewNull.Valid {
+		return c.ViewNull.Bool, true
+	}
+	return c.ColumnType.Nullable()
+}
+
+// gormColumnType embedded by viewNullColumnType, aliased since field named ColumnType conflicts with method of gorm.ColumnType
+type gormColumnType = gorm.ColumnType
+
+// viewNullColumnType column type reporting nullable of view column inferred from base table column
+type viewNullColumnType struct {
+	gormColumnType
+	nullable bool
+}
+
+func (ct viewNullColumnType) Nullable() (nullable bool, ok bool) { return ct.nullable, true }
+
+// callbackType column type passed to config callbacks, consistent with Nullable
+func (c *Column) callbackType() gorm.ColumnType {
+	if c.ViewNull.Valid {
+		return viewNullColumnType{gormColumnType: c.ColumnType, nullable: c.ViewNull.Bool}
+	}
+	return c.ColumnType
+}
+
+// SetCommentSource set comment source used when driver's comment is empty
+func (c *Column) SetCommentSource(source func(table, column string) (comment string, ok bool)) {
+	c.commentFrom = source
+}
+
+// Comment column comment, read from comment source when driver's comment is empty
+func (c *Column) Comment() (comment string, ok bool) {
+	if comment, ok = c.ColumnType.Comment(); (ok && comment != "") || c.commentFrom == nil {
+		return comment, ok
+	}
+	return c.commentFrom(c.TableName, c.Name())
+}
+
+// SetDataTypeMap set data type map
+func (c *Column) SetDataTypeMap(m map[string]func(columnType gorm.ColumnType) (dataType string)) {
+	c.dataTypeMap = m
+}
+
+// SetColumnTypeTagOverride set gorm type tag override map, keyed by table.column
+func (c *Column) SetColumnTypeTagOverride(m map[string]string) {
+	c.typeTagMap = m
+}
+
+// SetAutoIncrementOverride set autoIncrement override map, keyed by table.column
+func (c *Column) SetAutoIncrementOverride(m map[string]bool) {
+	c.autoIncrMap = m
+}
+
+// SetColumnNameOverride set gorm column tag override map, keyed by table.column
+func (c *Column) SetColumnNameOverride(m map[string]string) {
+	c.colNameMap = m
+}
+
+// SetTypeTagDialect set target dialect of type tag, type tag is translated from column's dialect
+func (c *Column) SetTypeTagDialect(dialect string) {
+	c.typeTagTo = dialect
+}
+
+// TypeTag gorm type tag of column, translated to target dialect if set,
+// ok reports whether translation found, type tag is passed through if not found
+func (c *Column) TypeTag() (typeTag string, ok bool) {
+	return translateTypeTag(c.columnType(), c.Dialect, c.typeTagTo)
+}
+
+// SetFloatPrecisionTag set whether generate precision and scale of float/double column as tags
+func (c *Column) SetFloatPrecisionTag(on bool) {
+	c.floatPrecisionTag = on
+}
+
+// SetTimePrecisionTag set whether generate fractional seconds precision of time column as precision tag
+func (c *Column) SetTimePrecisionTag(on bool) {
+	c.timePrecisionTag = on
+}
+
+// GetDataType get data type
+func (c *Column) GetDataType() (fieldtype string) {
+	fieldtype, _ = c.getDataType()
+	return fieldtype
+}
+
+// getDataType get data type, mapped reports whether data type comes from user's data type map
+func (c *Column) getDataType() (fieldtype string, mapped bool) {
+	if mapping, ok := c.dataTypeMap[c.DatabaseTypeName()]; ok {
+		return mapping(c.ColumnType), true
+	}
+	if name := c.EnumTypeName(); name != "" {
+		return name, false
+	}
+	if c.UseScanType && c.ScanType() != nil {
+		return c.ScanType().String(), false
+	}
+	if c.boolScanType && c.isBoolScanType() {
+		return "bool", false
+	}
+	if c.isNumericBool() {
+		return "bool", false
+	}
+	return dataType.Get(c.DatabaseTypeName(), c.columnType()), false
+}
+
+// SetEnumType set whether map enum column to named type derived from table and column name
+func (c *Column) SetEnumType(on bool) {
+	c.enumType = on
+}
+
+// EnumTypeName named type of enum column set by SetEnumType, table name is included to avoid collision
+// between tables, e.g. users.status => UsersStatus, empty if column is not enum(set, annotated integer) or mode is off
+func (c *Column) EnumTypeName() string {
+	if !c.enumType || len(c.EnumValues()) == 0 {
+		return ""
+	}
+	name := camelCase(c.TableName, true) + camelCase(c.Name(), true)
+	if !token.IsIdentifier(name) { // e.g. table name starts with digit
+		return ""
+	}
+	return name
+}
+
+// SetBoolScanType set whether prefer bool scan type over name based mapping for integer column, e.g. tinyint(1)
+func (c *Column) SetBoolScanType(on bool) {
+	c.boolScanType = on
+}
+
+// isBoolScanType integer column(tinyint/bit) reported with bool or sql.NullBool scan type by driver
+func (c *Column) isBoolScanType() bool {
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "tinyint", "bit", "smallint", "int2":
+	default:
+		return false
+	}
+	scanType := c.ScanType()
+	if scanType == nil {
+		return false
+	}
+	return scanType.Kind() == reflect.Bool || scanType == reflect.TypeOf(sql.NullBool{})
+}
+
+// SetNullableStrategy set nullable strategy and per column selector
+func (c *Column) SetNullableStrategy(strategy NullableStrategy, selector NullableStrategySelector) {
+	c.nullableStrategy = strategy
+	c.nullableSelector = selector
+}
+
+// SetNullableBoolMode set how to generate nullable bool column
+func (c *Column) SetNullableBoolMode(mode NullableBoolMode) {
+	c.nullableBool = mode
+}
+
+// SetPointerPolicy set pointer policies of Go type or type family and column(table.column)
+func (c *Column) SetPointerPolicy(types, columns map[string]PointerPolicy) {
+	c.typePointerPolicy, c.columnPointerPolicy = types, columns
+}
+
+// pointerPolicy pointer policy of column, policy of column takes precedence over Go type's, then type family's
+func (c *Column) pointerPolicy(fieldType string) PointerPolicy {
+	if policy, ok := c.columnPointerPolicy[c.key()]; ok {
+		return policy
+	}
+	if policy, ok := c.typePointerPolicy[fieldType]; ok {
+		return policy
+	}
+	return c.typePointerPolicy[typeFamily(fieldType)]
+}
+
+// pointerPolicyType Go type of field by pointer policy
+func (c *Column) pointerPolicyType(fieldType string, policy PointerPolicy) string {
+	if strings.HasPrefix(fieldType, "*") {
+		return fieldType
+	}
+	switch policy {
+	case PointerPolicyNullable:
+		if n, ok := c.Nullable(); ok && n {
+			return "*" + fieldType
+		}
+	case PointerPolicyAlways:
+		return "*" + fieldType
+	}
+	return fieldType
+}
+
+// typeFamily type family of Go type, builtin type and time.Time are families of their own
+func typeFamily(fieldType string) string {
+	switch {
+	case isNumericType(fieldType):
+		return TypeFamilyNumber
+	case fieldType == "string", fieldType == "bool", fieldType == "[]byte", fieldType == "[]uint8", fieldType == "time.Time":
+		return fieldType
+	}
+	return TypeFamilyCustom
+}
+
+// isNullableBool nullable bool column generated by NullableBoolMode
+func (c *Column) isNullableBool(fieldType string) bool {
+	if c.nullableBool == NullableBoolAsIs || fieldType != "bool" {
+		return false
+	}
+	n, ok := c.Nullable()
+	return ok && n
+}
+
+// nullableType Go type of nullable field, pointer when strategy is pointer or type has no sql.Null* equivalent
+func (c *Column) nullableType(fieldType string) string {
+	strategy := c.nullableStrategy
+	if c.nullableSelector != nil {
+		if s, ok := c.nullableSelector(c.TableName, c.callbackType(), fieldType); ok {
+			strategy = s
+		}
+	}
+	if nullType, ok := sqlNullTypes[fieldType]; ok && strategy == NullableSQLNull {
+		return nullType
+	}
+	return "*" + fieldType
+}
+
+// SetExampleTag set whether generate example tag from {{example:value}} directive in comment or default value
+func (c *Column) SetExampleTag(on bool) {
+	c.exampleTag = on
+}
+
+// exampleTagValue example declared by directive(stripped from comment) or literal default value,
+// expression default(e.g. now()) and null have no meaningful example
+func (c *Column) exampleTagValue(comment string) (string, string) {
+	var example string
+	if value, stripped, ok := CommentDirective(comment, "example"); ok {
+		example, comment = value, stripped
+	} else if value, ok := c.defaultTagValue(); ok && value != expressionDefault {
+		example = unquoteDefault(value)
+	}
+	if strings.EqualFold(example, "null") {
+		return "", comment
+	}
+	return strings.NewReplacer("`", "", `\`, `\\`, `"`, `\"`).Replace(example), comment
+}
+
+// unquoteDefault literal of default value without parentheses(sqlserver), quotes and type cast(postgres),
+// e.g. ('abc') => abc, 'it''s'::character varying => it's
+func unquoteDefault(value string) string {
+	value = strings.TrimSpace(value)
+	for len(value) >= 2 && value[0] == '(' && value[len(value)-1] == ')' {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+	if quoted := enumValueRegexp.FindString(value); quoted != "" && strings.HasPrefix(value, quoted) {
+		return strings.ReplaceAll(quoted[1:len(quoted)-1], "''", "'")
+	}
+	if i := strings.Index(value, "::"); i > 0 {
+		value = value[:i]
+	}
+	return value
+}
+
+// SetProtobufTag set whether generate protobuf tag, numbered by numbers or column ordinal position
+func (c *Column) SetProtobufTag(on bool, numbers func(table, column string) (number int, ok bool)) {
+	c.protobufTag = on
+	c.protobufNumbers = numbers
+}
+
+// protobufTagValue protobuf tag of field type, empty if field number is unknown
+func (c *Column) protobufTagValue(fieldType string) string {
+	number, ok := 0, false
+	if c.protobufNumbers != nil {
+		number, ok = c.protobufNumbers(c.TableName, c.Name())
+	}
+	if !ok {
+		number, ok = c.OrdinalPosition()
+	}
+	if !ok || number <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s,%d,opt,name=%s", protobufWireType(strings.TrimPrefix(fieldType, "*")), number, c.Name())
+}
+
+// protobufWireType protobuf wire type of Go type, message and length-delimited types are bytes
+func protobufWireType(goType string) string {
+	switch goType {
+	case "bool", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "varint"
+	case "float32":
+		return "fixed32"
+	case "float64":
+		return "fixed64"
+	}
+	return "bytes"
+}
+
+// SetGormTagOrder set gorm tag key order
+func (c *Column) SetGormTagOrder(order []string) {
+	c.gormTagOrder = order
+}
+
+// SetEmptyDefaultModes set empty string default tag mode, keyed by table.column
+func (c *Column) SetEmptyDefaultModes(m map[string]EmptyDefaultMode) {
+	c.emptyDefaultMap = m
+}
+
+// SetBinaryDefaultMode set default tag mode for binary column
+func (c *Column) SetBinaryDefaultMode(mode BinaryDefaultMode) {
+	c.binaryDefaultMode = mode
+}
+
+// SetTimestampType set custom time type of audit time columns(table.column, path.Match syntax)
+func (c *Column) SetTimestampType(goType string, columns []string) {
+	c.timestampType, c.timestampColumns = goType, columns
+}
+
+// isTimestampColumn column mapped to custom time type
+func (c *Column) isTimestampColumn() bool {
+	if c.timestampType == "" {
+		return false
+	}
+	for _, pattern := range c.timestampColumns {
+		if ok, _ := path.Match(pattern, c.key()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SetUTCTimeSerializer set serializer for time column without zone info, empty dialects means all dialects
+func (c *Column) SetUTCTimeSerializer(serializer string, dialects []string) {
+	c.utcTimeSerializer, c.utcTimeDialects = serializer, dialects
+}
+
+// SetUnixTimeRules set name pattern rules of integer unix timestamp column
+func (c *Column) SetUnixTimeRules(rules []UnixTimeRule) {
+	c.unixTimeRules = rules
+}
+
+// SetSignMappedType set whether detect unsigned type for data type from user's data type map
+func (c *Column) SetSignMappedType(on bool) {
+	c.signMappedType = on
+}
+
+// SetUnsignedAutoIncrement set whether generate unsigned Go type for auto increment primary key
+func (c *Column) SetUnsignedAutoIncrement(on bool) {
+	c.unsignedAutoIncrement = on
+}
+
+// SetUnsignedDecimal set whether constrain unsigned decimal column, with gte=0 binding if typ is empty or custom type typ
+func (c *Column) SetUnsignedDecimal(on bool, typ string) {
+	c.unsignedDecimal, c.unsignedDecimalType = on, typ
+}
+
+// SetBigIntDecimal set whether map zero scale decimal overflowing int64 to typ, empty typ means *big.Int with bigint serializer
+func (c *Column) SetBigIntDecimal(on bool, typ string) {
+	c.bigIntDecimal, c.bigIntDecimalType = on, typ
+}
+
+// IsBigIntDecimal zero scale decimal overflowing int64(precision > 18) or postgres numeric without precision,
+// mapped to big integer type when SetBigIntDecimal is on, type tag should be kept
+func (c *Column) IsBigIntDecimal() bool {
+	if !c.bigIntDecimal {
+		return false
+	}
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "decimal", "numeric":
+	default:
+		return false
+	}
+	columnType := strings.ToLower(strings.TrimSpace(c.columnType()))
+	if columnType == "numeric" { // postgres numeric without precision and scale
+		return true
+	}
+	matches := decimalPrecisionRegexp.FindStringSubmatch(columnType)
+	if matches == nil {
+		return false
+	}
+	precision, _ := strconv.Atoi(matches[1])
+	return precision > 18 && (matches[2] == "" || strings.Trim(matches[2], "0") == "")
+}
+
+var decimalPrecisionRegexp = regexp.MustCompile(`^(?:decimal|numeric|number)\s*\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)`)
+
+// bigIntDataType Go type of big integer decimal, serializer reports whether bigint serializer needed
+func (c *Column) bigIntDataType() (_ string, serializer bool) {
+	if c.bigIntDecimalType != "" {
+		return c.bigIntDecimalType, false
+	}
+	return "*big.Int", true
+}
+
+// SetFullTextReadOnly set whether generate full text search(tsvector) column as read-only
+func (c *Column) SetFullTextReadOnly(on bool) {
+	c.fullTextReadOnly = on
+}
+
+// SetReadOnly set read-only detection of database managed column, generated column or column(table.column, path.Match syntax) matched
+func (c *Column) SetReadOnly(generated bool, columns []string) {
+	c.readOnlyGenerated, c.readOnlyColumns = generated, columns
+}
+
+// SetTriggerColumns set columns(table.column, path.Match syntax) written only by database triggers
+func (c *Column) SetTriggerColumns(columns []string) {
+	c.triggerColumns = columns
+}
+
+// isTriggerWritten column is written only by database triggers, can be read but not written by app
+func (c *Column) isTriggerWritten() bool {
+	for _, pattern := range c.triggerColumns {
+		if ok, _ := path.Match(pattern, c.key()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isReadOnly column value is fully managed by database, e.g. generated column, tsvector maintained by trigger
+func (c *Column) isReadOnly() bool {
+	if c.fullTextReadOnly && strings.EqualFold(c.DatabaseTypeName(), "tsvector") { // tsvector is usually generated from other columns
+		return true
+	}
+	if c.readOnlyGenerated && c.Generated {
+		return true
+	}
+	for _, pattern := range c.readOnlyColumns {
+		if ok, _ := path.Match(pattern, c.key()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFullTextSearch column is postgres full text search type(tsvector/tsquery), type tag should be kept for AutoMigrate
+func (c *Column) IsFullTextSearch() bool {
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "tsvector", "tsquery":
+		return true
+	}
+	return false
+}
+
+// SetXMLType set Go type of xml column, structType(e.g. types.Invoice) with xml serializer takes precedence over typ
+func (c *Column) SetXMLType(typ XMLType, structType string) {
+	c.xmlType, c.xmlStructType = typ, structType
+}
+
+// IsXML xml column, xml of sqlserver and postgres, xmltype of oracle
+func (c *Column) IsXML() bool {
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "xml", "xmltype", "sys.xmltype":
+		return true
+	}
+	return false
+}
+
+// IsXMLMapped xml column mapped to non-string type, type tag is kept to create xml column in migration
+func (c *Column) IsXMLMapped() bool {
+	return c.IsXML() && (c.xmlType != XMLTypeAsIs || c.xmlStructType != "")
+}
+
+// xmlDataType get xml column's Go type, serializer reports whether xml serializer needed
+func (c *Column) xmlDataType(fieldType string) (_ string, serializer bool) {
+	if c.xmlStructType != "" {
+		return c.xmlStructType, true
+	}
+	if c.xmlType == XMLTypeBytes {
+		return "[]byte", false
+	}
+	return fieldType, false
+}
+
+// SetArrayType set Go type of postgres array column
+func (c *Column) SetArrayType(typ ArrayType) {
+	c.arrayType = typ
+}
+
+// arrayElemTypes Go element type and pq array type of postgres array element type
+var arrayElemTypes = map[string][2]string{
+	"int2":              {"int32", "pq.Int32Array"},
+	"int4":              {"int32", "pq.Int32Array"},
+	"smallint":          {"int32", "pq.Int32Array"},
+	"integer":           {"int32", "pq.Int32Array"},
+	"int8":              {"int64", "pq.Int64Array"},
+	"bigint":            {"int64", "pq.Int64Array"},
+	"float4":            {"float32", "pq.Float32Array"},
+	"real":              {"float32", "pq.Float32Array"},
+	"float8":            {"float64", "pq.Float64Array"},
+	"double precision":  {"float64", "pq.Float64Array"},
+	"numeric":           {"float64", "pq.Float64Array"},
+	"bool":              {"bool", "pq.BoolArray"},
+	"boolean":           {"bool", "pq.BoolArray"},
+	"text":              {"string", "pq.StringArray"},
+	"varchar":           {"string", "pq.StringArray"},
+	"character varying": {"string", "pq.StringArray"},
+	"bpchar":            {"string", "pq.StringArray"},
+	"character":         {"string", "pq.StringArray"},
+	"citext":            {"string", "pq.StringArray"},
+	"uuid":              {"string", "pq.StringArray"},
+	"bytea":             {"[]byte", "pq.ByteaArray"},
+}
+
+// arrayElemType element type name of one dimensional postgres array column, e.g. _int4 or integer[] => int4/integer
+func (c *Column) arrayElemType() string {
+	if name := strings.ToLower(c.DatabaseTypeName()); strings.HasPrefix(name, "_") {
+		return name[1:]
+	}
+	columnType := strings.ToLower(strings.TrimSpace(c.columnType()))
+	if !strings.HasSuffix(columnType, "[]") || strings.HasSuffix(columnType, "[][]") {
+		return ""
+	}
+	elemType := strings.TrimSuffix(columnType, "[]")
+	if i := strings.Index(elemType, "("); i >= 0 { // e.g. varchar(64)[]
+		elemType = elemType[:i]
+	}
+	return strings.TrimSpace(elemType)
+}
+
+// IsArrayMapped postgres array column mapped by ArrayType, type tag is kept to create array column in migration
+func (c *Column) IsArrayMapped() bool {
+	if c.arrayType == ArrayTypeAsIs {
+		return false
+	}
+	_, ok := arrayElemTypes[c.arrayElemType()]
+	return ok
+}
+
+// arrayDataType get postgres array column's Go type, serializer reports whether json serializer needed
+func (c *Column) arrayDataType(fieldType string) (_ string, serializer bool) {
+	types, ok := arrayElemTypes[c.arrayElemType()]
+	switch {
+	case !ok:
+		return fieldType, false
+	case c.arrayType == ArrayTypePQ:
+		return types[1], false
+	case c.arrayType == ArrayTypeJSON:
+		return "[]" + types[0], true
+	default:
+		return fieldType, false
+	}
+}
+
+// SetYesNoColumns set single character columns(table.column, path.Match syntax) storing 'Y'/'N' mapped to bool
+func (c *Column) SetYesNoColumns(columns []string) {
+	c.yesNoColumns = columns
+}
+
+// isYesNo single character column storing 'Y'/'N' mapped to bool with yesno serializer
+func (c *Column) isYesNo() bool {
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "char", "nchar", "character", "bpchar":
+	default:
+		return false
+	}
+	if !strings.HasSuffix(c.columnType(), "(1)") {
+		return false
+	}
+	for _, pattern := range c.yesNoColumns {
+		if ok, _ := path.Match(pattern, c.key()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SetNumericBool set column names(path.Match syntax) of zero scale single digit numeric mapped to bool
+func (c *Column) SetNumericBool(names []string) {
+	c.numericBoolNames = names
+}
+
+// isNumericBool zero scale single digit numeric column(numeric(1,0), NUMBER(1)) used as boolean, name matched
+func (c *Column) isNumericBool() bool {
+	if len(c.numericBoolNames) == 0 {
+		return false
+	}
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "decimal", "numeric", "number":
+	default:
+		return false
+	}
+	matches := decimalPrecisionRegexp.FindStringSubmatch(strings.ToLower(strings.TrimSpace(c.columnType())))
+	if matches == nil || strings.TrimLeft(matches[1], "0") != "1" || strings.Trim(matches[2], "0") != "" {
+		return false
+	}
+	for _, pattern := range c.numericBoolNames {
+		if ok, _ := path.Match(pattern, c.Name()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SetUniqueAsIndex set whether generate unique index as index:name,unique instead of uniqueIndex:name
+func (c *Column) SetUniqueAsIndex(on bool) {
+	c.uniqueAsIndex = on
+}
+
+// SetIndexDBOrder set whether generate index tags in database reported index order,
+// unique index is generated as index:name,unique to keep order in one tag key
+func (c *Column) SetIndexDBOrder(on bool) {
+	c.indexDBOrder = on
+}
+
+// SetJSONType set Go type of json column, arrayColumns(table.column) are json array columns
+func (c *Column) SetJSONType(typ JSONType, arrayColumns []string) {
+	c.jsonType, c.jsonArrayColumns = typ, arrayColumns
+}
+
+// SetGoDefaults set Go-managed default expression of columns(table.column), default tag of them is suppressed
+func (c *Column) SetGoDefaults(defaults map[string]string) {
+	c.goDefaults = defaults
+}
+
+// SetJSONStructTypes set struct type of json columns(table.column) with json serializer
+func (c *Column) SetJSONStructTypes(types map[string]string) {
+	c.jsonStructTypes = types
+}
+
+// jsonStructType struct type of json column, leading * is trimmed as pointer is decided by nullable
+func (c *Column) jsonStructType() string {
+	return strings.TrimPrefix(strings.TrimSpace(c.jsonStructTypes[c.key()]), "*")
+}
+
+// SetPointerDefaultMode set default tag mode for pointer field with default value
+func (c *Column) SetPointerDefaultMode(mode PointerDefaultMode) {
+	c.pointerDefaultMode = mode
+}
+
+// SetSkipZeroDefault set whether to skip default tag equal to the Go zero value of field type
+func (c *Column) SetSkipZeroDefault(skip bool) {
+	c.skipZeroDefault = skip
+}
+
+// SetEnumDefault set whether normalize enum column's default value to quoted member string
+func (c *Column) SetEnumDefault(on bool) {
+	c.enumDefault = on
+}
+
+// SetUUIDBinding set binding rule(uuid, uuid4 etc.) of uuid column, empty means no binding
+func (c *Column) SetUUIDBinding(rule string) {
+	c.uuidBinding = rule
+}
+
+// isUUID uuid column, e.g. postgres uuid, sqlserver uniqueidentifier, mysql char(36)
+func (c *Column) isUUID() bool {
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "uuid", "uniqueidentifier":
+		return true
+	}
+	return strings.EqualFold(c.columnType(), "char(36)")
+}
+
+// SetNetType set whether generate network address column with type tag and normalized default
+func (c *Column) SetNetType(on bool) {
+	c.netType = on
+}
+
+// IsNetType postgres network address column(inet, cidr, macaddr) when SetNetType is on, type tag should be kept
+func (c *Column) IsNetType() bool {
+	if !c.netType {
+		return false
+	}
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "inet", "cidr", "macaddr", "macaddr8":
+		return true
+	}
+	return false
+}
+
+// netDefaultTagValue quoted literal of network address default without postgres cast,
+// e.g. '0.0.0.0/0'::cidr => '0.0.0.0/0', NULL(NULL::inet) default is dropped
+func netDefaultTagValue(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if isExpressionDefault(value) {
+		return expressionDefault, true
+	}
+	literal := unquoteDefault(value)
+	if !strings.HasPrefix(value, "'") && strings.EqualFold(literal, "NULL") {
+		return "", false
+	}
+	return "'" + strings.ReplaceAll(literal, "'", "''") + "'", true
+}
+
+// SetDetectIdentity set whether detect identity column not reported as auto increment by driver
+func (c *Column) SetDetectIdentity(on bool) {
+	c.detectIdentity = on
 }
 
-// SetDataTypeMap set data type map
-func (c *Column) SetDataTypeMap(m map[string]func(columnType gorm.ColumnType) (dataType string)) {
-	c.dataTypeMap = m
+// AutoIncrement column is auto increment, identity column not reported by driver is detected when detectIdentity is on
+func (c *Column) AutoIncrement() (isAutoIncrement bool, ok bool) {
+	if isAutoIncrement, ok = c.autoIncrMap[c.key()]; ok {
+		return isAutoIncrement, ok
+	}
+	if c.sharedPKForeignKey() != nil { // key value comes from referenced row
+		return false, true
+	}
+	if isAutoIncrement, ok = c.ColumnType.AutoIncrement(); (ok && isAutoIncrement) || !c.detectIdentity {
+		return isAutoIncrement, ok
+	}
+	if c.isIdentity() {
+		return true, true
+	}
+	return isAutoIncrement, ok
 }
 
-// GetDataType get data type
-func (c *Column) GetDataType() (fieldtype string) {
-	if mapping, ok := c.dataTypeMap[c.DatabaseTypeName()]; ok {
-		return mapping(c.ColumnType)
+// isIdentity identity column filled from metadata, reported by driver's column type implementing IsIdentity() (bool, bool)
+// or declared in column type, e.g. int identity(1,1)
+func (c *Column) isIdentity() bool {
+	if c.Identity {
+		return true
 	}
-	if c.UseScanType && c.ScanType() != nil {
-		return c.ScanType().String()
+	if ct, ok := c.ColumnType.(interface{ IsIdentity() (bool, bool) }); ok {
+		if identity, ok := ct.IsIdentity(); ok && identity {
+			return true
+		}
 	}
-	return dataType.Get(c.DatabaseTypeName(), c.columnType())
+	return strings.Contains(strings.ToLower(c.columnType()), "identity")
+}
+
+// SetJSONDirective set whether generate json tag from {{json:name}} directive in comment
+func (c *Column) SetJSONDirective(on bool) {
+	c.jsonDirective = on
+}
+
+// SetCheckDirective set whether generate check tag from {{check:expr}} directive in comment
+func (c *Column) SetCheckDirective(on bool) {
+	c.checkDirective = on
+}
+
+// SetBindingOmitempty set whether add omitempty to binding tag of pointer field
+func (c *Column) SetBindingOmitempty(on bool) {
+	c.bindingOmitempty = on
 }
 
 // WithNS with name strategy
@@ -44,21 +1068,130 @@ func (c *Column) WithNS(jsonTagNS func(columnName string) string) {
 	}
 }
 
+// WithTagNS with additional tags' name strategy, keyed by tag key
+func (c *Column) WithTagNS(tagNS map[string]func(columnName string) string) {
+	c.tagNS = tagNS
+}
+
+// SetTagNS register additional tag's name strategy, e.g. form, xml, mapstructure, nil ns removes the tag
+func (c *Column) SetTagNS(tagKey string, ns func(columnName string) string) {
+	tagNS := make(map[string]func(columnName string) string, len(c.tagNS)+1)
+	for key, fn := range c.tagNS { // copy, tagNS may be shared by columns
+		tagNS[key] = fn
+	}
+	if ns == nil {
+		delete(tagNS, tagKey)
+	} else {
+		tagNS[tagKey] = ns
+	}
+	c.tagNS = tagNS
+}
+
+// ApplyTagGenerators merge tags generated by user generators into field tags in order, empty content removes the tag,
+// gorm tag is skipped
+func (c *Column) ApplyTagGenerators(f *Field, generators []TagGenerator) {
+	for _, generator := range generators {
+		tags := generator(c.callbackType(), f)
+		if len(tags) > 0 && f.Tag == nil {
+			f.Tag = make(field.Tag, len(tags))
+		}
+		for key, content := range tags {
+			switch {
+			case key == "" || key == field.TagKeyGorm:
+			case content == "":
+				f.Tag.Remove(key)
+			default:
+				f.Tag.Set(key, content)
+			}
+		}
+	}
+}
+
+// SetOmitemptyPolicies set omitempty policy of tags keyed by tag key, e.g. json, form
+func (c *Column) SetOmitemptyPolicies(policies map[string]OmitemptyPolicy) {
+	c.omitempty = policies
+}
+
+// tagWithOmitempty append omitempty option to tag content, binding/validate rules are prefixed with omitempty,
+// content "-" is kept as is
+func tagWithOmitempty(key, content string) string {
+	switch {
+	case content == "" || content == "-":
+		return content
+	case key == field.TagKeyBinding || key == field.TagKeyValidate:
+		return bindingWithOmitempty(content)
+	case contains(strings.Split(content, ",")[1:], "omitempty"):
+		return content
+	default:
+		return content + ",omitempty"
+	}
+}
+
 // ToField convert to field
 func (c *Column) ToField(nullable, coverable, signable bool) *Field {
-	fieldType := c.GetDataType()
-	if signable && strings.Contains(c.columnType(), "unsigned") && strings.HasPrefix(fieldType, "int") {
+	fieldType, mapped := c.getDataType()
+	jsonSerializer, jsonStruct := false, false
+	if !mapped && c.isJSON() {
+		fieldType, jsonSerializer = c.jsonDataType(fieldType)
+		jsonStruct = c.jsonStructType() != ""
+	}
+	xmlSerializer := false
+	if !mapped && c.IsXML() {
+		fieldType, xmlSerializer = c.xmlDataType(fieldType)
+	}
+	arrayMapped, arraySerializer := !mapped && c.IsArrayMapped(), false
+	if arrayMapped {
+		fieldType, arraySerializer = c.arrayDataType(fieldType)
+	}
+	yesNo := !mapped && c.isYesNo()
+	if yesNo {
+		fieldType = "bool"
+	}
+	bigIntSerializer := false
+	if !mapped && c.IsBigIntDecimal() {
+		fieldType, bigIntSerializer = c.bigIntDataType()
+	}
+	unixTimeSerializer := c.unixTimeSerializer(fieldType, mapped)
+	if unixTimeSerializer != "" {
+		fieldType = "time.Time"
+	}
+	if signable && (!mapped || c.signMappedType) && strings.Contains(c.columnType(), "unsigned") && strings.HasPrefix(fieldType, "int") {
 		fieldType = "u" + fieldType
 	}
+	if c.unsignedAutoIncrement && c.isAutoIncrementPK() && strings.HasPrefix(fieldType, "int") {
+		fieldType = "u" + fieldType
+	}
+	unsignedDecimal := c.unsignedDecimal && c.isUnsignedDecimal()
+	if unsignedDecimal && c.unsignedDecimalType != "" {
+		fieldType = c.unsignedDecimalType
+	}
+	if fieldType == "time.Time" && unixTimeSerializer == "" && c.isTimestampColumn() {
+		fieldType = c.timestampType
+	}
 	defaultValue, ok := c.defaultTagValue()
+	pointerPolicy := c.pointerPolicy(fieldType)
+	nullableBool := c.isNullableBool(fieldType)
 	switch {
-	case c.Name() == "deleted_at" && fieldType == "time.Time":
+	case c.Name() == "deleted_at" && fieldType == "time.Time" && unixTimeSerializer == "":
 		fieldType = "gorm.DeletedAt"
+	case jsonStruct: // pointer to struct for NULL, neither sql.Null* nor double pointer
+		if n, ok := c.Nullable(); nullable && ok && n {
+			fieldType = "*" + fieldType
+		}
+	case jsonSerializer: // nullable json is nil map or slice
+	case arrayMapped: // nullable array is nil slice
+	case bigIntSerializer: // *big.Int is pointer already
+	case pointerPolicy != PointerPolicyDefault: // regardless of nullable and coverable
+		fieldType = c.pointerPolicyType(fieldType, pointerPolicy)
+	case nullableBool: // regardless of nullable, coverable and nullable strategy
+		fieldType = "*bool"
 	case coverable && ok && c.needDefaultTag(defaultValue):
 		fieldType = "*" + fieldType
 	case nullable && !strings.HasPrefix(fieldType, "*"):
-		if n, ok := c.Nullable(); ok && n {
+		if n, ok := c.Nullable(); ok && n && yesNo { // yesno serializer supports pointer only
 			fieldType = "*" + fieldType
+		} else if ok && n {
+			fieldType = c.nullableType(fieldType)
 		}
 	}
 
@@ -66,23 +1199,183 @@ func (c *Column) ToField(nullable, coverable, signable bool) *Field {
 	if c, ok := c.Comment(); ok {
 		comment = c
 	}
+	var example string
+	if c.exampleTag {
+		example, comment = c.exampleTagValue(comment)
+	}
+	jsonTag := c.jsonTagNS(c.Name())
+	if c.jsonDirective {
+		if name, stripped, ok := CommentDirective(comment, "json"); ok && name != "" {
+			jsonTag, comment = name, stripped
+		}
+	}
+	if c.checkDirective {
+		if _, stripped, ok := CommentDirective(comment, "check"); ok {
+			comment = stripped
+		}
+	}
+	if c.enumType && c.IsEnumAnnotated() { // not binding rule
+		comment = strings.TrimSpace(enumAnnotationRegexp.ReplaceAllString(comment, ""))
+	}
 	comment, binding := c.commentToBinding(comment)
+	if unsignedDecimal && c.unsignedDecimalType == "" && isNumericType(strings.TrimPrefix(fieldType, "*")) {
+		binding = bindingWithRule(binding, "gte=0")
+	}
+	uuidBinding := c.uuidBinding != "" && c.isUUID() && strings.TrimPrefix(fieldType, "*") == "string" && !hasUUIDRule(binding)
+	if uuidBinding {
+		binding = bindingWithRule(binding, c.uuidBinding)
+	}
+	if (c.bindingOmitempty || uuidBinding) && strings.HasPrefix(fieldType, "*") {
+		binding = bindingWithOmitempty(binding)
+	}
 	tag := map[string]string{
-		field.TagKeyJson: c.jsonTagNS(c.Name()),
+		field.TagKeyJson: jsonTag,
 	}
 	if binding != "" {
 		tag[field.TagKeyBinding] = binding
 	}
+	if example != "" {
+		tag[field.TagKeyExample] = example
+	}
+	if c.protobufTag {
+		if pb := c.protobufTagValue(fieldType); pb != "" {
+			tag[field.TagKeyProtobuf] = pb
+		}
+	}
+	for key, ns := range c.tagNS {
+		if key == field.TagKeyGorm || ns == nil {
+			continue
+		}
+		if content := ns(c.Name()); content != "" {
+			tag[key] = content
+		}
+	}
+	for key, policy := range c.omitempty {
+		if content, ok := tag[key]; ok && policy != nil && policy(c.TableName, c.callbackType(), fieldType) {
+			tag[key] = tagWithOmitempty(key, content)
+		}
+	}
+
+	gormTag := c.buildGormTag()
+	if nullableBool && c.nullableBool == NullableBoolNoDefault { // nil is NULL instead of database default
+		gormTag.Remove(field.TagKeyGormDefault)
+	}
+	if jsonSerializer {
+		gormTag.Set(field.TagKeyGormSerializer, "json")
+	}
+	if xmlSerializer {
+		gormTag.Set(field.TagKeyGormSerializer, field.XMLSerializerName)
+	}
+	if arraySerializer {
+		gormTag.Set(field.TagKeyGormSerializer, "json")
+	}
+	if yesNo {
+		gormTag.Set(field.TagKeyGormSerializer, field.YesNoSerializerName)
+	}
+	if bigIntSerializer {
+		gormTag.Set(field.TagKeyGormSerializer, field.BigIntSerializerName)
+	}
+	if unixTimeSerializer != "" {
+		gormTag.Set(field.TagKeyGormSerializer, unixTimeSerializer)
+	} else if strings.TrimPrefix(fieldType, "*") == "time.Time" && c.needUTCTimeSerializer() {
+		gormTag.Set(field.TagKeyGormSerializer, c.utcTimeSerializer)
+	}
+	multiline := c.multilineComment()
+	if dv, ok := gormTag[field.TagKeyGormDefault]; ok && len(dv) > 0 && strings.HasPrefix(fieldType, "*") {
+		switch c.pointerDefaultMode {
+		case PointerDefaultComment:
+			comment, multiline = appendComment(comment, multiline, fmt.Sprintf("nil means database default: %s", dv[0]))
+		case PointerDefaultDBManaged:
+			gormTag.Set(field.TagKeyGormDefault, "(-)")
+		}
+	}
+	if c.binaryDefaultMode == BinaryDefaultComment {
+		if note := c.binaryDefaultComment(); note != "" {
+			comment, multiline = appendComment(comment, multiline, note)
+		}
+	}
+	if fk := c.sharedPKForeignKey(); fk != nil && c.sharedPKMode == SharedPKComment {
+		comment, multiline = appendComment(comment, multiline, "shared primary key references "+fk.Reference())
+	}
 
+	ordinal, _ := c.OrdinalPosition()
 	return &Field{
 		Name:             c.Name(),
 		Type:             fieldType,
 		ColumnName:       c.Name(),
-		MultilineComment: c.multilineComment(),
-		GORMTag:          c.buildGormTag(),
+		MultilineComment: multiline,
+		GORMTag:          gormTag,
+		GORMTagOrder:     c.gormTagOrder,
 		Tag:              tag,
 		ColumnComment:    comment,
+		GoDefault:        c.goDefaults[c.key()],
+		Ordinal:          ordinal,
+	}
+}
+
+// appendComment append note to field comment, in new line for multiline comment
+func appendComment(comment string, multiline bool, note string) (string, bool) {
+	switch {
+	case comment == "":
+		return note, multiline
+	case multiline:
+		return comment + "\n" + note, multiline
+	default:
+		return comment + "; " + note, multiline
+	}
+}
+
+// unixTimeSerializer serializer of integer column matching unix time rules, empty means not matched
+func (c *Column) unixTimeSerializer(fieldType string, mapped bool) string {
+	if mapped || !(strings.HasPrefix(fieldType, "int") || strings.HasPrefix(fieldType, "uint")) {
+		return ""
+	}
+	for _, rule := range c.unixTimeRules {
+		if ok, _ := path.Match(rule.Pattern, c.Name()); ok {
+			if rule.Precision == "" {
+				return string(UnixTimeSecond)
+			}
+			return string(rule.Precision)
+		}
+	}
+	return ""
+}
+
+// needUTCTimeSerializer time column without zone info(timestamptz etc.) in specified dialects
+func (c *Column) needUTCTimeSerializer() bool {
+	if c.utcTimeSerializer == "" {
+		return false
+	}
+	if len(c.utcTimeDialects) > 0 && !contains(c.utcTimeDialects, c.Dialect) {
+		return false
 	}
+
+	typ := strings.ToLower(c.columnType())
+	return !strings.HasSuffix(strings.ToLower(c.DatabaseTypeName()), "tz") &&
+		!strings.Contains(typ, "datetimeoffset") &&
+		!(strings.Contains(typ, "with time zone") && !strings.Contains(typ, "without time zone"))
+}
+
+var timePrecisionRegexp = regexp.MustCompile(`(?i)^(datetime|timestamp|timestamptz|time|timetz)\s*\((\d+)\)(.*)$`)
+
+// splitTimePrecision split fractional seconds precision from time type, e.g. datetime(6) -> datetime, 6
+func splitTimePrecision(typeTag string) (typ string, precision string) {
+	matches := timePrecisionRegexp.FindStringSubmatch(strings.TrimSpace(typeTag))
+	if matches == nil {
+		return typeTag, ""
+	}
+	return matches[1] + matches[3], matches[2]
+}
+
+var floatPrecisionRegexp = regexp.MustCompile(`(?i)^(float|double|real)\s*\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)(.*)$`)
+
+// splitFloatPrecision split precision and scale from float type, e.g. double(16,4) -> double, 16, 4
+func splitFloatPrecision(typeTag string) (typ string, precision string, scale string) {
+	matches := floatPrecisionRegexp.FindStringSubmatch(strings.TrimSpace(typeTag))
+	if matches == nil {
+		return typeTag, "", ""
+	}
+	return matches[1] + matches[4], matches[2], matches[3]
 }
 
 func (c *Column) multilineComment() bool {
@@ -90,10 +1383,44 @@ func (c *Column) multilineComment() bool {
 	return ok && strings.Contains(cm, "\n")
 }
 
+// columnTagValue escape column name for gorm column tag, identifier quotes around name(e.g. "Order" quoted as
+// reserved word) are removed as gorm quotes column itself, tag separator ; is escaped for gorm,
+// backquote is removed and double quote is escaped to keep struct tag valid
+func columnTagValue(name string) string {
+	name = strings.ReplaceAll(name, "`", "")
+	if n := len(name); n > 2 && (name[0] == '"' && name[n-1] == '"' || name[0] == '[' && name[n-1] == ']') {
+		name = strings.ReplaceAll(name[1:n-1], `""`, `"`)
+	}
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, ";", `\\;`).Replace(name)
+}
+
 func (c *Column) buildGormTag() field.GormTag {
 	tag := field.GormTag{
-		field.TagKeyGormColumn: []string{c.Name()},
-		field.TagKeyGormType:   []string{c.columnType()},
+		field.TagKeyGormColumn: []string{columnTagValue(c.Name())},
+	}
+	if name := c.colNameMap[c.key()]; name != "" {
+		tag.Set(field.TagKeyGormColumn, columnTagValue(name))
+	}
+	if typeTag, ok := c.typeTagMap[c.key()]; !ok {
+		typeTag, _ = c.TypeTag()
+		if c.timePrecisionTag {
+			var precision string
+			if typeTag, precision = splitTimePrecision(typeTag); precision != "" {
+				tag.Set(field.TagKeyGormPrecision, precision)
+			}
+		}
+		if c.floatPrecisionTag {
+			var precision, scale string
+			if typeTag, precision, scale = splitFloatPrecision(typeTag); precision != "" {
+				tag.Set(field.TagKeyGormPrecision, precision)
+			}
+			if scale != "" {
+				tag.Set(field.TagKeyGormScale, scale)
+			}
+		}
+		tag.Set(field.TagKeyGormType, typeTag)
+	} else if typeTag != "" {
+		tag.Set(field.TagKeyGormType, typeTag)
 	}
 	isPriKey, ok := c.PrimaryKey()
 	isValidPriKey := ok && isPriKey
@@ -101,26 +1428,61 @@ func (c *Column) buildGormTag() field.GormTag {
 		tag.Set(field.TagKeyGormPrimaryKey, "")
 		if at, ok := c.AutoIncrement(); ok {
 			tag.Set(field.TagKeyGormAutoIncrement, fmt.Sprintf("%t", at))
+			if at && c.detectIdentity && c.Increment > 1 {
+				tag.Set(field.TagKeyGormAutoIncrementIncrement, strconv.FormatInt(c.Increment, 10))
+			}
 		}
 	} else if n, ok := c.Nullable(); ok && !n {
 		tag.Set(field.TagKeyGormNotNull, "")
 	}
+	autoIncrOverride, overridden := c.autoIncrMap[c.key()]
+	if overridden && autoIncrOverride && !isValidPriKey { // sequence backed column
+		tag.Set(field.TagKeyGormAutoIncrement, "true")
+	}
 
-	for _, idx := range c.Indexes {
-		if idx == nil {
-			continue
+	if c.isTimestampColumn() { // custom time type is not tracked by gorm automatically
+		switch c.Name() {
+		case "created_at":
+			tag.Set(field.TagKeyGormAutoCreateTime)
+		case "updated_at":
+			tag.Set(field.TagKeyGormAutoUpdateTime)
 		}
-		if pk, _ := idx.PrimaryKey(); pk { //ignore PrimaryKey
-			continue
-		}
-		if uniq, _ := idx.Unique(); uniq {
-			tag.Append(field.TagKeyGormUniqueIndex, fmt.Sprintf("%s,priority:%d", idx.Name(), idx.Priority))
+	}
+
+	for _, idx := range c.tagIndexes() {
+		if idx.Composite {
+			tag.Append(field.TagKeyGormIndex, fmt.Sprintf(",composite:%s,priority:%d", idx.Name(), idx.Priority))
+		} else if uniq, _ := idx.Unique(); uniq && (c.uniqueAsIndex || c.indexDBOrder) {
+			tag.Append(field.TagKeyGormIndex, fmt.Sprintf("%s,unique,priority:%d%s", idx.tagName(), idx.Priority, idx.tagOption()))
+		} else if uniq {
+			tag.Append(field.TagKeyGormUniqueIndex, fmt.Sprintf("%s,priority:%d%s", idx.tagName(), idx.Priority, idx.tagOption()))
 		} else {
-			tag.Append(field.TagKeyGormIndex, fmt.Sprintf("%s,priority:%d", idx.Name(), idx.Priority))
+			tag.Append(field.TagKeyGormIndex, fmt.Sprintf("%s,priority:%d", idx.tagName(), idx.Priority))
+		}
+	}
+
+	readOnly := c.isReadOnly()
+	if readOnly { // not null and default interfere with reads of database managed column
+		tag.Set(field.TagKeyGormReadOnly)
+		tag.Remove(field.TagKeyGormNotNull)
+	} else if c.isTriggerWritten() {
+		tag.Set(field.TagKeyGormReadOnly)
+		tag.Set(field.TagKeyGormWrite, "false")
+		if _, hasDefault := c.DefaultValue(); !hasDefault { // app cannot populate it, trigger may set it after insert
+			tag.Remove(field.TagKeyGormNotNull)
 		}
 	}
 
-	if dtValue, ok := c.defaultTagValue(); ok {
+	for _, check := range c.Checks {
+		if check != nil {
+			tag.Append(field.TagKeyGormCheck, check.TagValue())
+		}
+	}
+	if check := c.directiveCheck(); check != nil && !c.hasCheck(check.Constraint) {
+		tag.Append(field.TagKeyGormCheck, check.TagValue())
+	}
+
+	if dtValue, ok := c.defaultTagValue(); ok && !readOnly && !(overridden && autoIncrOverride) { // sequence default is implied by autoIncrement
 		if c.needDefaultTag(dtValue) { // cannot set default tag for primary key
 			tag.Set(field.TagKeyGormDefault, dtValue)
 		}
@@ -152,12 +1514,59 @@ func (c *Column) commentToBinding(comment string) (string, string) {
 	}
 }
 
+// bindingWithOmitempty make binding optional, binding declared required(e.g. [[required]] in comment) is kept
+func bindingWithOmitempty(binding string) string {
+	rules := strings.Split(binding, ",")
+	if contains(rules, "required") || contains(rules, "omitempty") {
+		return binding
+	}
+	if binding == "" {
+		return "omitempty"
+	}
+	return "omitempty," + binding
+}
+
+// bindingWithRule append rule to binding, binding already declared the same rule(e.g. gte=1 in comment) is kept
+func bindingWithRule(binding, rule string) string {
+	name := strings.SplitN(rule, "=", 2)[0]
+	for _, r := range strings.Split(binding, ",") {
+		if strings.SplitN(r, "=", 2)[0] == name {
+			return binding
+		}
+	}
+	if binding == "" {
+		return rule
+	}
+	return binding + "," + rule
+}
+
+// hasUUIDRule binding declared uuid rule(e.g. uuid4 in comment), which takes precedence
+func hasUUIDRule(binding string) bool {
+	for _, rule := range strings.Split(binding, ",") {
+		if strings.HasPrefix(rule, "uuid") {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumericType(typ string) bool {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return true
+	}
+	return false
+}
+
 // needDefaultTag check if default tag needed
 // FIX: fix 0 or '' default value missing error
 func (c *Column) needDefaultTag(defaultTagValue string) bool {
 	//if defaultTagValue == "" {
 	//	return false
 	//}
+	if c.skipZeroDefault && c.isZeroDefault(defaultTagValue) {
+		return false
+	}
 	switch c.ScanType().Kind() {
 	case reflect.Bool:
 		return true
@@ -168,7 +1577,30 @@ func (c *Column) needDefaultTag(defaultTagValue string) bool {
 	case reflect.Struct:
 		return strings.Trim(defaultTagValue, "'0:- ") != ""
 	}
-	return c.Name() != "created_at" && c.Name() != "updated_at"
+	// expression default of created_at/updated_at is declared, e.g. CURRENT_TIMESTAMP with raw bytes scan type
+	return defaultTagValue == expressionDefault || c.Name() != "created_at" && c.Name() != "updated_at"
+}
+
+// isZeroDefault check if default value equals to the Go zero value of column type, e.g. 0, false, empty string
+func (c *Column) isZeroDefault(defaultTagValue string) bool {
+	value := strings.TrimSpace(defaultTagValue)
+	if i := strings.Index(value, "::"); i > 0 { // postgres type cast: '0'::integer
+		value = value[:i]
+	}
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(value, "("), ")"))
+	switch c.ScanType().Kind() {
+	case reflect.Bool:
+		switch strings.ToLower(strings.Trim(value, "'")) {
+		case "0", "false", "f", "b'0":
+			return true
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.Trim(value, "'"), 64)
+		return err == nil && f == 0
+	case reflect.String:
+		return value == "''"
+	}
+	return false
 }
 
 // defaultTagValue return gorm default tag's value
@@ -178,12 +1610,301 @@ func (c *Column) defaultTagValue() (string, bool) {
 	if !ok {
 		return "", false
 	}
+	if _, goManaged := c.goDefaults[c.key()]; goManaged { // value is assigned by app, e.g. in BeforeCreate hook
+		return "", false
+	}
+	if c.binaryDefaultMode != BinaryDefaultAsIs && c.isBinary() {
+		return c.binaryDefaultTagValue(value)
+	}
+	if c.IsNetType() {
+		return netDefaultTagValue(value)
+	}
+	if c.enumDefault && strings.EqualFold(c.DatabaseTypeName(), "enum") {
+		if values := c.EnumValues(); len(values) > 0 {
+			return enumDefaultTagValue(value, values)
+		}
+	}
 	if strings.TrimSpace(value) == "" {
+		switch c.emptyDefaultMap[c.key()] {
+		case EmptyDefaultUnquoted:
+			return value, true
+		case EmptyDefaultDrop:
+			return "", false
+		}
 		return "'" + value + "'", true
 	}
+	if isExpressionDefault(value) {
+		return expressionDefault, true
+	}
 	return value, true
 }
 
+// expressionDefault default tag value of expression default, gorm parses plain default value as literal
+// (e.g. CURRENT_TIMESTAMP of string field), (-) means default value is generated by database
+const expressionDefault = "(-)"
+
+// defaultKeywords sql keywords evaluated as default value
+var defaultKeywords = map[string]bool{
+	"CURRENT_TIMESTAMP": true,
+	"CURRENT_DATE":      true,
+	"CURRENT_TIME":      true,
+	"LOCALTIMESTAMP":    true,
+	"LOCALTIME":         true,
+	"CURRENT_USER":      true,
+	"SESSION_USER":      true,
+}
+
+// isExpressionDefault check if default value is function call(e.g. now(), gen_random_uuid()) or keyword,
+// quoted literal and number wrapped in parentheses(sqlserver: ((0)), ('abc')) are not
+func isExpressionDefault(value string) bool {
+	value = strings.TrimSpace(value)
+	for len(value) >= 2 && value[0] == '(' && value[len(value)-1] == ')' {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+	if value == "" || value[0] == '\'' {
+		return false
+	}
+	return strings.Contains(value, "(") && strings.Contains(value, ")") || defaultKeywords[strings.ToUpper(value)]
+}
+
+// enumDefaultTagValue normalize enum default to quoted member string, 1-based member index is resolved,
+// index 0(empty/invalid member) and unknown member are dropped
+func enumDefaultTagValue(value string, values []string) (string, bool) {
+	member := strings.TrimSpace(value)
+	quoted := len(member) >= 2 && member[0] == '\'' && member[len(member)-1] == '\''
+	if quoted {
+		member = strings.ReplaceAll(member[1:len(member)-1], "''", "'")
+	}
+	if !contains(values, member) {
+		index, err := strconv.Atoi(member)
+		if quoted || err != nil || index <= 0 || index > len(values) {
+			return "", false
+		}
+		member = values[index-1]
+	}
+	return "'" + strings.ReplaceAll(member, "'", "''") + "'", true
+}
+
+// EnumMember member of enum default value, 1-based member index is resolved, ok is false for unknown member
+func EnumMember(value string, values []string) (member string, ok bool) {
+	quoted, ok := enumDefaultTagValue(value, values)
+	if !ok {
+		return "", false
+	}
+	return strings.ReplaceAll(quoted[1:len(quoted)-1], "''", "'"), true
+}
+
+// key column's unique key: table.column
+func (c *Column) key() string {
+	return c.TableName + "." + c.Name()
+}
+
+func (c *Column) isJSON() bool {
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "json", "jsonb":
+		return true
+	}
+	return false
+}
+
+// jsonDataType get json column's Go type, serializer reports whether json serializer needed
+func (c *Column) jsonDataType(fieldType string) (_ string, serializer bool) {
+	if structType := c.jsonStructType(); structType != "" {
+		return structType, true
+	}
+	switch c.jsonType {
+	case JSONTypeRaw:
+		return "datatypes.JSON", false
+	case JSONTypeMap:
+		if contains(c.jsonArrayColumns, c.key()) || c.isJSONArrayDefault() {
+			return "[]interface{}", true
+		}
+		return "map[string]interface{}", true
+	default:
+		return fieldType, false
+	}
+}
+
+// isJSONArrayDefault detect json array column by default value, e.g. '[]', (json_array()), '[]'::jsonb
+func (c *Column) isJSONArrayDefault() bool {
+	value, ok := c.DefaultValue()
+	if !ok {
+		return false
+	}
+	value = strings.ToLower(strings.Trim(strings.TrimSpace(value), "'()"))
+	return strings.HasPrefix(value, "[") || strings.HasPrefix(value, "json_array") || strings.HasPrefix(value, "jsonb_build_array")
+}
+
+// EnumValues values of enum(set) column parsed from column type, e.g. enum('a','b'), or values of integer column
+// declared by comment annotation, e.g. [[enum:1=Active,2=Disabled]]
+func (c *Column) EnumValues() []string {
+	if values, _ := c.EnumAnnotation(); len(values) > 0 {
+		return values
+	}
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "enum", "set":
+	default:
+		return nil
+	}
+	columnType, _ := c.ColumnType.ColumnType()
+	start, end := strings.Index(columnType, "("), strings.LastIndex(columnType, ")")
+	if start < 0 || end <= start {
+		return nil
+	}
+	var values []string
+	for _, v := range enumValueRegexp.FindAllString(columnType[start+1:end], -1) {
+		values = append(values, strings.ReplaceAll(v[1:len(v)-1], "''", "'"))
+	}
+	return values
+}
+
+var enumValueRegexp = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+// enumAnnotationRegexp enum annotation in comment of integer column, e.g. [[enum:1=Active,2=Disabled]]
+var enumAnnotationRegexp = regexp.MustCompile(`\[\[enum:([^\]]*)]]`)
+
+// integerTypes database types of integer column which can be annotated as enum
+var integerTypes = map[string]bool{
+	"tinyint": true, "smallint": true, "mediumint": true, "int": true, "integer": true, "bigint": true,
+	"int2": true, "int4": true, "int8": true,
+}
+
+// EnumAnnotation values and their labels declared by [[enum:1=Active,2=Disabled]] in comment of integer column,
+// nil if not annotated or any value is not integer
+func (c *Column) EnumAnnotation() (values []string, labels map[string]string) {
+	if !integerTypes[strings.ToLower(c.DatabaseTypeName())] {
+		return nil, nil
+	}
+	comment, _ := c.Comment()
+	match := enumAnnotationRegexp.FindStringSubmatch(comment)
+	if match == nil {
+		return nil, nil
+	}
+	labels = make(map[string]string)
+	for _, item := range strings.Split(match[1], ",") {
+		value, label, _ := strings.Cut(item, "=")
+		value, label = strings.TrimSpace(value), strings.TrimSpace(label)
+		if i, err := strconv.Atoi(value); err != nil || strconv.Itoa(i) != value {
+			return nil, nil
+		}
+		if !contains(values, value) {
+			values = append(values, value)
+		}
+		if label != "" {
+			labels[value] = label
+		}
+	}
+	return values, labels
+}
+
+// IsEnumAnnotated integer column declared as enum by comment annotation
+func (c *Column) IsEnumAnnotated() bool {
+	values, _ := c.EnumAnnotation()
+	return len(values) > 0
+}
+
+func (c *Column) isAutoIncrementPK() bool {
+	pk, ok := c.PrimaryKey()
+	if !ok || !pk {
+		return false
+	}
+	ai, ok := c.AutoIncrement()
+	return ok && ai
+}
+
+func (c *Column) isUnsignedDecimal() bool {
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "decimal", "numeric":
+		return strings.Contains(strings.ToLower(c.columnType()), "unsigned")
+	}
+	return false
+}
+
+func (c *Column) isBinary() bool {
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "bytea", "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob", "image":
+		return true
+	}
+	return false
+}
+
+// binaryDefaultTagValue convert binary default value to hex literal of current dialect
+// e.g. postgres: '\x1234'::bytea, mysql: 0x1234 / _binary'abc' / ''
+func (c *Column) binaryDefaultTagValue(value string) (string, bool) {
+	if c.binaryDefaultMode == BinaryDefaultDrop {
+		return "", false
+	}
+
+	hexValue := binaryDefaultHex(value)
+	if hexValue == "" { // empty bytes
+		return "", false
+	}
+
+	switch c.Dialect {
+	case "postgres":
+		return `'\x` + hexValue + `'`, true
+	case "sqlserver":
+		return "0x" + hexValue, true
+	default:
+		return "X'" + hexValue + "'", true
+	}
+}
+
+// binaryDefaultHex hex digits of binary default value, e.g. '\x1234'::bytea, '\001ab'::bytea(escape format), 0x1234, X'1234', _binary'ab'
+func binaryDefaultHex(value string) string {
+	value = strings.TrimSpace(value)
+	bytea := strings.HasSuffix(value, "::bytea")
+	value = strings.TrimSuffix(value, "::bytea")
+	switch lower := strings.ToLower(value); {
+	case strings.HasPrefix(lower, "0x"):
+		return value[2:]
+	case strings.HasPrefix(lower, "x'"):
+		return strings.Trim(value[1:], "'")
+	case strings.HasPrefix(lower, "'\\x"):
+		return strings.Trim(value[3:], "'")
+	default:
+		value = strings.TrimPrefix(value, "_binary")
+		if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+		if bytea {
+			value = byteaEscapeRegexp.ReplaceAllStringFunc(value, func(s string) string {
+				if s == `\\` {
+					return `\`
+				}
+				b, _ := strconv.ParseUint(s[1:], 8, 8)
+				return string([]byte{byte(b)})
+			})
+		}
+		return hex.EncodeToString([]byte(value))
+	}
+}
+
+// byteaEscapeRegexp escaped byte of postgres bytea escape format, octal \ooo or backslash \\
+var byteaEscapeRegexp = regexp.MustCompile(`\\[0-3][0-7]{2}|\\\\`)
+
+// binaryDefaultComment note of binary default as Go byte literal, e.g. database default: []byte{0x12, 0x34},
+// empty if column has no binary default or default is not valid hex
+func (c *Column) binaryDefaultComment() string {
+	value, ok := c.DefaultValue()
+	if !ok || !c.isBinary() || strings.EqualFold(strings.TrimSpace(value), "null") {
+		return ""
+	}
+	data, err := hex.DecodeString(binaryDefaultHex(value))
+	if err != nil {
+		return ""
+	}
+	literals := make([]string, 0, binaryDefaultCommentMax+1)
+	for i, b := range data {
+		if i == binaryDefaultCommentMax {
+			literals = append(literals, fmt.Sprintf("... %d bytes", len(data)))
+			break
+		}
+		literals = append(literals, fmt.Sprintf("0x%02x", b))
+	}
+	return "database default: []byte{" + strings.Join(literals, ", ") + "}"
+}
+
 func (c *Column) columnType() (v string) {
 	if cl, ok := c.ColumnType.ColumnType(); ok {
 		// FIX: fix blob binary type error
@@ -202,3 +1923,12 @@ func (c *Column) columnType() (v string) {
 	}
 	return c.DatabaseTypeName()
 }
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
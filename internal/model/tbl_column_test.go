@@ -0,0 +1,1812 @@
+package model
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen/field"
+)
+
+func newColumn(name, dataType, columnType string, opts ...func(*migrator.ColumnType)) *Column {
+	ct := migrator.ColumnType{
+		NameValue:       sql.NullString{String: name, Valid: true},
+		DataTypeValue:   sql.NullString{String: dataType, Valid: true},
+		ColumnTypeValue: sql.NullString{String: columnType, Valid: true},
+		NullableValue:   sql.NullBool{Bool: true, Valid: true},
+		ScanTypeValue:   reflect.TypeOf(""),
+	}
+	for _, opt := range opts {
+		opt(&ct)
+	}
+	col := &Column{ColumnType: ct, TableName: "users"}
+	col.WithNS(nil)
+	return col
+}
+
+func withDefault(value string) func(*migrator.ColumnType) {
+	return func(ct *migrator.ColumnType) { ct.DefaultValueValue = sql.NullString{String: value, Valid: true} }
+}
+
+func withScanType(typ reflect.Type) func(*migrator.ColumnType) {
+	return func(ct *migrator.ColumnType) { ct.ScanTypeValue = typ }
+}
+
+func TestColumn_BinaryDefault(t *testing.T) {
+	bytesType := reflect.TypeOf([]byte(nil))
+	testcases := []struct {
+		dialect  string
+		column   *Column
+		mode     BinaryDefaultMode
+		expected []string
+	}{
+		{"postgres", newColumn("data", "bytea", "bytea", withScanType(bytesType), withDefault(`'\x'::bytea`)), BinaryDefaultHex, nil},
+		{"postgres", newColumn("data", "bytea", "bytea", withScanType(bytesType), withDefault(`'\x1234'::bytea`)), BinaryDefaultHex, []string{`'\x1234'`}},
+		{"postgres", newColumn("data", "bytea", "bytea", withScanType(bytesType), withDefault(`'\x1234'::bytea`)), BinaryDefaultDrop, nil},
+		{"postgres", newColumn("data", "bytea", "bytea", withScanType(bytesType), withDefault(`'\x1234'::bytea`)), BinaryDefaultAsIs, []string{`'\x1234'::bytea`}},
+		{"mysql", newColumn("data", "blob", "blob", withScanType(bytesType), withDefault(``)), BinaryDefaultHex, nil},
+		{"mysql", newColumn("data", "blob", "blob", withScanType(bytesType), withDefault(`_binary'ab'`)), BinaryDefaultHex, []string{`X'6162'`}},
+		{"mysql", newColumn("data", "varbinary", "varbinary(16)", withScanType(bytesType), withDefault(`0x1234`)), BinaryDefaultHex, []string{`X'1234'`}},
+		{"mysql", newColumn("data", "blob", "blob", withScanType(bytesType), withDefault(``)), BinaryDefaultAsIs, []string{`''`}},
+		{"mysql", newColumn("name", "varchar", "varchar(16)", withDefault(``)), BinaryDefaultHex, []string{`''`}},
+	}
+	for _, testcase := range testcases {
+		testcase.column.Dialect = testcase.dialect
+		testcase.column.SetBinaryDefaultMode(testcase.mode)
+		tag := testcase.column.ToField(false, false, false).GORMTag
+		if got := tag[field.TagKeyGormDefault]; !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("%s column %s default tag expect: %v, got: %v", testcase.dialect, testcase.column.Name(), testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_BinaryDefaultComment(t *testing.T) {
+	bytesType := reflect.TypeOf([]byte(nil))
+	testcases := []struct {
+		column          *Column
+		expectedTag     []string
+		expectedComment string
+	}{
+		{newColumn("data", "bytea", "bytea", withScanType(bytesType), withDefault(`'\x1234'::bytea`)), []string{`'\x1234'`}, "database default: []byte{0x12, 0x34}"},
+		{newColumn("data", "bytea", "bytea", withScanType(bytesType), withDefault(`'\001a\\'::bytea`)), []string{`'\x01615c'`}, "database default: []byte{0x01, 0x61, 0x5c}"},
+		{newColumn("data", "bytea", "bytea", withScanType(bytesType), withDefault(`'\x'::bytea`)), nil, "database default: []byte{}"},
+		{newColumn("data", "bytea", "bytea", withScanType(bytesType), withDefault(`'\x`+strings.Repeat("ab", 20)+`'::bytea`)), []string{`'\x` + strings.Repeat("ab", 20) + `'`},
+			"database default: []byte{" + strings.Repeat("0xab, ", 16) + "... 20 bytes}"},
+		{newColumn("data", "bytea", "bytea", withScanType(bytesType)), nil, ""},
+		{newColumn("name", "varchar", "varchar(16)", withDefault(`'ab'`)), []string{`'ab'`}, ""},
+	}
+	for _, testcase := range testcases {
+		testcase.column.Dialect = "postgres"
+		testcase.column.SetBinaryDefaultMode(BinaryDefaultComment)
+		f := testcase.column.ToField(false, false, false)
+		if got := f.GORMTag[field.TagKeyGormDefault]; !reflect.DeepEqual(got, testcase.expectedTag) {
+			t.Errorf("column %s default tag expect: %v, got: %v", testcase.column.Name(), testcase.expectedTag, got)
+		}
+		if f.ColumnComment != testcase.expectedComment {
+			t.Errorf("column %s comment expect: %q, got: %q", testcase.column.Name(), testcase.expectedComment, f.ColumnComment)
+		}
+	}
+}
+
+func TestColumn_SignMappedType(t *testing.T) {
+	dataTypeMap := map[string]func(columnType gorm.ColumnType) (dataType string){
+		"int": func(gorm.ColumnType) string { return "int64" },
+	}
+	testcases := []struct {
+		column     *Column
+		mapping    bool
+		signMapped bool
+		expected   string
+	}{
+		{newColumn("age", "int", "int unsigned"), false, false, "uint32"},
+		{newColumn("age", "int", "int unsigned"), true, false, "int64"},
+		{newColumn("age", "int", "int unsigned"), true, true, "uint64"},
+		{newColumn("age", "int", "int"), true, true, "int64"},
+	}
+	for _, testcase := range testcases {
+		if testcase.mapping {
+			testcase.column.SetDataTypeMap(dataTypeMap)
+		}
+		testcase.column.SetSignMappedType(testcase.signMapped)
+		if got := testcase.column.ToField(false, false, true).Type; got != testcase.expected {
+			t.Errorf("column type %s(mapping: %t, signMapped: %t) expect: %s, got: %s",
+				testcase.column.columnType(), testcase.mapping, testcase.signMapped, testcase.expected, got)
+		}
+	}
+}
+
+func newIndex(name string, unique bool, priority int32) *Index {
+	return &Index{
+		Index: migrator.Index{
+			NameValue:   name,
+			UniqueValue: sql.NullBool{Bool: unique, Valid: true},
+		},
+		Priority: priority,
+	}
+}
+
+func TestColumn_UniqueAsIndex(t *testing.T) {
+	testcases := []struct {
+		indexes       []*Index
+		uniqueAsIndex bool
+		expected      string
+	}{
+		{[]*Index{newIndex("idx_name", true, 1)}, false, "column:name;type:varchar(64);uniqueIndex:idx_name,priority:1"},
+		{[]*Index{newIndex("idx_name", true, 1)}, true, "column:name;type:varchar(64);index:idx_name,unique,priority:1"},
+		{[]*Index{newIndex("idx_name_age", true, 1), newIndex("idx_name", false, 1)}, true,
+			"column:name;type:varchar(64);index:idx_name_age,unique,priority:1;index:idx_name,priority:1"},
+		{[]*Index{newIndex("idx_age_name", true, 2)}, true, "column:name;type:varchar(64);index:idx_age_name,unique,priority:2"},
+	}
+	for _, testcase := range testcases {
+		col := newColumn("name", "varchar", "varchar(64)")
+		col.Indexes = testcase.indexes
+		col.SetUniqueAsIndex(testcase.uniqueAsIndex)
+		if got := col.ToField(false, false, false).GORMTag.Build(); got != testcase.expected {
+			t.Errorf("gorm tag expect: %s, got: %s", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_UniqueAsIndexParsedByGorm(t *testing.T) {
+	tenant, email := newColumn("tenant_id", "bigint", "bigint"), newColumn("email", "varchar", "varchar(64)")
+	tenant.Indexes, email.Indexes = []*Index{newIndex("idx_tenant_email", true, 1)}, []*Index{newIndex("idx_tenant_email", true, 2)}
+	tenant.SetUniqueAsIndex(true)
+	email.SetUniqueAsIndex(true)
+
+	st := reflect.StructOf([]reflect.StructField{
+		{Name: "TenantID", Type: reflect.TypeOf(int64(0)), Tag: reflect.StructTag(`gorm:"` + tenant.ToField(false, false, false).GORMTag.Build() + `"`)},
+		{Name: "Email", Type: reflect.TypeOf(""), Tag: reflect.StructTag(`gorm:"` + email.ToField(false, false, false).GORMTag.Build() + `"`)},
+	})
+	s, err := schema.Parse(reflect.New(st).Interface(), &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("parse schema fail: %s", err)
+	}
+	idx := s.LookIndex("idx_tenant_email")
+	if idx == nil || idx.Class != "UNIQUE" || len(idx.Fields) != 2 || idx.Fields[0].DBName != "tenant_id" || idx.Fields[1].DBName != "email" {
+		t.Errorf("gorm expect parse composite unique index idx_tenant_email(tenant_id, email), got: %+v", idx)
+	}
+}
+
+func TestColumn_MultipleIndexes(t *testing.T) {
+	testcases := []struct {
+		indexes       []*Index
+		uniqueAsIndex bool
+		expected      string
+	}{
+		{[]*Index{newIndex("idx_name_age", false, 1), newIndex("idx_name", true, 1)}, false,
+			"column:name;type:varchar(64);uniqueIndex:idx_name,priority:1;index:idx_name_age,priority:1"},
+		{[]*Index{newIndex("idx_name_age", false, 1), newIndex("idx_name", true, 1), newIndex("idx_name", true, 1)}, true,
+			"column:name;type:varchar(64);index:idx_name,unique,priority:1;index:idx_name_age,priority:1"},
+		{[]*Index{newIndex("idx_name_b", false, 2), newIndex("idx_name_a", false, 1), nil}, false,
+			"column:name;type:varchar(64);index:idx_name_a,priority:1;index:idx_name_b,priority:2"},
+	}
+	for _, testcase := range testcases {
+		col := newColumn("name", "varchar", "varchar(64)")
+		col.Indexes = testcase.indexes
+		col.SetUniqueAsIndex(testcase.uniqueAsIndex)
+		if got := col.ToField(false, false, false).GORMTag.Build(); got != testcase.expected {
+			t.Errorf("gorm tag expect: %s, got: %s", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_IndexDBOrder(t *testing.T) {
+	testcases := []struct {
+		indexes      []*Index
+		indexDBOrder bool
+		expected     string
+	}{
+		{[]*Index{newIndex("idx_b", false, 1), newIndex("uq_a", true, 1), newIndex("idx_c", false, 1)}, false,
+			"column:name;type:varchar(64);uniqueIndex:uq_a,priority:1;index:idx_b,priority:1;index:idx_c,priority:1"},
+		{[]*Index{newIndex("idx_b", false, 1), newIndex("uq_a", true, 1), newIndex("idx_c", false, 1)}, true,
+			"column:name;type:varchar(64);index:idx_b,priority:1;index:uq_a,unique,priority:1;index:idx_c,priority:1"},
+		{[]*Index{newIndex("idx_c", false, 2), newIndex("idx_a", false, 1), newIndex("idx_c", false, 2), nil}, true,
+			"column:name;type:varchar(64);index:idx_c,priority:2;index:idx_a,priority:1"},
+	}
+	for _, testcase := range testcases {
+		col := newColumn("name", "varchar", "varchar(64)")
+		col.Indexes = testcase.indexes
+		col.SetIndexDBOrder(testcase.indexDBOrder)
+		for i := 0; i < 3; i++ {
+			if got := col.ToField(false, false, false).GORMTag.Build(); got != testcase.expected {
+				t.Errorf("gorm tag expect: %s, got: %s", testcase.expected, got)
+			}
+		}
+	}
+}
+
+func TestColumn_NullsNotDistinctIndex(t *testing.T) {
+	nullsNotDistinct := func(idx *Index) *Index {
+		idx.NullsNotDistinct = true
+		return idx
+	}
+	testcases := []struct {
+		indexes       []*Index
+		uniqueAsIndex bool
+		expected      string
+	}{
+		{[]*Index{newIndex("idx_name", true, 1)}, false, "column:name;type:varchar(64);uniqueIndex:idx_name,priority:1"},
+		{[]*Index{nullsNotDistinct(newIndex("idx_name", true, 1))}, false,
+			"column:name;type:varchar(64);uniqueIndex:idx_name,priority:1,option:NULLS NOT DISTINCT"},
+		{[]*Index{nullsNotDistinct(newIndex("idx_name", true, 1))}, true,
+			"column:name;type:varchar(64);index:idx_name,unique,priority:1,option:NULLS NOT DISTINCT"},
+		{[]*Index{nullsNotDistinct(newIndex("idx_name", false, 1))}, false, "column:name;type:varchar(64);index:idx_name,priority:1"},
+	}
+	for _, testcase := range testcases {
+		col := newColumn("name", "varchar", "varchar(64)")
+		col.Indexes = testcase.indexes
+		col.SetUniqueAsIndex(testcase.uniqueAsIndex)
+		if got := col.ToField(false, false, false).GORMTag.Build(); got != testcase.expected {
+			t.Errorf("gorm tag expect: %s, got: %s", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_PointerDefault(t *testing.T) {
+	nullable := func(on bool) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: on, Valid: true} }
+	}
+	testcases := []struct {
+		column          *Column
+		nullable        bool
+		coverable       bool
+		mode            PointerDefaultMode
+		expectedType    string
+		expectedDefault []string
+		expectedComment string
+	}{
+		{newColumn("name", "varchar", "varchar(64)", withDefault("'abc'")), true, false, PointerDefaultAsIs, "*string", []string{"'abc'"}, ""},
+		{newColumn("name", "varchar", "varchar(64)", withDefault("'abc'")), true, false, PointerDefaultComment, "*string", []string{"'abc'"}, "nil means database default: 'abc'"},
+		{newColumn("name", "varchar", "varchar(64)", withDefault("'abc'")), true, false, PointerDefaultDBManaged, "*string", []string{"(-)"}, ""},
+		{newColumn("age", "int", "int", withDefault("18"), nullable(false)), false, true, PointerDefaultDBManaged, "*int32", []string{"(-)"}, ""},
+		{newColumn("age", "int", "int", withDefault("18"), nullable(false)), false, false, PointerDefaultDBManaged, "int32", []string{"18"}, ""},
+		{newColumn("age", "int", "int", withDefault("18"), nullable(false)), true, false, PointerDefaultComment, "int32", []string{"18"}, ""},
+		{newColumn("name", "varchar", "varchar(64)"), true, false, PointerDefaultDBManaged, "*string", nil, ""},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetPointerDefaultMode(testcase.mode)
+		f := testcase.column.ToField(testcase.nullable, testcase.coverable, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", f.ColumnName, testcase.expectedType, f.Type)
+		}
+		if got := f.GORMTag[field.TagKeyGormDefault]; !reflect.DeepEqual(got, testcase.expectedDefault) {
+			t.Errorf("column %s default tag expect: %v, got: %v", f.ColumnName, testcase.expectedDefault, got)
+		}
+		if f.ColumnComment != testcase.expectedComment {
+			t.Errorf("column %s comment expect: %q, got: %q", f.ColumnName, testcase.expectedComment, f.ColumnComment)
+		}
+	}
+}
+
+func TestColumn_NullableBool(t *testing.T) {
+	newBoolColumn := func(opts ...func(*migrator.ColumnType)) *Column {
+		return newColumn("active", "boolean", "boolean", append(opts, withScanType(reflect.TypeOf(false)))...)
+	}
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column          *Column
+		nullable        bool
+		coverable       bool
+		mode            NullableBoolMode
+		expectedType    string
+		expectedDefault []string
+	}{
+		{newBoolColumn(withDefault("true")), false, false, NullableBoolAsIs, "bool", []string{"true"}},
+		{newBoolColumn(withDefault("true")), false, false, NullableBoolDefault, "*bool", []string{"true"}},
+		{newBoolColumn(withDefault("true")), true, true, NullableBoolNoDefault, "*bool", nil},
+		{newBoolColumn(), false, false, NullableBoolDefault, "*bool", nil},
+		{newBoolColumn(), false, false, NullableBoolNoDefault, "*bool", nil},
+		{newBoolColumn(withDefault("true"), notNull), false, false, NullableBoolNoDefault, "bool", []string{"true"}},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetNullableStrategy(NullableSQLNull, nil)
+		testcase.column.SetNullableBoolMode(testcase.mode)
+		f := testcase.column.ToField(testcase.nullable, testcase.coverable, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("nullable bool mode %d type expect: %s, got: %s", testcase.mode, testcase.expectedType, f.Type)
+		}
+		if got := f.GORMTag[field.TagKeyGormDefault]; !reflect.DeepEqual(got, testcase.expectedDefault) {
+			t.Errorf("nullable bool mode %d default tag expect: %v, got: %v", testcase.mode, testcase.expectedDefault, got)
+		}
+	}
+}
+
+func TestColumn_SkipZeroDefault(t *testing.T) {
+	boolType, intType, floatType := reflect.TypeOf(false), reflect.TypeOf(int32(0)), reflect.TypeOf(float64(0))
+	testcases := []struct {
+		column          *Column
+		expectedDefault []string
+	}{
+		{newColumn("enabled", "tinyint", "tinyint(1)", withScanType(boolType), withDefault("0")), nil},
+		{newColumn("enabled", "boolean", "boolean", withScanType(boolType), withDefault("false")), nil},
+		{newColumn("enabled", "bit", "bit(1)", withScanType(boolType), withDefault("b'0'")), nil},
+		{newColumn("enabled", "boolean", "boolean", withScanType(boolType), withDefault("true")), []string{"true"}},
+		{newColumn("age", "int", "int", withScanType(intType), withDefault("0")), nil},
+		{newColumn("age", "integer", "integer", withScanType(intType), withDefault("'0'::integer")), nil},
+		{newColumn("age", "int", "int", withScanType(intType), withDefault("18")), []string{"18"}},
+		{newColumn("price", "decimal", "decimal(10,2)", withScanType(floatType), withDefault("0.00")), nil},
+		{newColumn("price", "decimal", "decimal(10,2)", withScanType(floatType), withDefault("0.01")), []string{"0.01"}},
+		{newColumn("name", "varchar", "varchar(64)", withDefault("")), nil},
+		{newColumn("name", "varchar", "varchar(64)", withDefault("''::character varying")), nil},
+		{newColumn("name", "varchar", "varchar(64)", withDefault("'0'")), []string{"'0'"}},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetSkipZeroDefault(true)
+		f := testcase.column.ToField(false, false, false)
+		if got := f.GORMTag[field.TagKeyGormDefault]; !reflect.DeepEqual(got, testcase.expectedDefault) {
+			t.Errorf("column %s default tag expect: %v, got: %v", f.ColumnName, testcase.expectedDefault, got)
+		}
+	}
+}
+
+func TestColumn_EnumValues(t *testing.T) {
+	testcases := []struct {
+		column   *Column
+		expected []string
+	}{
+		{newColumn("status", "enum", "enum('pending','paid')"), []string{"pending", "paid"}},
+		{newColumn("status", "enum", "enum('it''s','a,b','')"), []string{"it's", "a,b", ""}},
+		{newColumn("status", "varchar", "varchar(16)"), nil},
+	}
+	for _, testcase := range testcases {
+		if got := testcase.column.EnumValues(); !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("enum values expect: %v, got: %v", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_EnumAnnotation(t *testing.T) {
+	comment := func(c string) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) { ct.CommentValue = sql.NullString{String: c, Valid: true} }
+	}
+	testcases := []struct {
+		column   *Column
+		expected []string
+		labels   map[string]string
+	}{
+		{newColumn("status", "tinyint", "tinyint", comment("state [[enum:1=Active, 2=Disabled]]")), []string{"1", "2"}, map[string]string{"1": "Active", "2": "Disabled"}},
+		{newColumn("level", "int", "int", comment("[[enum:0,1,-1=Low]]")), []string{"0", "1", "-1"}, map[string]string{"-1": "Low"}},
+		{newColumn("level", "int", "int", comment("[[enum:01,02]]")), nil, nil},
+		{newColumn("level", "int", "int", comment("[[enum:a=Active]]")), nil, nil},
+		{newColumn("level", "varchar", "varchar(8)", comment("[[enum:1=Active]]")), nil, nil},
+		{newColumn("tags", "set", "set('a','b')"), []string{"a", "b"}, nil},
+	}
+	for _, testcase := range testcases {
+		values, labels := testcase.column.EnumAnnotation()
+		if testcase.labels != nil && (!reflect.DeepEqual(values, testcase.expected) || !reflect.DeepEqual(labels, testcase.labels)) {
+			t.Errorf("column %s enum annotation expect: %v %v, got: %v %v", testcase.column.Name(), testcase.expected, testcase.labels, values, labels)
+		}
+		if got := testcase.column.EnumValues(); !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("column %s enum values expect: %v, got: %v", testcase.column.Name(), testcase.expected, got)
+		}
+	}
+
+	status := newColumn("status", "tinyint", "tinyint", comment("[[enum:1=Active,2=Disabled]]"))
+	status.SetEnumType(true)
+	f := status.ToField(true, false, false)
+	if f.Type != "*UsersStatus" || f.Tag[field.TagKeyBinding] != "" {
+		t.Errorf("annotated enum field expect type *UsersStatus without binding, got: %s %q", f.Type, f.Tag[field.TagKeyBinding])
+	}
+}
+
+func TestColumn_EnumType(t *testing.T) {
+	testcases := []struct {
+		column   *Column
+		enumType bool
+		expected string
+	}{
+		{newColumn("status", "enum", "enum('active','banned')"), true, "UsersStatus"},
+		{newColumn("status", "enum", "enum('active','banned')"), false, "string"},
+		{newColumn("status", "varchar", "varchar(16)"), true, "string"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetEnumType(testcase.enumType)
+		if got := testcase.column.GetDataType(); got != testcase.expected {
+			t.Errorf("enum type expect: %s, got: %s", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_TypeTagOverride(t *testing.T) {
+	overrides := map[string]string{"users.bio": "text", "users.tags": ""}
+	testcases := []struct {
+		column   *Column
+		expected []string
+	}{
+		{newColumn("bio", "varchar", "varchar(1024)"), []string{"text"}},
+		{newColumn("tags", "_text", "text[]"), nil},
+		{newColumn("name", "varchar", "varchar(64)"), []string{"varchar(64)"}},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetColumnTypeTagOverride(overrides)
+		f := testcase.column.ToField(false, false, false)
+		if got := f.GORMTag[field.TagKeyGormType]; !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("column %s type tag expect: %v, got: %v", f.ColumnName, testcase.expected, got)
+		}
+		if f.Type != "string" {
+			t.Errorf("column %s Go type should not be affected, got: %s", f.ColumnName, f.Type)
+		}
+	}
+}
+
+func TestColumn_UnsignedDecimal(t *testing.T) {
+	testcases := []struct {
+		column          *Column
+		typ             string
+		mapped          string
+		expectedType    string
+		expectedBinding string
+	}{
+		{newColumn("price", "decimal", "decimal(10,2) unsigned"), "", "", "*float64", "gte=0"},
+		{newColumn("price", "decimal", "decimal(10,2) unsigned"), "types.UDecimal", "", "*types.UDecimal", ""},
+		{newColumn("price", "decimal", "decimal(10,2) unsigned"), "", "decimal.Decimal", "*decimal.Decimal", ""},
+		{newColumn("price", "decimal", "decimal(10,2)"), "", "", "*float64", ""},
+	}
+	for _, testcase := range testcases {
+		if testcase.mapped != "" {
+			mapped := testcase.mapped
+			testcase.column.SetDataTypeMap(map[string]func(gorm.ColumnType) string{"decimal": func(gorm.ColumnType) string { return mapped }})
+		}
+		testcase.column.SetUnsignedDecimal(true, testcase.typ)
+		f := testcase.column.ToField(true, false, true)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", f.ColumnName, testcase.expectedType, f.Type)
+		}
+		if f.Tag[field.TagKeyBinding] != testcase.expectedBinding {
+			t.Errorf("column %s binding expect: %q, got: %q", f.ColumnName, testcase.expectedBinding, f.Tag[field.TagKeyBinding])
+		}
+		if typeTag := f.GORMTag[field.TagKeyGormType]; len(typeTag) == 0 || typeTag[0] != testcase.column.columnType() {
+			t.Errorf("column %s type tag should be kept, got: %v", f.ColumnName, typeTag)
+		}
+	}
+}
+
+func TestMergeIndexes(t *testing.T) {
+	dbIndex := func(name string, columns ...string) gorm.Index {
+		return migrator.Index{TableName: "orders", NameValue: name, ColumnList: columns, PrimaryKeyValue: sql.NullBool{Valid: true}}
+	}
+	tenant := (&CompositeIndex{IndexName: "tenant", ColumnList: []string{"tenant_id", "created_at"}}).ApplyTo("orders", []string{"id", "tenant_id", "created_at"})
+	testcases := []struct {
+		dbIndexes  []gorm.Index
+		precedence IndexPrecedence
+		expected   []string
+	}{
+		{nil, IndexPrecedenceDB, []string{"tenant"}},
+		{[]gorm.Index{dbIndex("idx_status", "status")}, IndexPrecedenceDB, []string{"idx_status", "tenant"}},
+		{[]gorm.Index{dbIndex("idx_tenant_time", "tenant_id", "created_at")}, IndexPrecedenceDB, []string{"idx_tenant_time"}},
+		{[]gorm.Index{dbIndex("idx_orders_tenant", "tenant_id")}, IndexPrecedenceDB, []string{"idx_orders_tenant"}},
+		{[]gorm.Index{dbIndex("idx_tenant_time", "tenant_id", "created_at")}, IndexPrecedenceConfig, []string{"tenant"}},
+	}
+	for _, testcase := range testcases {
+		var got []string
+		for _, idx := range MergeIndexes(testcase.dbIndexes, []*CompositeIndex{tenant}, testcase.precedence) {
+			got = append(got, idx.Name())
+		}
+		if !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("merged indexes expect: %v, got: %v", testcase.expected, got)
+		}
+	}
+
+	if idx := (&CompositeIndex{IndexName: "tenant", ColumnList: []string{"tenant_id"}, TableNames: []string{"users"}}).ApplyTo("orders", []string{"tenant_id"}); idx != nil {
+		t.Errorf("composite index should not apply to table not declared, got: %+v", idx)
+	}
+
+	col := newColumn("created_at", "datetime", "datetime")
+	col.Indexes = GroupByColumn([]gorm.Index{tenant})["created_at"]
+	if got := col.ToField(false, false, false).GORMTag[field.TagKeyGormIndex]; !reflect.DeepEqual(got, []string{",composite:tenant,priority:2"}) {
+		t.Errorf("composite index tag expect: %v, got: %v", []string{",composite:tenant,priority:2"}, got)
+	}
+}
+
+func TestIndexDefs(t *testing.T) {
+	dbIndex := func(name string, primaryKey, unique bool, columns ...string) gorm.Index {
+		return migrator.Index{TableName: "users", NameValue: name, ColumnList: columns,
+			PrimaryKeyValue: sql.NullBool{Bool: primaryKey, Valid: true}, UniqueValue: sql.NullBool{Bool: unique, Valid: true}}
+	}
+	im := GroupByColumn([]gorm.Index{
+		dbIndex("PRIMARY", true, true, "id"),
+		dbIndex("idx_tenant_name", false, true, "tenant_id", "name"),
+		dbIndex("idx_name", false, false, "name"),
+	})
+	var columns []*Column
+	for _, name := range []string{"id", "name", "tenant_id", "age"} { // column order differs from index priority
+		col := newColumn(name, "varchar", "varchar(64)")
+		col.Indexes = im[name]
+		columns = append(columns, col)
+	}
+	expected := []field.IndexDef{
+		{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: field.IndexTypePrimaryKey},
+		{Name: "idx_tenant_name", Columns: []string{"tenant_id", "name"}, Unique: true, Type: field.IndexTypeUnique},
+		{Name: "idx_name", Columns: []string{"name"}, Type: field.IndexTypeIndex},
+	}
+	if got := IndexDefs(columns); !reflect.DeepEqual(got, expected) {
+		t.Errorf("index defs expect: %+v, got: %+v", expected, got)
+	}
+}
+
+func TestNormalizeIndexPriorities(t *testing.T) {
+	index := func(name string, priority int32) *Index {
+		return &Index{Index: migrator.Index{TableName: "orders", NameValue: name}, Priority: priority}
+	}
+	testcases := []struct {
+		indexes  map[string][]*Index
+		expected map[string][]int32
+	}{
+		{
+			map[string][]*Index{"tenant_id": {index("idx_tenant", 0), index("idx_id", 0)}, "created_at": {index("idx_tenant", 1)}},
+			map[string][]int32{"tenant_id": {1, 1}, "created_at": {2}},
+		},
+		{
+			map[string][]*Index{"tenant_id": {index("idx_tenant", 1), index("idx_id", 1)}, "created_at": {index("idx_tenant", 2)}},
+			map[string][]int32{"tenant_id": {1, 1}, "created_at": {2}},
+		},
+	}
+	for _, testcase := range testcases {
+		NormalizeIndexPriorities(testcase.indexes)
+		got := make(map[string][]int32, len(testcase.indexes))
+		for col, indexes := range testcase.indexes {
+			for _, idx := range indexes {
+				got[col] = append(got[col], idx.Priority)
+			}
+		}
+		if !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("normalized index priorities expect: %v, got: %v", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_UnsignedAutoIncrement(t *testing.T) {
+	pk := func(autoIncrement bool) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) {
+			ct.PrimaryKeyValue = sql.NullBool{Bool: true, Valid: true}
+			ct.AutoIncrementValue = sql.NullBool{Bool: autoIncrement, Valid: true}
+			ct.NullableValue = sql.NullBool{Bool: false, Valid: true}
+		}
+	}
+	testcases := []struct {
+		column   *Column
+		on       bool
+		expected string
+	}{
+		{newColumn("id", "bigint", "bigint", pk(true)), true, "uint64"},
+		{newColumn("id", "int", "int", pk(true)), true, "uint32"},
+		{newColumn("id", "bigint", "bigint unsigned", pk(true)), true, "uint64"},
+		{newColumn("id", "bigint", "bigint", pk(true)), false, "int64"},
+		{newColumn("id", "bigint", "bigint", pk(false)), true, "int64"},
+		{newColumn("id", "varchar", "varchar(36)", pk(true)), true, "string"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetUnsignedAutoIncrement(testcase.on)
+		if f := testcase.column.ToField(false, false, true); f.Type != testcase.expected {
+			t.Errorf("column %s type expect: %s, got: %s", testcase.column.columnType(), testcase.expected, f.Type)
+		}
+	}
+}
+
+func TestColumn_TagNS(t *testing.T) {
+	tagNS := map[string]func(string) string{
+		"redis": func(c string) string {
+			if c == "password" {
+				return "-"
+			}
+			return c
+		},
+		"yaml": func(c string) string { return "" },
+	}
+	testcases := []struct {
+		column   *Column
+		expected string
+	}{
+		{newColumn("user_name", "varchar", "varchar(64)"), `json:"user_name" redis:"user_name"`},
+		{newColumn("password", "varchar", "varchar(64)"), `json:"password" redis:"-"`},
+	}
+	for _, testcase := range testcases {
+		testcase.column.WithTagNS(tagNS)
+		if got := testcase.column.ToField(false, false, false).Tag.Build(); got != testcase.expected {
+			t.Errorf("tag expect: %s, got: %s", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_SetTagNS(t *testing.T) {
+	shared := map[string]func(string) string{"redis": func(c string) string { return c }}
+	col := newColumn("user_name", "varchar", "varchar(64)")
+	col.WithTagNS(shared)
+	col.SetTagNS("xml", func(c string) string { return c })
+	col.SetTagNS("form", func(c string) string { return "-" })
+	col.SetTagNS("mapstructure", func(c string) string { return c })
+	col.SetTagNS("redis", nil)
+
+	expected := `json:"user_name" form:"-" mapstructure:"user_name" xml:"user_name"`
+	for i := 0; i < 10; i++ { // map iteration must not affect tag order
+		if got := col.ToField(false, false, false).Tag.Build(); got != expected {
+			t.Fatalf("tag expect: %s, got: %s", expected, got)
+		}
+	}
+	if len(shared) != 1 {
+		t.Errorf("shared tag name strategies should not be modified, got: %d", len(shared))
+	}
+
+	other := newColumn("user_name", "varchar", "varchar(64)")
+	if got := other.ToField(false, false, false).Tag.Build(); got != `json:"user_name"` {
+		t.Errorf("tag without name strategies expect: %s, got: %s", `json:"user_name"`, got)
+	}
+}
+
+func TestColumn_UnixTime(t *testing.T) {
+	rules := []UnixTimeRule{{Pattern: "*_time", Precision: UnixTimeMilli}, {Pattern: "*_at", Precision: UnixTimeSecond}}
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column             *Column
+		expectedType       string
+		expectedSerializer []string
+	}{
+		{newColumn("login_time", "bigint", "bigint", notNull), "time.Time", []string{"unixmilli"}},
+		{newColumn("created_at", "int", "int", notNull), "time.Time", []string{"unixtime"}},
+		{newColumn("expired_at", "int", "int"), "*time.Time", []string{"unixtime"}},
+		{newColumn("deleted_at", "int", "int"), "*time.Time", []string{"unixtime"}},
+		{newColumn("updated_at", "datetime", "datetime", notNull), "time.Time", nil},
+		{newColumn("retry_count", "int", "int", notNull), "int32", nil},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetUnixTimeRules(rules)
+		f := testcase.column.ToField(true, false, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", f.ColumnName, testcase.expectedType, f.Type)
+		}
+		if got := f.GORMTag[field.TagKeyGormSerializer]; !reflect.DeepEqual(got, testcase.expectedSerializer) {
+			t.Errorf("column %s serializer expect: %v, got: %v", f.ColumnName, testcase.expectedSerializer, got)
+		}
+	}
+}
+
+func TestColumn_XMLType(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column             *Column
+		typ                XMLType
+		structType         string
+		expectedType       string
+		expectedSerializer []string
+	}{
+		{newColumn("doc", "xml", "xml", notNull), XMLTypeAsIs, "", "string", nil},
+		{newColumn("doc", "xml", "xml", notNull), XMLTypeBytes, "", "[]byte", nil},
+		{newColumn("doc", "xml", "xml", notNull), XMLTypeBytes, "types.Invoice", "types.Invoice", []string{"xml"}},
+		{newColumn("doc", "XMLTYPE", "XMLTYPE"), XMLTypeAsIs, "types.Invoice", "*types.Invoice", []string{"xml"}},
+		{newColumn("name", "varchar", "varchar(64)", notNull), XMLTypeBytes, "types.Invoice", "string", nil},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetXMLType(testcase.typ, testcase.structType)
+		f := testcase.column.ToField(true, false, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", f.ColumnName, testcase.expectedType, f.Type)
+		}
+		if got := f.GORMTag[field.TagKeyGormSerializer]; !reflect.DeepEqual(got, testcase.expectedSerializer) {
+			t.Errorf("column %s serializer expect: %v, got: %v", f.ColumnName, testcase.expectedSerializer, got)
+		}
+	}
+}
+
+func TestColumn_YesNo(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column             *Column
+		columns            []string
+		expectedType       string
+		expectedSerializer []string
+	}{
+		{newColumn("active", "char", "char(1)", notNull), nil, "string", nil},
+		{newColumn("active", "char", "char(1)", notNull), []string{"*.*"}, "bool", []string{"yesno"}},
+		{newColumn("active", "bpchar", "bpchar(1)"), []string{"users.active"}, "*bool", []string{"yesno"}},
+		{newColumn("active", "char", "char(2)", notNull), []string{"*.*"}, "string", nil},
+		{newColumn("active", "varchar", "varchar(1)", notNull), []string{"*.*"}, "string", nil},
+		{newColumn("code", "char", "char(1)", notNull), []string{"*.active"}, "string", nil},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetYesNoColumns(testcase.columns)
+		f := testcase.column.ToField(true, false, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", f.ColumnName, testcase.expectedType, f.Type)
+		}
+		if got := f.GORMTag[field.TagKeyGormSerializer]; !reflect.DeepEqual(got, testcase.expectedSerializer) {
+			t.Errorf("column %s serializer expect: %v, got: %v", f.ColumnName, testcase.expectedSerializer, got)
+		}
+	}
+}
+
+func TestColumn_JSONStructType(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	types := map[string]string{"users.address": "types.Address", "users.profile": "*types.Profile"}
+	testcases := []struct {
+		column       *Column
+		nullable     bool
+		jsonType     JSONType
+		expectedType string
+	}{
+		{newColumn("address", "json", "json"), true, JSONTypeAsIs, "*types.Address"},
+		{newColumn("address", "jsonb", "jsonb", notNull), true, JSONTypeAsIs, "types.Address"},
+		{newColumn("address", "json", "json"), false, JSONTypeAsIs, "types.Address"},
+		{newColumn("address", "json", "json"), true, JSONTypeMap, "*types.Address"},
+		{newColumn("profile", "json", "json"), true, JSONTypeRaw, "*types.Profile"},
+		{newColumn("profile", "json", "json", notNull), true, JSONTypeAsIs, "types.Profile"},
+		{newColumn("extra", "json", "json"), true, JSONTypeMap, "map[string]interface{}"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetJSONType(testcase.jsonType, nil)
+		testcase.column.SetJSONStructTypes(types)
+		f := testcase.column.ToField(testcase.nullable, false, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", f.ColumnName, testcase.expectedType, f.Type)
+		}
+		if got := f.GORMTag[field.TagKeyGormSerializer]; !reflect.DeepEqual(got, []string{"json"}) {
+			t.Errorf("column %s serializer expect: [json], got: %v", f.ColumnName, got)
+		}
+	}
+}
+
+func TestColumn_ArrayType(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column             *Column
+		arrayType          ArrayType
+		expectedType       string
+		expectedSerializer []string
+	}{
+		{newColumn("tags", "_text", "text[]"), ArrayTypeAsIs, "*string", nil},
+		{newColumn("tags", "_text", "text[]"), ArrayTypePQ, "pq.StringArray", nil},
+		{newColumn("tags", "_text", "text[]"), ArrayTypeJSON, "[]string", []string{"json"}},
+		{newColumn("scores", "_int8", "bigint[]", notNull), ArrayTypePQ, "pq.Int64Array", nil},
+		{newColumn("scores", "_int8", "bigint[]", notNull), ArrayTypeJSON, "[]int64", []string{"json"}},
+		{newColumn("names", "ARRAY", "character varying(64)[]"), ArrayTypePQ, "pq.StringArray", nil},
+		{newColumn("names", "ARRAY", "character varying(64)[]"), ArrayTypeJSON, "[]string", []string{"json"}},
+		{newColumn("flags", "_bool", "boolean[]"), ArrayTypeJSON, "[]bool", []string{"json"}},
+		{newColumn("matrix", "ARRAY", "integer[][]"), ArrayTypePQ, "*string", nil},
+		{newColumn("points", "_point", "point[]"), ArrayTypeJSON, "*string", nil},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetArrayType(testcase.arrayType)
+		f := testcase.column.ToField(true, false, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s %s type expect: %s, got: %s", f.ColumnName, testcase.column.columnType(), testcase.expectedType, f.Type)
+		}
+		if got := f.GORMTag[field.TagKeyGormSerializer]; !reflect.DeepEqual(got, testcase.expectedSerializer) {
+			t.Errorf("column %s serializer expect: %v, got: %v", f.ColumnName, testcase.expectedSerializer, got)
+		}
+	}
+}
+
+func TestColumn_CaseSensitiveColumnTag(t *testing.T) {
+	newPostgresColumn := func(name string) *Column {
+		col := newColumn(name, "int4", "integer")
+		col.Dialect = "postgres"
+		return col
+	}
+	testcases := []struct {
+		column   *Column
+		override string
+		expected string
+	}{
+		{newPostgresColumn("userID"), "", "userID"},
+		{newPostgresColumn("CreatedAt"), "", "CreatedAt"},
+		{newPostgresColumn("user id"), "", "user id"},
+		{newPostgresColumn("order;line"), "", "order;line"},
+		{newPostgresColumn(`say"hi"`), "", `say"hi"`},
+		{newPostgresColumn("userid"), "userID", "userID"},
+		{newPostgresColumn("Order"), "", "Order"}, // mixed-case reserved word
+		{newPostgresColumn(`"Select"`), "", "Select"},
+		{newPostgresColumn("select"), `"Select"`, "Select"},
+		{newPostgresColumn("[Group]"), "", "Group"},
+		{newPostgresColumn(`"say""hi"""`), "", `say"hi"`},
+		{newPostgresColumn("`Order`"), "", "Order"},
+	}
+	for _, testcase := range testcases {
+		if testcase.override != "" {
+			testcase.column.SetColumnNameOverride(map[string]string{testcase.column.key(): testcase.override})
+		}
+		f := testcase.column.ToField(false, false, false)
+		gormTag, ok := reflect.StructTag(f.Tags()).Lookup(field.TagKeyGorm)
+		if !ok {
+			t.Errorf("column %q tag is invalid struct tag: %s", f.ColumnName, f.Tags())
+			continue
+		}
+		if got := schema.ParseTagSetting(gormTag, ";")["COLUMN"]; got != testcase.expected {
+			t.Errorf("column %q gorm column expect: %q, got: %q", f.ColumnName, testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_NetTypeDefault(t *testing.T) {
+	testcases := []struct {
+		column          *Column
+		netType         bool
+		expectedDefault []string
+	}{
+		{newColumn("allow", "cidr", "cidr", withDefault("'0.0.0.0/0'::cidr")), true, []string{"'0.0.0.0/0'"}},
+		{newColumn("ip", "inet", "inet", withDefault("'127.0.0.1'::inet")), true, []string{"'127.0.0.1'"}},
+		{newColumn("mac", "macaddr", "macaddr", withDefault("'08:00:2b:01:02:03'::macaddr")), true, []string{"'08:00:2b:01:02:03'"}},
+		{newColumn("ip", "inet", "inet", withDefault("NULL::inet")), true, nil},
+		{newColumn("ip", "inet", "inet", withDefault("inet_client_addr()")), true, []string{"(-)"}},
+		{newColumn("ip", "inet", "inet"), true, nil},
+		{newColumn("allow", "cidr", "cidr", withDefault("'0.0.0.0/0'::cidr")), false, []string{"'0.0.0.0/0'::cidr"}},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetNetType(testcase.netType)
+		f := testcase.column.ToField(true, false, false)
+		if f.Type != "*string" {
+			t.Errorf("column %s type expect: *string, got: %s", f.ColumnName, f.Type)
+		}
+		if got := f.GORMTag[field.TagKeyGormDefault]; !reflect.DeepEqual(got, testcase.expectedDefault) {
+			t.Errorf("column %s default expect: %v, got: %v", f.ColumnName, testcase.expectedDefault, got)
+		}
+	}
+}
+
+func TestColumn_PointerPolicy(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	types := map[string]PointerPolicy{
+		"string":         PointerPolicyValue,
+		"time.Time":      PointerPolicyNullable,
+		TypeFamilyNumber: PointerPolicyNullable,
+		"int64":          PointerPolicyAlways,
+		TypeFamilyCustom: PointerPolicyAlways,
+	}
+	columns := map[string]PointerPolicy{"users.nickname": PointerPolicyNullable, "users.age": PointerPolicyDefault}
+	testcases := []struct {
+		column       *Column
+		nullable     bool
+		expectedType string
+	}{
+		{newColumn("name", "varchar", "varchar(64)"), true, "string"},
+		{newColumn("nickname", "varchar", "varchar(64)"), true, "*string"},
+		{newColumn("born_at", "timestamp", "timestamp"), false, "*time.Time"},
+		{newColumn("born_at", "timestamp", "timestamp", notNull), true, "time.Time"},
+		{newColumn("score", "int", "int"), false, "*int32"},
+		{newColumn("age", "int", "int"), false, "int32"},
+		{newColumn("age", "int", "int"), true, "*int32"},
+		{newColumn("views", "bigint", "bigint", notNull), false, "*int64"},
+		{newColumn("meta", "json", "json", notNull), false, "*datatypes.JSON"},
+		{newColumn("active", "boolean", "boolean"), true, "*bool"},
+		{newColumn("active", "boolean", "boolean"), false, "bool"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetJSONType(JSONTypeRaw, nil)
+		testcase.column.SetPointerPolicy(types, columns)
+		f := testcase.column.ToField(testcase.nullable, false, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", f.ColumnName, testcase.expectedType, f.Type)
+		}
+	}
+}
+
+func TestColumn_NumericBool(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column       *Column
+		names        []string
+		expectedType string
+	}{
+		{newColumn("is_active", "numeric", "numeric(1,0)", notNull), []string{"*"}, "bool"},
+		{newColumn("retry", "numeric", "numeric(1,0)", notNull), []string{"*"}, "bool"},
+		{newColumn("is_active", "NUMBER", "NUMBER(1,0)"), []string{"*"}, "*bool"},
+		{newColumn("is_active", "NUMBER", "NUMBER(1)", notNull), []string{"is_*", "has_*"}, "bool"},
+		{newColumn("has_child", "numeric", "numeric(1, 0)", notNull), []string{"is_*", "has_*"}, "bool"},
+		{newColumn("retry", "numeric", "numeric(1,0)", notNull), []string{"is_*", "has_*"}, "int32"},
+		{newColumn("is_active", "numeric", "numeric(2,0)", notNull), []string{"*"}, "int32"},
+		{newColumn("is_active", "numeric", "numeric(1,1)", notNull), []string{"*"}, "int32"},
+		{newColumn("is_active", "numeric", "numeric(1,0)", notNull), nil, "int32"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetNumericBool(testcase.names)
+		f := testcase.column.ToField(true, false, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s %s with names %v type expect: %s, got: %s",
+				f.ColumnName, testcase.column.columnType(), testcase.names, testcase.expectedType, f.Type)
+		}
+	}
+}
+
+func TestColumn_BigIntDecimal(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column             *Column
+		typ                string
+		expectedType       string
+		expectedSerializer []string
+	}{
+		{newColumn("balance", "decimal", "decimal(38,0)", notNull), "", "*big.Int", []string{"bigint"}},
+		{newColumn("balance", "numeric", "numeric"), "", "*big.Int", []string{"bigint"}},
+		{newColumn("balance", "numeric", "numeric(20)", notNull), "decimal.Decimal", "decimal.Decimal", nil},
+		{newColumn("balance", "decimal", "decimal(38,0)"), "decimal.Decimal", "*decimal.Decimal", nil},
+		{newColumn("balance", "decimal", "decimal(18,0)", notNull), "", "float64", nil},
+		{newColumn("balance", "decimal", "decimal(38,2)", notNull), "", "float64", nil},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetBigIntDecimal(true, testcase.typ)
+		f := testcase.column.ToField(true, false, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", testcase.column.columnType(), testcase.expectedType, f.Type)
+		}
+		if got := f.GORMTag[field.TagKeyGormSerializer]; !reflect.DeepEqual(got, testcase.expectedSerializer) {
+			t.Errorf("column %s serializer expect: %v, got: %v", testcase.column.columnType(), testcase.expectedSerializer, got)
+		}
+	}
+
+	col := newColumn("balance", "decimal", "decimal(38,0)", notNull)
+	if f := col.ToField(true, false, false); f.Type != "float64" {
+		t.Errorf("column without big int decimal type expect: float64, got: %s", f.Type)
+	}
+}
+
+func TestShortenIndexNames(t *testing.T) {
+	index := func(name string) gorm.Index { return migrator.Index{NameValue: name} }
+	long1 := "idx_order_items_tenant_id_warehouse_id_created_at"
+	long2 := "idx_order_items_tenant_id_warehouse_id_updated_at"
+	names := ShortenIndexNames([]gorm.Index{index("idx_short"), index(long1), index(long2)}, 30)
+
+	if _, ok := names["idx_short"]; ok {
+		t.Errorf("short index name should not be shortened")
+	}
+	if len(names[long1]) != 30 || len(names[long2]) != 30 {
+		t.Errorf("shortened index name length expect: 30, got: %q %q", names[long1], names[long2])
+	}
+	if names[long1] == names[long2] {
+		t.Errorf("shortened index names should be unique, got: %q", names[long1])
+	}
+	if again := ShortenIndexNames([]gorm.Index{index(long2), index(long1)}, 30); !reflect.DeepEqual(again, names) {
+		t.Errorf("shortened index names should be deterministic, expect: %v, got: %v", names, again)
+	}
+	if got := ShortenIndexNames([]gorm.Index{index(long1)}, 0); len(got) != 0 {
+		t.Errorf("index name should not be shortened without limit, got: %v", got)
+	}
+}
+
+func TestColumn_EnumDefault(t *testing.T) {
+	const columnType = "enum('pending','paid','it''s','2')"
+	testcases := []struct {
+		defaultValue string
+		expected     []string
+	}{
+		{"pending", []string{"'pending'"}},
+		{"'paid'", []string{"'paid'"}},
+		{"2", []string{"'2'"}},
+		{"1", []string{"'pending'"}},
+		{"3", []string{"'it''s'"}},
+		{"0", nil},
+		{"5", nil},
+		{"unknown", nil},
+	}
+	for _, testcase := range testcases {
+		col := newColumn("status", "enum", columnType, withDefault(testcase.defaultValue))
+		col.SetEnumDefault(true)
+		if got := col.ToField(false, false, false).GORMTag[field.TagKeyGormDefault]; !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("enum default %s expect: %v, got: %v", testcase.defaultValue, testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_BoolScanType(t *testing.T) {
+	testcases := []struct {
+		column   *Column
+		on       bool
+		expected string
+	}{
+		{newColumn("enabled", "tinyint", "tinyint(4)", withScanType(reflect.TypeOf(false))), true, "bool"},
+		{newColumn("enabled", "tinyint", "tinyint", withScanType(reflect.TypeOf(sql.NullBool{}))), true, "bool"},
+		{newColumn("enabled", "bit", "bit(1)", withScanType(reflect.TypeOf(sql.NullBool{}))), true, "bool"},
+		{newColumn("retry", "tinyint", "tinyint", withScanType(reflect.TypeOf(int8(0)))), true, "int32"},
+		{newColumn("retry", "tinyint", "tinyint", withScanType(reflect.TypeOf(sql.NullInt64{}))), true, "int32"},
+		{newColumn("enabled", "tinyint", "tinyint(4)", withScanType(reflect.TypeOf(false))), false, "int32"},
+		{newColumn("enabled", "bit", "bit(1)", withScanType(reflect.TypeOf(sql.NullBool{}))), false, "[]uint8"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetBoolScanType(testcase.on)
+		if got := testcase.column.GetDataType(); got != testcase.expected {
+			t.Errorf("column %s(scan type %s) type expect: %s, got: %s", testcase.column.Name(), testcase.column.ScanType(), testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_ReadOnly(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	generated := func(c *Column) *Column { c.Generated = true; return c }
+	testcases := []struct {
+		column      *Column
+		expectedTag string
+	}{
+		{generated(newColumn("total", "int", "int", notNull)), "column:total;type:int;->"},
+		{newColumn("search_vector", "varchar", "varchar(255)", notNull, withDefault("''")), "column:search_vector;type:varchar(255);->"},
+		{newColumn("name", "varchar", "varchar(64)", notNull, withDefault("''")), "column:name;type:varchar(64);not null;default:''"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetReadOnly(true, []string{"*.search_vector"})
+		if got := testcase.column.ToField(false, false, false).GORMTag.Build(); got != testcase.expectedTag {
+			t.Errorf("column %s gorm tag expect: %s, got: %s", testcase.column.Name(), testcase.expectedTag, got)
+		}
+	}
+}
+
+func TestColumn_FullTextSearch(t *testing.T) {
+	generated := func(c *Column) *Column { c.Generated = true; return c }
+	testcases := []struct {
+		column      *Column
+		readOnly    bool
+		expectedTag string
+	}{
+		{newColumn("search", "tsvector", "tsvector"), true, "column:search;type:tsvector;->"},
+		{newColumn("search", "tsvector", "tsvector"), false, "column:search;type:tsvector"},
+		{newColumn("search", "TSVECTOR", "tsvector"), true, "column:search;type:tsvector;->"},
+		{newColumn("query", "tsquery", "tsquery"), true, "column:query;type:tsquery"},
+		{generated(newColumn("search", "tsvector", "tsvector")), true, "column:search;type:tsvector;->"}, // detected read-only once
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetFullTextReadOnly(testcase.readOnly)
+		testcase.column.SetReadOnly(true, nil)
+		if !testcase.column.IsFullTextSearch() {
+			t.Errorf("column %s expect full text search type", testcase.column.Name())
+		}
+		f := testcase.column.ToField(false, false, false)
+		if f.Type != "string" {
+			t.Errorf("column %s type expect: string, got: %s", testcase.column.Name(), f.Type)
+		}
+		if got := f.GORMTag.Build(); got != testcase.expectedTag {
+			t.Errorf("column %s(read-only: %t) gorm tag expect: %s, got: %s", testcase.column.Name(), testcase.readOnly, testcase.expectedTag, got)
+		}
+	}
+	if newColumn("body", "text", "text").IsFullTextSearch() {
+		t.Errorf("text column expect not full text search type")
+	}
+}
+
+func TestColumn_UUIDBinding(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	comment := func(c string) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) { ct.CommentValue = sql.NullString{String: c, Valid: true} }
+	}
+	testcases := []struct {
+		column   *Column
+		expected string
+	}{
+		{newColumn("id", "uuid", "uuid", notNull), "uuid4"},
+		{newColumn("ref_id", "char", "char(36)"), "omitempty,uuid4"},
+		{newColumn("ref_id", "char", "char(36)", notNull, comment("[[required,uuid]]")), "required,uuid"},
+		{newColumn("ref_id", "char", "char(36)", notNull, comment("[[required]]")), "required,uuid4"},
+		{newColumn("code", "char", "char(32)", notNull), ""},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetUUIDBinding("uuid4")
+		if got := testcase.column.ToField(true, false, false).Tag[field.TagKeyBinding]; got != testcase.expected {
+			t.Errorf("column %s binding expect: %q, got: %q", testcase.column.Name(), testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_TypeTagDialect(t *testing.T) {
+	testcases := []struct {
+		column   *Column
+		expected string
+		ok       bool
+	}{
+		{newColumn("age", "tinyint", "tinyint(4)"), "smallint", true},
+		{newColumn("enabled", "tinyint", "tinyint(1)"), "boolean", true},
+		{newColumn("id", "bigint", "bigint unsigned"), "bigint", true},
+		{newColumn("created_at", "datetime", "datetime(6)"), "timestamp(6)", true},
+		{newColumn("updated_at", "datetime", "datetime"), "timestamp", true},
+		{newColumn("bio", "longtext", "longtext"), "text", true},
+		{newColumn("name", "varchar", "varchar(64)"), "varchar(64)", true},
+		{newColumn("price", "decimal", "decimal(10,2) unsigned"), "numeric(10,2)", true},
+		{newColumn("status", "enum", "enum('a','b')"), "enum('a','b')", false},
+	}
+	for _, testcase := range testcases {
+		testcase.column.Dialect = "mysql"
+		testcase.column.SetTypeTagDialect("postgres")
+		if got, ok := testcase.column.TypeTag(); got != testcase.expected || ok != testcase.ok {
+			t.Errorf("column %s type tag expect: %s(%t), got: %s(%t)", testcase.column.Name(), testcase.expected, testcase.ok, got, ok)
+		}
+	}
+}
+
+func TestColumn_TimePrecisionTag(t *testing.T) {
+	testcases := []struct {
+		column            *Column
+		expectedType      []string
+		expectedPrecision []string
+	}{
+		{newColumn("created_at", "datetime", "datetime(6)"), []string{"datetime"}, []string{"6"}},
+		{newColumn("updated_at", "timestamp", "timestamp"), []string{"timestamp"}, nil},
+		{newColumn("start_time", "time", "time(3)"), []string{"time"}, []string{"3"}},
+		{newColumn("logged_at", "timestamp", "timestamp(3) with time zone"), []string{"timestamp with time zone"}, []string{"3"}},
+		{newColumn("price", "decimal", "decimal(10,2)"), []string{"decimal(10,2)"}, nil},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetTimePrecisionTag(true)
+		tag := testcase.column.ToField(false, false, false).GORMTag
+		if got := tag[field.TagKeyGormType]; !reflect.DeepEqual(got, testcase.expectedType) {
+			t.Errorf("column %s type tag expect: %v, got: %v", testcase.column.Name(), testcase.expectedType, got)
+		}
+		if got := tag[field.TagKeyGormPrecision]; !reflect.DeepEqual(got, testcase.expectedPrecision) {
+			t.Errorf("column %s precision tag expect: %v, got: %v", testcase.column.Name(), testcase.expectedPrecision, got)
+		}
+	}
+}
+
+func TestColumn_FloatPrecisionTag(t *testing.T) {
+	float32Type, float64Type := withScanType(reflect.TypeOf(float32(0))), withScanType(reflect.TypeOf(float64(0)))
+	testcases := []struct {
+		column       *Column
+		expectedType string
+		expectedTag  string
+	}{
+		{newColumn("score", "float", "float", float32Type), "float32", "column:score;type:float"},
+		{newColumn("price", "float", "float(10,2)", float32Type), "float32", "column:price;type:float;precision:10;scale:2"},
+		{newColumn("rate", "double", "double(16,4)", float64Type), "float64", "column:rate;type:double;precision:16;scale:4"},
+		{newColumn("ratio", "double", "double(16,4) unsigned", float64Type), "float64", "column:ratio;type:double unsigned;precision:16;scale:4"},
+		{newColumn("weight", "float", "float(24)", float32Type), "float32", "column:weight;type:float;precision:24"},
+		{newColumn("amount", "decimal", "decimal(10,2)", float64Type), "float64", "column:amount;type:decimal(10,2)"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetFloatPrecisionTag(true)
+		f := testcase.column.ToField(false, false, false)
+		if got := strings.TrimPrefix(f.Type, "*"); got != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", f.ColumnName, testcase.expectedType, got)
+		}
+		if got := f.GORMTag.Build(); got != testcase.expectedTag {
+			t.Errorf("column %s gorm tag expect: %s, got: %s", f.ColumnName, testcase.expectedTag, got)
+		}
+	}
+}
+
+func TestColumn_TriggerColumns(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column      *Column
+		expectedTag string
+	}{
+		{newColumn("order_total", "int", "int", notNull), "column:order_total;type:int;->;<-:false"},
+		{newColumn("order_count", "int", "int", notNull, withDefault("0")), "column:order_count;type:int;not null;default:0;->;<-:false"},
+		{newColumn("name", "varchar", "varchar(64)", notNull), "column:name;type:varchar(64);not null"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetTriggerColumns([]string{"users.order_*"})
+		if got := testcase.column.ToField(false, false, false).GORMTag.Build(); got != testcase.expectedTag {
+			t.Errorf("column %s gorm tag expect: %s, got: %s", testcase.column.Name(), testcase.expectedTag, got)
+		}
+	}
+}
+
+func TestColumn_CommentSource(t *testing.T) {
+	source := func(table, column string) (string, bool) {
+		if table == "users" && column == "email" {
+			return "user email[[required,email]]", true
+		}
+		return "", false
+	}
+	withComment := func(ct *migrator.ColumnType) { ct.CommentValue = sql.NullString{String: "driver comment", Valid: true} }
+	testcases := []struct {
+		column          *Column
+		expectedComment string
+		expectedBinding string
+	}{
+		{newColumn("email", "varchar", "varchar(64)"), "user email", "required,email"},
+		{newColumn("email", "varchar", "varchar(64)", withComment), "driver comment", ""},
+		{newColumn("name", "varchar", "varchar(64)"), "", ""},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetCommentSource(source)
+		f := testcase.column.ToField(false, false, false)
+		if f.ColumnComment != testcase.expectedComment || f.Tag[field.TagKeyBinding] != testcase.expectedBinding {
+			t.Errorf("column %s comment/binding expect: %q/%q, got: %q/%q", f.ColumnName, testcase.expectedComment, testcase.expectedBinding, f.ColumnComment, f.Tag[field.TagKeyBinding])
+		}
+		if comment := f.GORMTag[field.TagKeyGormComment]; testcase.expectedComment != "" && (len(comment) == 0 || comment[0] != testcase.expectedComment) {
+			t.Errorf("column %s comment tag expect: %q, got: %v", f.ColumnName, testcase.expectedComment, comment)
+		}
+	}
+}
+
+func TestColumn_EmptyDefault(t *testing.T) {
+	modes := map[string]EmptyDefaultMode{
+		"users.quoted":   EmptyDefaultQuoted,
+		"users.unquoted": EmptyDefaultUnquoted,
+		"users.dropped":  EmptyDefaultDrop,
+	}
+	testcases := []struct {
+		column   *Column
+		expected string
+	}{
+		{newColumn("name", "varchar", "varchar(64)", withDefault("")), "column:name;type:varchar(64);default:''"},
+		{newColumn("quoted", "varchar", "varchar(64)", withDefault(" ")), "column:quoted;type:varchar(64);default:' '"},
+		{newColumn("unquoted", "varchar", "varchar(64)", withDefault("")), "column:unquoted;type:varchar(64);default"},
+		{newColumn("unquoted", "varchar", "varchar(64)", withDefault("  ")), "column:unquoted;type:varchar(64);default:  "},
+		{newColumn("dropped", "varchar", "varchar(64)", withDefault("")), "column:dropped;type:varchar(64)"},
+		{newColumn("dropped", "varchar", "varchar(64)", withDefault(" ")), "column:dropped;type:varchar(64)"},
+		{newColumn("dropped", "varchar", "varchar(64)", withDefault("'x'")), "column:dropped;type:varchar(64);default:'x'"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetEmptyDefaultModes(modes)
+		if got := testcase.column.ToField(false, false, false).GORMTag.Build(); got != testcase.expected {
+			t.Errorf("gorm tag expect: %q, got: %q", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_ExpressionDefault(t *testing.T) {
+	rawBytes := withScanType(reflect.TypeOf(sql.RawBytes{}))
+	testcases := []struct {
+		column   *Column
+		expected string
+	}{
+		{newColumn("id", "uuid", "uuid", withDefault("gen_random_uuid()")), "column:id;type:uuid;default:(-)"},
+		{newColumn("token", "varchar", "varchar(64)", withDefault("CURRENT_USER")), "column:token;type:varchar(64);default:(-)"},
+		{newColumn("created_at", "datetime", "datetime", withDefault("CURRENT_TIMESTAMP"), rawBytes), "column:created_at;type:datetime;default:(-)"},
+		{newColumn("updated_at", "datetime", "datetime", withDefault("current_timestamp()"), rawBytes), "column:updated_at;type:datetime;default:(-)"},
+		{newColumn("expired_at", "datetime", "datetime", withDefault("(getdate())"), rawBytes), "column:expired_at;type:datetime;default:(-)"},
+		{newColumn("name", "varchar", "varchar(64)", withDefault("'abc'")), "column:name;type:varchar(64);default:'abc'"},
+		{newColumn("note", "varchar", "varchar(64)", withDefault("'now()'")), "column:note;type:varchar(64);default:'now()'"},
+		{newColumn("age", "int", "int", withDefault("0"), withScanType(reflect.TypeOf(0))), "column:age;type:int;default:0"},
+		{newColumn("score", "int", "int", withDefault("((0))"), withScanType(reflect.TypeOf(0))), "column:score;type:int;default:((0))"},
+	}
+	for _, testcase := range testcases {
+		if got := testcase.column.ToField(false, false, false).GORMTag.Build(); got != testcase.expected {
+			t.Errorf("gorm tag expect: %q, got: %q", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_GormTagOrder(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	comment := func(ct *migrator.ColumnType) { ct.CommentValue = sql.NullString{String: "user name", Valid: true} }
+	testcases := []struct {
+		order    []string
+		expected string
+	}{
+		{nil, "column:name;type:varchar(64);not null;index:idx_name,priority:1;default:'x';comment:user name"},
+		{field.GormTagOrderV2, "column:name;type:varchar(64);default:'x';not null;index:idx_name,priority:1;comment:user name"},
+		{[]string{field.TagKeyGormComment, field.TagKeyGormDefault}, "comment:user name;default:'x';column:name;type:varchar(64);not null;index:idx_name,priority:1"},
+	}
+	for _, testcase := range testcases {
+		col := newColumn("name", "varchar", "varchar(64)", notNull, comment, withDefault("'x'"))
+		col.Indexes = []*Index{newIndex("idx_name", false, 1)}
+		col.SetGormTagOrder(testcase.order)
+		f := col.ToField(false, false, false)
+		if got := f.Tags(); got != `gorm:"`+testcase.expected+`" json:"name"` {
+			t.Errorf("gorm tag expect: %s, got: %s", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_NullableStrategy(t *testing.T) {
+	selector := func(table string, columnType gorm.ColumnType, goType string) (NullableStrategy, bool) {
+		if columnType.Name() == "nickname" {
+			return NullablePointer, true
+		}
+		return 0, false
+	}
+	testcases := []struct {
+		column   *Column
+		strategy NullableStrategy
+		expected string
+	}{
+		{newColumn("name", "varchar", "varchar(64)"), NullablePointer, "*string"},
+		{newColumn("name", "varchar", "varchar(64)"), NullableSQLNull, "sql.NullString"},
+		{newColumn("nickname", "varchar", "varchar(64)"), NullableSQLNull, "*string"},
+		{newColumn("age", "int", "int(11)", withScanType(reflect.TypeOf(int32(0)))), NullableSQLNull, "sql.NullInt32"},
+		{newColumn("created_at", "datetime", "datetime", withScanType(reflect.TypeOf(sql.NullTime{}))), NullableSQLNull, "sql.NullTime"},
+		// no sql.Null* equivalent falls back to pointer
+		{newColumn("score", "float", "float", withScanType(reflect.TypeOf(float32(0)))), NullableSQLNull, "*float32"},
+		{newColumn("data", "blob", "blob", withScanType(reflect.TypeOf([]byte(nil)))), NullableSQLNull, "*[]byte"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetNullableStrategy(testcase.strategy, selector)
+		if got := testcase.column.ToField(true, false, false).Type; got != testcase.expected {
+			t.Errorf("column %s type expect: %s, got: %s", testcase.column.Name(), testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_JSONDirective(t *testing.T) {
+	withComment := func(comment string) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) { ct.CommentValue = sql.NullString{String: comment, Valid: true} }
+	}
+	testcases := []struct {
+		column          *Column
+		directive       bool
+		expectedJSON    string
+		expectedComment string
+	}{
+		{newColumn("user_name", "varchar", "varchar(64)", withComment("name {{json:userName}}")), true, "userName", "name"},
+		{newColumn("password", "varchar", "varchar(64)", withComment("{{json:-}} hashed password")), true, "-", "hashed password"},
+		{newColumn("email", "varchar", "varchar(64)", withComment("email[[email]] {{json:mail}}")), true, "mail", "email"},
+		{newColumn("age", "int", "int", withComment("age {{size:3}}")), true, "age", "age {{size:3}}"},
+		{newColumn("user_name", "varchar", "varchar(64)", withComment("name {{json:userName}}")), false, "user_name", "name {{json:userName}}"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetJSONDirective(testcase.directive)
+		f := testcase.column.ToField(false, false, false)
+		if f.Tag[field.TagKeyJson] != testcase.expectedJSON || f.ColumnComment != testcase.expectedComment {
+			t.Errorf("column %s json/comment expect: %q/%q, got: %q/%q", f.ColumnName,
+				testcase.expectedJSON, testcase.expectedComment, f.Tag[field.TagKeyJson], f.ColumnComment)
+		}
+	}
+}
+
+func TestColumn_CheckDirective(t *testing.T) {
+	withComment := func(comment string) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) { ct.CommentValue = sql.NullString{String: comment, Valid: true} }
+	}
+	newDialectColumn := func(dialect, name, comment string) *Column {
+		col := newColumn(name, "varchar", "varchar(32)", withComment(comment))
+		col.Dialect = dialect
+		return col
+	}
+	testcases := []struct {
+		column          *Column
+		directive       bool
+		expectedCheck   string
+		expectedComment string
+	}{
+		{newDialectColumn("postgres", "phone", "phone {{check:~ '^[0-9]+$'}}"), true, "phone ~ '^[0-9]+$'", "phone"},
+		{newDialectColumn("postgres", "code", "{{check:~ '^[A-Z]{3}$'}} code"), true, "code ~ '^[A-Z]{3}$'", "code"},
+		{newDialectColumn("postgres", "tag", `{{check:~ '^\w+;"x"$'}}`), true, `tag ~ '^\\w+\\;\"x\"$'`, ""},
+		{newDialectColumn("mysql", "phone", "phone {{check:~ '^[0-9]+$'}}"), true, "phone REGEXP '^[0-9]+$'", "phone"},
+		{newDialectColumn("postgres", "age", "age {{check:age > 0}}"), true, "age > 0", "age"},
+		{newDialectColumn("sqlite", "phone", "phone {{check:~ '^[0-9]+$'}}"), true, "", "phone"},
+		{newDialectColumn("postgres", "phone", "phone {{check:~ '^[0-9]+$'}}"), false, "", "phone {{check:~ '^[0-9]+$'}}"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetCheckDirective(testcase.directive)
+		f := testcase.column.ToField(false, false, false)
+		if check := strings.Join(f.GORMTag[field.TagKeyGormCheck], ","); check != testcase.expectedCheck || f.ColumnComment != testcase.expectedComment {
+			t.Errorf("column %s check/comment expect: %q/%q, got: %q/%q", f.ColumnName,
+				testcase.expectedCheck, testcase.expectedComment, check, f.ColumnComment)
+		}
+	}
+}
+
+func TestColumn_ExampleTag(t *testing.T) {
+	withComment := func(comment string) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) { ct.CommentValue = sql.NullString{String: comment, Valid: true} }
+	}
+	testcases := []struct {
+		column          *Column
+		expected        string
+		expectedComment string
+	}{
+		{newColumn("name", "varchar", "varchar(64)", withDefault("'it''s'::character varying")), "it's", ""},
+		{newColumn("title", "varchar", "varchar(64)", withDefault("('say \"hi\"')")), `say \"hi\"`, ""},
+		{newColumn("age", "int", "int", withDefault("18"), withScanType(reflect.TypeOf(0))), "18", ""},
+		{newColumn("email", "varchar", "varchar(64)", withDefault("''"), withComment("email {{example:a@b.com}}")), "a@b.com", "email"},
+		{newColumn("token", "uuid", "uuid", withDefault("gen_random_uuid()")), "", ""},
+		{newColumn("note", "varchar", "varchar(64)", withDefault("NULL")), "", ""},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetExampleTag(true)
+		f := testcase.column.ToField(false, false, false)
+		if f.Tag[field.TagKeyExample] != testcase.expected || f.ColumnComment != testcase.expectedComment {
+			t.Errorf("column %s example/comment expect: %q/%q, got: %q/%q", f.ColumnName,
+				testcase.expected, testcase.expectedComment, f.Tag[field.TagKeyExample], f.ColumnComment)
+		}
+	}
+}
+
+func TestColumn_ProtobufTag(t *testing.T) {
+	numbers := func(table, column string) (int, bool) {
+		if table == "users" && column == "email" {
+			return 10, true
+		}
+		return 0, false
+	}
+	newOrdinalColumn := func(ordinal int, name, dataType string, typ reflect.Type) *Column {
+		col := newColumn(name, dataType, dataType, withScanType(typ))
+		col.Ordinal = ordinal
+		return col
+	}
+	testcases := []struct {
+		column   *Column
+		expected string
+	}{
+		{newOrdinalColumn(1, "id", "bigint", reflect.TypeOf(int64(0))), "varint,1,opt,name=id"},
+		{newOrdinalColumn(2, "name", "varchar", reflect.TypeOf("")), "bytes,2,opt,name=name"},
+		{newOrdinalColumn(3, "score", "double", reflect.TypeOf(float64(0))), "fixed64,3,opt,name=score"},
+		{newOrdinalColumn(4, "email", "varchar", reflect.TypeOf("")), "bytes,10,opt,name=email"},
+		{newOrdinalColumn(0, "nickname", "varchar", reflect.TypeOf("")), ""},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetProtobufTag(true, numbers)
+		f := testcase.column.ToField(true, false, false)
+		if got := f.Tag[field.TagKeyProtobuf]; got != testcase.expected {
+			t.Errorf("column %s protobuf tag expect: %q, got: %q", f.ColumnName, testcase.expected, got)
+		}
+	}
+}
+
+type baseColumnType = migrator.ColumnType
+
+type identityColumnType struct{ baseColumnType }
+
+func (identityColumnType) IsIdentity() (bool, bool) { return true, true }
+
+func TestColumn_AutoIncrementOverride(t *testing.T) {
+	primaryKey := func(autoIncrement bool) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) {
+			ct.PrimaryKeyValue = sql.NullBool{Bool: true, Valid: true}
+			ct.AutoIncrementValue = sql.NullBool{Bool: autoIncrement, Valid: true}
+		}
+	}
+	intType := withScanType(reflect.TypeOf(int64(0)))
+	overrides := map[string]bool{"users.id": false, "users.seq_no": true, "users.code": true}
+	testcases := []struct {
+		column   *Column
+		expected string
+	}{
+		{newColumn("id", "bigint", "bigint", primaryKey(true), intType), "column:id;type:bigint;primaryKey;autoIncrement:false"},
+		{newColumn("seq_no", "bigint", "bigint", withDefault("nextval('users_seq_no_seq'::regclass)"), intType), "column:seq_no;type:bigint;autoIncrement:true"},
+		{newColumn("code", "bigint", "bigint", primaryKey(false), intType), "column:code;type:bigint;primaryKey;autoIncrement:true"},
+		{newColumn("uid", "bigint", "bigint", primaryKey(true), intType), "column:uid;type:bigint;primaryKey;autoIncrement:true"},
+		{newColumn("age", "int", "int", withDefault("18"), intType), "column:age;type:int;default:18"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetAutoIncrementOverride(overrides)
+		if got := testcase.column.ToField(false, false, false).GORMTag.Build(); got != testcase.expected {
+			t.Errorf("gorm tag expect: %q, got: %q", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_SharedPrimaryKey(t *testing.T) {
+	primaryKey := func(autoIncrement bool) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) {
+			ct.PrimaryKeyValue = sql.NullBool{Bool: true, Valid: true}
+			ct.AutoIncrementValue = sql.NullBool{Bool: autoIncrement, Valid: true}
+		}
+	}
+	intType := withScanType(reflect.TypeOf(int64(0)))
+	withFK := func(col *Column, refTable, refColumn string) *Column {
+		col.ForeignKeys = []*ForeignKey{{Name: "fk_" + col.Name(), Column: col.Name(), RefTable: refTable, RefColumn: refColumn}}
+		return col
+	}
+	testcases := []struct {
+		column          *Column
+		mode            SharedPKMode
+		expectedTag     string
+		expectedComment string
+	}{
+		{withFK(newColumn("user_id", "bigint", "bigint", primaryKey(true), intType), "users", "id"), SharedPKAsIs,
+			"column:user_id;type:bigint;primaryKey;autoIncrement:true", ""},
+		{withFK(newColumn("user_id", "bigint", "bigint", primaryKey(true), intType), "users", "id"), SharedPKNoAutoIncrement,
+			"column:user_id;type:bigint;primaryKey;autoIncrement:false", ""},
+		{withFK(newColumn("user_id", "bigint", "bigint", primaryKey(true), intType), "users", "id"), SharedPKComment,
+			"column:user_id;type:bigint;primaryKey;autoIncrement:false", "shared primary key references users(id)"},
+		{withFK(newColumn("user_id", "bigint", "bigint", primaryKey(false), intType), "users", ""), SharedPKComment,
+			"column:user_id;type:bigint;primaryKey;autoIncrement:false", "shared primary key references users"},
+		// composite primary key partially foreign key, only referencing column is affected
+		{newColumn("seq", "bigint", "bigint", primaryKey(true), intType), SharedPKComment,
+			"column:seq;type:bigint;primaryKey;autoIncrement:true", ""},
+		// foreign key not being primary key
+		{withFK(newColumn("owner_id", "bigint", "bigint", intType), "users", "id"), SharedPKComment,
+			"column:owner_id;type:bigint", ""},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetSharedPKMode(testcase.mode)
+		f := testcase.column.ToField(false, false, false)
+		if got := f.GORMTag.Build(); got != testcase.expectedTag {
+			t.Errorf("gorm tag expect: %q, got: %q", testcase.expectedTag, got)
+		}
+		if f.ColumnComment != testcase.expectedComment {
+			t.Errorf("comment expect: %q, got: %q", testcase.expectedComment, f.ColumnComment)
+		}
+	}
+}
+
+func TestColumn_ViewNull(t *testing.T) {
+	testcases := []struct {
+		viewNull sql.NullBool
+		expected string
+	}{
+		{sql.NullBool{}, "*string"},
+		{sql.NullBool{Bool: false, Valid: true}, "string"},
+		{sql.NullBool{Bool: true, Valid: true}, "*string"},
+	}
+	for _, testcase := range testcases {
+		col := newColumn("email", "varchar", "varchar(64)")
+		col.ViewNull = testcase.viewNull
+		col.SetOmitemptyPolicies(map[string]OmitemptyPolicy{"json": OmitemptyNullable})
+		f := col.ToField(true, false, false)
+		if f.Type != testcase.expected {
+			t.Errorf("field type expect: %s, got: %s", testcase.expected, f.Type)
+		}
+		if omitempty := strings.HasSuffix(f.Tag["json"], ",omitempty"); omitempty != (testcase.expected == "*string") {
+			t.Errorf("json tag omitempty expect: %t, got: %s", testcase.expected == "*string", f.Tag["json"])
+		}
+	}
+}
+
+func TestColumn_ColumnNameOverride(t *testing.T) {
+	overrides := map[string]string{"users.user_name": "UserName", "users.age": ""}
+	testcases := []struct {
+		column   *Column
+		expected string
+	}{
+		{newColumn("user_name", "varchar", "varchar(64)"), "column:UserName;type:varchar(64)"},
+		{newColumn("age", "int", "int"), "column:age;type:int"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetColumnNameOverride(overrides)
+		f := testcase.column.ToField(false, false, false)
+		if got := f.GORMTag.Build(); got != testcase.expected {
+			t.Errorf("gorm tag expect: %q, got: %q", testcase.expected, got)
+		}
+		if f.ColumnName != testcase.column.Name() || f.Tag[field.TagKeyJson] != testcase.column.Name() {
+			t.Errorf("column name and json tag expect: %q, got: %q, %q", testcase.column.Name(), f.ColumnName, f.Tag[field.TagKeyJson])
+		}
+	}
+}
+
+func TestColumn_TagOmitempty(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	emailBinding := func(ct *migrator.ColumnType) { ct.CommentValue = sql.NullString{String: "[[email]]", Valid: true} }
+	policies := map[string]OmitemptyPolicy{
+		field.TagKeyJson:    OmitemptyNullable,
+		"form":              OmitemptyAlways,
+		"yaml":              OmitemptyAlways,
+		field.TagKeyBinding: OmitemptyNullable,
+	}
+	tagNS := map[string]func(string) string{
+		"form": func(n string) string { return n },
+		"yaml": func(string) string { return "-" },
+	}
+	testcases := []struct {
+		column   *Column
+		expected field.Tag
+	}{
+		{newColumn("name", "varchar", "varchar(64)"), field.Tag{"json": "name,omitempty", "form": "name,omitempty", "yaml": "-"}},
+		{newColumn("age", "int", "int", notNull), field.Tag{"json": "age", "form": "age,omitempty", "yaml": "-"}},
+		{newColumn("email", "varchar", "varchar(64)", emailBinding), field.Tag{"json": "email,omitempty", "form": "email,omitempty", "yaml": "-", "binding": "omitempty,email"}},
+	}
+	for _, testcase := range testcases {
+		testcase.column.WithTagNS(tagNS)
+		testcase.column.SetOmitemptyPolicies(policies)
+		if got := testcase.column.ToField(false, false, false).Tag; !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("tag expect: %v, got: %v", testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_DetectIdentity(t *testing.T) {
+	primaryKey := func(ct *migrator.ColumnType) { ct.PrimaryKeyValue = sql.NullBool{Bool: true, Valid: true} }
+	autoIncrement := func(ct *migrator.ColumnType) { ct.AutoIncrementValue = sql.NullBool{Bool: true, Valid: true} }
+	identity := func(increment int64) func(*Column) {
+		return func(c *Column) { c.Identity, c.Increment = true, increment }
+	}
+	withColumnType := func(c *Column) { c.ColumnType = identityColumnType{c.ColumnType.(migrator.ColumnType)} }
+	testcases := []struct {
+		column   *Column
+		opt      func(*Column)
+		detect   bool
+		expected string
+	}{
+		{newColumn("id", "int", "int", primaryKey, autoIncrement), nil, false, "column:id;type:int;primaryKey;autoIncrement:true"},
+		{newColumn("id", "int", "int", primaryKey), identity(1), false, "column:id;type:int;primaryKey"},
+		{newColumn("id", "int", "int", primaryKey), identity(1), true, "column:id;type:int;primaryKey;autoIncrement:true"},
+		{newColumn("id", "int", "int", primaryKey), identity(10), true, "column:id;type:int;primaryKey;autoIncrement:true;autoIncrementIncrement:10"},
+		{newColumn("id", "int", "int identity(1,1)", primaryKey), nil, true, "column:id;type:int identity(1,1);primaryKey;autoIncrement:true"},
+		{newColumn("id", "int", "int", primaryKey), withColumnType, true, "column:id;type:int;primaryKey;autoIncrement:true"},
+		{newColumn("id", "int", "int", primaryKey), nil, true, "column:id;type:int;primaryKey"},
+	}
+	for i, testcase := range testcases {
+		if testcase.opt != nil {
+			testcase.opt(testcase.column)
+		}
+		testcase.column.SetDetectIdentity(testcase.detect)
+		if got := testcase.column.ToField(false, false, false).GORMTag.Build(); got != testcase.expected {
+			t.Errorf("case %d gorm tag expect: %s, got: %s", i, testcase.expected, got)
+		}
+	}
+}
+
+func TestColumn_TimestampType(t *testing.T) {
+	timeType := withScanType(reflect.TypeOf(time.Time{}))
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column       *Column
+		columns      []string
+		expectedType string
+		expectedTag  string
+	}{
+		{newColumn("created_at", "datetime", "datetime", timeType, notNull), []string{"*.created_at", "*.updated_at"},
+			"types.Timestamp", "column:created_at;type:datetime;not null;autoCreateTime"},
+		{newColumn("updated_at", "datetime", "datetime", timeType), []string{"*.created_at", "*.updated_at"},
+			"*types.Timestamp", "column:updated_at;type:datetime;autoUpdateTime"},
+		{newColumn("deleted_at", "datetime", "datetime", timeType), []string{"*.created_at", "*.updated_at"},
+			"gorm.DeletedAt", "column:deleted_at;type:datetime"},
+		{newColumn("deleted_at", "datetime", "datetime", timeType), []string{"users.*_at"},
+			"*types.Timestamp", "column:deleted_at;type:datetime"},
+		{newColumn("created_at", "datetime", "datetime", timeType), nil, "*time.Time", "column:created_at;type:datetime"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetTimestampType("types.Timestamp", testcase.columns)
+		f := testcase.column.ToField(true, false, false)
+		if f.Type != testcase.expectedType || f.GORMTag.Build() != testcase.expectedTag {
+			t.Errorf("column %s type/tag expect: %s/%s, got: %s/%s", f.ColumnName, testcase.expectedType, testcase.expectedTag, f.Type, f.GORMTag.Build())
+		}
+	}
+}
+
+type ordinalColumnType struct {
+	baseColumnType
+	ordinal int
+}
+
+func (ct ordinalColumnType) OrdinalPosition() (int, bool) { return ct.ordinal, ct.ordinal > 0 }
+
+func TestColumn_OrdinalPosition(t *testing.T) {
+	driverColumn := func(ordinal int) *Column {
+		col := newColumn("name", "varchar", "varchar(64)")
+		col.ColumnType = ordinalColumnType{baseColumnType: col.ColumnType.(migrator.ColumnType), ordinal: ordinal}
+		return col
+	}
+	testcases := []struct {
+		column   *Column
+		ordinal  int
+		expected int
+		ok       bool
+	}{
+		{newColumn("name", "varchar", "varchar(64)"), 0, 0, false},
+		{newColumn("name", "varchar", "varchar(64)"), 3, 3, true},
+		{driverColumn(2), 0, 2, true},
+		{driverColumn(0), 0, 0, false},
+		// ordinal read from database precedes the one reported by driver
+		{driverColumn(2), 5, 5, true},
+	}
+	for i, testcase := range testcases {
+		testcase.column.Ordinal = testcase.ordinal
+		if ordinal, ok := testcase.column.OrdinalPosition(); ordinal != testcase.expected || ok != testcase.ok {
+			t.Errorf("case %d ordinal position expect: %d %t, got: %d %t", i, testcase.expected, testcase.ok, ordinal, ok)
+		}
+		if f := testcase.column.ToField(false, false, false); f.Ordinal != testcase.expected {
+			t.Errorf("case %d field ordinal expect: %d, got: %d", i, testcase.expected, f.Ordinal)
+		}
+	}
+}
+
+func TestColumn_UTCTimeSerializer(t *testing.T) {
+	timeType := withScanType(reflect.TypeOf(time.Time{}))
+	testcases := []struct {
+		dialect  string
+		column   *Column
+		dialects []string
+		expected bool
+	}{
+		{"mysql", newColumn("created_at", "datetime", "datetime", timeType), nil, true},
+		{"postgres", newColumn("created_at", "timestamp", "timestamp without time zone", timeType), nil, true},
+		// columns with zone info are exempt
+		{"postgres", newColumn("created_at", "timestamptz", "timestamptz", timeType), nil, false},
+		{"postgres", newColumn("created_at", "timestamp", "timestamp(6) with time zone", timeType), nil, false},
+		{"postgres", newColumn("opened_at", "timetz", "time with time zone", timeType), nil, false},
+		{"sqlserver", newColumn("created_at", "datetimeoffset", "datetimeoffset", timeType), nil, false},
+		// dialects limited
+		{"mysql", newColumn("created_at", "datetime", "datetime", timeType), []string{"mysql"}, true},
+		{"postgres", newColumn("created_at", "timestamp", "timestamp", timeType), []string{"mysql"}, false},
+		// non-time column
+		{"mysql", newColumn("created_at", "varchar", "varchar(32)"), nil, false},
+	}
+	for _, testcase := range testcases {
+		testcase.column.Dialect = testcase.dialect
+		testcase.column.SetUTCTimeSerializer(field.UTCTimeSerializerName, testcase.dialects)
+		f := testcase.column.ToField(true, false, false)
+		if got := strings.Contains(f.GORMTag.Build(), "serializer:utc"); got != testcase.expected {
+			t.Errorf("%s column %s(%s) utc serializer expect: %t, got: %s", testcase.dialect, f.ColumnName,
+				testcase.column.DatabaseTypeName(), testcase.expected, f.GORMTag.Build())
+		}
+	}
+
+	column := newColumn("created_at", "datetime", "datetime", timeType)
+	if f := column.ToField(true, false, false); strings.Contains(f.GORMTag.Build(), "serializer") {
+		t.Errorf("column without utc serializer set expect no serializer, got: %s", f.GORMTag.Build())
+	}
+}
+
+func TestColumn_BindingOmitempty(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	comment := func(c string) func(*migrator.ColumnType) {
+		return func(ct *migrator.ColumnType) { ct.CommentValue = sql.NullString{String: c, Valid: true} }
+	}
+	testcases := []struct {
+		column   *Column
+		on       bool
+		expected string
+	}{
+		{newColumn("nickname", "varchar", "varchar(64)"), true, "omitempty"},
+		{newColumn("nickname", "varchar", "varchar(64)", comment("[[max=64]]")), true, "omitempty,max=64"},
+		{newColumn("nickname", "varchar", "varchar(64)", comment("[[omitempty,max=64]]")), true, "omitempty,max=64"},
+		// required-but-nullable declared in comment overrides omitempty
+		{newColumn("nickname", "varchar", "varchar(64)", comment("[[required,max=64]]")), true, "required,max=64"},
+		// non-pointer field is unchanged
+		{newColumn("name", "varchar", "varchar(64)", notNull, comment("[[max=64]]")), true, "max=64"},
+		{newColumn("name", "varchar", "varchar(64)", notNull), true, ""},
+		// opt-in
+		{newColumn("nickname", "varchar", "varchar(64)", comment("[[max=64]]")), false, "max=64"},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetBindingOmitempty(testcase.on)
+		if got := testcase.column.ToField(true, false, false).Tag[field.TagKeyBinding]; got != testcase.expected {
+			t.Errorf("column %s binding expect: %q, got: %q", testcase.column.Name(), testcase.expected, got)
+		}
+	}
+}
+
+func TestGroupCheckByColumn(t *testing.T) {
+	positive := &Check{Name: "chk_age", Constraint: "(`age` > 0)"}
+	spanning := &Check{Name: "chk_range", Constraint: "(max_age >= AGE)"}
+	page := &Check{Name: "chk_page", Constraint: "(page > 0)"}
+	constant := &Check{Name: "chk_const", Constraint: "(1 = 1)"}
+	checks := GroupCheckByColumn([]*Check{positive, spanning, page, constant, nil}, []string{"id", "age", "max_age"})
+	expected := map[string][]*Check{"age": {positive, spanning}}
+	if !reflect.DeepEqual(checks, expected) {
+		t.Errorf("checks expect grouped by the first referenced column in table order: %v, got: %v", expected, checks)
+	}
+
+	for _, testcase := range []struct {
+		check    *Check
+		expected string
+	}{
+		{positive, "chk_age,(age > 0)"},
+		{&Check{Constraint: `(status IN ("a", "b"))`}, `(status IN (\"a\", \"b\"))`},
+		{&Check{Name: "chk_path", Constraint: `(path <> '\')`}, `chk_path,(path <> '\\')`},
+	} {
+		if got := testcase.check.TagValue(); got != testcase.expected {
+			t.Errorf("check tag value expect: %s, got: %s", testcase.expected, got)
+		}
+	}
+
+	column := newColumn("age", "int", "int")
+	column.Checks = checks["age"]
+	if tag := column.ToField(false, false, false).GORMTag.Build(); !strings.Contains(tag, "check:chk_age,(age > 0);check:chk_range,(max_age >= AGE)") {
+		t.Errorf("column with checks expect check tags, got: %s", tag)
+	}
+	if tag := newColumn("age", "int", "int").ToField(false, false, false).GORMTag.Build(); strings.Contains(tag, "check") {
+		t.Errorf("column without checks expect no check tag, got: %s", tag)
+	}
+}
+
+func TestColumn_JSONType(t *testing.T) {
+	notNull := func(ct *migrator.ColumnType) { ct.NullableValue = sql.NullBool{Bool: false, Valid: true} }
+	testcases := []struct {
+		column             *Column
+		jsonType           JSONType
+		arrayColumns       []string
+		expectedType       string
+		expectedSerializer bool
+	}{
+		{newColumn("extra", "json", "json"), JSONTypeAsIs, nil, "*string", false},
+		{newColumn("extra", "json", "json"), JSONTypeRaw, nil, "*datatypes.JSON", false},
+		{newColumn("extra", "jsonb", "jsonb", notNull), JSONTypeRaw, nil, "datatypes.JSON", false},
+		// nullable map is nil map, not pointer
+		{newColumn("extra", "json", "json"), JSONTypeMap, nil, "map[string]interface{}", true},
+		{newColumn("extra", "jsonb", "jsonb", notNull), JSONTypeMap, nil, "map[string]interface{}", true},
+		// top level array by configured column or array default
+		{newColumn("tags", "json", "json"), JSONTypeMap, []string{"users.tags"}, "[]interface{}", true},
+		{newColumn("tags", "json", "json"), JSONTypeMap, []string{"orders.tags"}, "map[string]interface{}", true},
+		{newColumn("tags", "json", "json", withDefault("('[]')")), JSONTypeMap, nil, "[]interface{}", true},
+		{newColumn("tags", "jsonb", "jsonb", withDefault("'[]'::jsonb")), JSONTypeMap, nil, "[]interface{}", true},
+		{newColumn("tags", "json", "json", withDefault("(json_array())")), JSONTypeMap, nil, "[]interface{}", true},
+		{newColumn("extra", "json", "json", withDefault("'{}'")), JSONTypeMap, nil, "map[string]interface{}", true},
+		// non-json column
+		{newColumn("name", "varchar", "varchar(64)"), JSONTypeMap, nil, "*string", false},
+	}
+	for _, testcase := range testcases {
+		testcase.column.SetJSONType(testcase.jsonType, testcase.arrayColumns)
+		f := testcase.column.ToField(true, false, false)
+		if f.Type != testcase.expectedType {
+			t.Errorf("column %s type expect: %s, got: %s", f.ColumnName, testcase.expectedType, f.Type)
+		}
+		if _, ok := f.GORMTag[field.TagKeyGormSerializer]; ok != testcase.expectedSerializer {
+			t.Errorf("column %s json serializer expect: %t, got: %s", f.ColumnName, testcase.expectedSerializer, f.GORMTag.Build())
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package model
+
+import "fmt"
+
+// ForeignKey table foreign key constraint info, one entry per column of composite foreign key
+type ForeignKey struct {
+	Name      string `gorm:"column:CONSTRAINT_NAME"`
+	Column    string `gorm:"column:COLUMN_NAME"`
+	RefTable  string `gorm:"column:REFERENCED_TABLE_NAME"`
+	RefColumn string `gorm:"column:REFERENCED_COLUMN_NAME"`
+}
+
+// SharedPKMode how to generate primary key column which is also foreign key,
+// e.g. user_profiles.user_id referencing users.id in one-to-one tables sharing primary key
+type SharedPKMode int
+
+const (
+	// SharedPKAsIs keep primary key as is, foreign keys are not read
+	SharedPKAsIs SharedPKMode = iota
+	// SharedPKNoAutoIncrement generate autoIncrement:false, key value comes from referenced row
+	SharedPKNoAutoIncrement
+	// SharedPKComment generate as SharedPKNoAutoIncrement and note referenced column in field comment,
+	// e.g. shared primary key references users(id)
+	SharedPKComment
+)
+
+// GroupForeignKeyByColumn group foreign keys by referencing column, foreign keys of unknown column are ignored
+func GroupForeignKeyByColumn(foreignKeys []*ForeignKey, columnNames []string) map[string][]*ForeignKey {
+	known := make(map[string]bool, len(columnNames))
+	for _, name := range columnNames {
+		known[name] = true
+	}
+	columnFKMap := make(map[string][]*ForeignKey, len(foreignKeys))
+	for _, fk := range foreignKeys {
+		if fk == nil || !known[fk.Column] {
+			continue
+		}
+		columnFKMap[fk.Column] = append(columnFKMap[fk.Column], fk)
+	}
+	return columnFKMap
+}
+
+// Reference referenced table and column, e.g. users(id), column is omitted if unknown(sqlite implicit primary key)
+func (fk *ForeignKey) Reference() string {
+	if fk.RefColumn == "" {
+		return fk.RefTable
+	}
+	return fmt.Sprintf("%s(%s)", fk.RefTable, fk.RefColumn)
+}
+
+// SetSharedPKMode set how to generate primary key column which is also foreign key
+func (c *Column) SetSharedPKMode(mode SharedPKMode) {
+	c.sharedPKMode = mode
+}
+
+// sharedPKForeignKey foreign key of primary key column, nil if column is not primary key or mode is SharedPKAsIs,
+// only the referencing columns of composite primary key partially being foreign key are affected
+func (c *Column) sharedPKForeignKey() *ForeignKey {
+	if c.sharedPKMode == SharedPKAsIs || len(c.ForeignKeys) == 0 {
+		return nil
+	}
+	if pk, ok := c.PrimaryKey(); !ok || !pk {
+		return nil
+	}
+	return c.ForeignKeys[0]
+}
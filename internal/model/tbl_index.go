@@ -1,11 +1,106 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"gorm.io/gen/field"
+)
 
 // Index table index info
 type Index struct {
 	gorm.Index
-	Priority int32 `gorm:"column:SEQ_IN_INDEX"`
+	Priority  int32  `gorm:"column:SEQ_IN_INDEX"`
+	Composite bool   `gorm:"-"` // declared in config, generated as index:,composite:name
+	TagName   string `gorm:"-"` // name used in tag, shortened for identifier length limit
+
+	NullsNotDistinct bool `gorm:"-"` // unique index treats nulls as equal(postgres 15+ NULLS NOT DISTINCT)
+}
+
+// nullsNotDistinctOption option of unique index treating nulls as equal
+const nullsNotDistinctOption = ",option:NULLS NOT DISTINCT"
+
+// tagOption index option used in tag
+func (idx *Index) tagOption() string {
+	if uniq, _ := idx.Unique(); uniq && idx.NullsNotDistinct {
+		return nullsNotDistinctOption
+	}
+	return ""
+}
+
+// tagName index name used in tag
+func (idx *Index) tagName() string {
+	if idx.TagName != "" {
+		return idx.TagName
+	}
+	return idx.Name()
+}
+
+// IdentifierMaxLen default max identifier length of dialects, dialect not listed has no limit
+var IdentifierMaxLen = map[string]int{
+	"mysql":     64,
+	"postgres":  63,
+	"sqlserver": 128,
+	"oracle":    30,
+}
+
+// ShortenIndexNames shorten index names longer than maxLen to prefix + hash suffix deterministically,
+// return original name -> shortened name, shortened names are unique in the indexes
+func ShortenIndexNames(indexList []gorm.Index, maxLen int) map[string]string {
+	names := make(map[string]string)
+	if maxLen <= 0 {
+		return names
+	}
+
+	used := make(map[string]bool, len(indexList))
+	var longNames []string
+	for _, idx := range indexList {
+		if idx == nil {
+			continue
+		}
+		if _, composite := idx.(*CompositeIndex); composite { // name is generated by gorm
+			continue
+		}
+		if name := idx.Name(); len(name) > maxLen {
+			longNames = append(longNames, name)
+		} else {
+			used[name] = true
+		}
+	}
+	sort.Strings(longNames)
+
+	for _, name := range longNames {
+		if _, ok := names[name]; ok {
+			continue
+		}
+		for salt := 0; ; salt++ {
+			short := shortenIdentifier(name, maxLen, salt)
+			if !used[short] {
+				used[short], names[name] = true, short
+				break
+			}
+		}
+	}
+	return names
+}
+
+// shortenIdentifier keep prefix of name and append hash of full name, e.g. idx_very_long_name_1a2b3c4d
+func shortenIdentifier(name string, maxLen int, salt int) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	if salt > 0 {
+		_, _ = h.Write([]byte(strconv.Itoa(salt)))
+	}
+	suffix := fmt.Sprintf("%08x", h.Sum32())
+	if maxLen <= len(suffix)+1 {
+		return suffix[:maxLen]
+	}
+	return strings.TrimRight(name[:maxLen-len(suffix)-1], "_") + "_" + suffix
 }
 
 // GroupByColumn group columns
@@ -19,12 +114,165 @@ func GroupByColumn(indexList []gorm.Index) map[string][]*Index {
 		if idx == nil {
 			continue
 		}
+		_, composite := idx.(*CompositeIndex)
 		for i, col := range idx.Columns() {
 			columnIndexMap[col] = append(columnIndexMap[col], &Index{
-				Index:    idx,
-				Priority: int32(i + 1),
+				Index:     idx,
+				Priority:  int32(i + 1),
+				Composite: composite,
 			})
 		}
 	}
 	return columnIndexMap
 }
+
+// NormalizeIndexPriorities shift priorities of each index to start at 1, keeping their relative order,
+// some drivers report 0-based sequence in index; priorities already starting at 1 are unchanged
+func NormalizeIndexPriorities(columnIndexMap map[string][]*Index) {
+	lowest := make(map[string]int32)
+	for _, indexes := range columnIndexMap {
+		for _, idx := range indexes {
+			if p, ok := lowest[idx.Name()]; !ok || idx.Priority < p {
+				lowest[idx.Name()] = idx.Priority
+			}
+		}
+	}
+	for _, indexes := range columnIndexMap {
+		for _, idx := range indexes {
+			idx.Priority += 1 - lowest[idx.Name()]
+		}
+	}
+}
+
+// IndexDefs collect index definitions from indexes of columns in order of first appearance,
+// columns of composite index are ordered by priority
+func IndexDefs(columns []*Column) []field.IndexDef {
+	type indexColumn struct {
+		name     string
+		priority int32
+	}
+	var defs []field.IndexDef
+	defColumns := make(map[int][]indexColumn)
+	positions := make(map[string]int)
+	for _, c := range columns {
+		for _, idx := range c.Indexes {
+			key := fmt.Sprintf("%t:%s", idx.Composite, idx.Name())
+			pos, ok := positions[key]
+			if !ok {
+				def := field.IndexDef{Name: idx.Name(), Type: field.IndexTypeIndex}
+				if pk, _ := idx.PrimaryKey(); pk {
+					def.Unique, def.Type = true, field.IndexTypePrimaryKey
+				} else if uniq, _ := idx.Unique(); uniq {
+					def.Unique, def.Type = true, field.IndexTypeUnique
+				}
+				pos, positions[key] = len(defs), len(defs)
+				defs = append(defs, def)
+			}
+			defColumns[pos] = append(defColumns[pos], indexColumn{name: c.Name(), priority: idx.Priority})
+		}
+	}
+	for pos := range defs {
+		cols := defColumns[pos]
+		sort.SliceStable(cols, func(i, j int) bool { return cols[i].priority < cols[j].priority })
+		for _, col := range cols {
+			defs[pos].Columns = append(defs[pos].Columns, col.name)
+		}
+	}
+	return defs
+}
+
+// IndexPrecedence precedence between config declared composite index and database reported index on the same columns
+type IndexPrecedence int
+
+const (
+	// IndexPrecedenceDB database reported index wins, config declared index only supplements missing ones
+	IndexPrecedenceDB IndexPrecedence = iota
+	// IndexPrecedenceConfig config declared index wins, conflicting database reported index is dropped
+	IndexPrecedenceConfig
+)
+
+// CompositeIndex composite index declared in config, shared by multiple tables
+type CompositeIndex struct {
+	IndexName  string
+	ColumnList []string // ordered columns
+	TableNames []string // empty means all tables containing every column
+	TableName  string   // table the index applied to
+}
+
+// Table table name
+func (idx *CompositeIndex) Table() string { return idx.TableName }
+
+// Name index name
+func (idx *CompositeIndex) Name() string { return idx.IndexName }
+
+// Columns ordered columns
+func (idx *CompositeIndex) Columns() []string { return idx.ColumnList }
+
+// PrimaryKey composite index is never primary key
+func (idx *CompositeIndex) PrimaryKey() (isPrimaryKey bool, ok bool) { return false, true }
+
+// Unique composite index is not unique
+func (idx *CompositeIndex) Unique() (unique bool, ok bool) { return false, true }
+
+// Option index option
+func (idx *CompositeIndex) Option() string { return "" }
+
+// ApplyTo get index applied to table with columns, return nil if not applicable
+func (idx *CompositeIndex) ApplyTo(tableName string, columns []string) *CompositeIndex {
+	if len(idx.ColumnList) == 0 || (len(idx.TableNames) > 0 && !contains(idx.TableNames, tableName)) {
+		return nil
+	}
+	for _, col := range idx.ColumnList {
+		if !contains(columns, col) {
+			return nil
+		}
+	}
+	return &CompositeIndex{IndexName: idx.IndexName, ColumnList: idx.ColumnList, TableNames: idx.TableNames, TableName: tableName}
+}
+
+// MergeIndexes merge config declared composite indexes into database reported indexes,
+// index on the same ordered columns or with the same name(idx_table_name) is conflicting, resolved by precedence
+func MergeIndexes(dbIndexes []gorm.Index, compositeIndexes []*CompositeIndex, precedence IndexPrecedence) []gorm.Index {
+	conflict := func(dbIdx gorm.Index, idx *CompositeIndex) bool {
+		if pk, _ := dbIdx.PrimaryKey(); pk {
+			return false
+		}
+		return dbIdx.Name() == "idx_"+idx.TableName+"_"+idx.IndexName ||
+			strings.Join(dbIdx.Columns(), ",") == strings.Join(idx.ColumnList, ",")
+	}
+
+	result := make([]gorm.Index, 0, len(dbIndexes)+len(compositeIndexes))
+	for _, dbIdx := range dbIndexes {
+		if dbIdx == nil {
+			continue
+		}
+		if precedence == IndexPrecedenceConfig {
+			dropped := false
+			for _, idx := range compositeIndexes {
+				if dropped = conflict(dbIdx, idx); dropped {
+					break
+				}
+			}
+			if dropped {
+				continue
+			}
+		}
+		result = append(result, dbIdx)
+	}
+	for _, idx := range compositeIndexes {
+		if precedence == IndexPrecedenceDB {
+			conflicted := false
+			for _, dbIdx := range dbIndexes {
+				if dbIdx != nil && conflict(dbIdx, idx) {
+					conflicted = true
+					break
+				}
+			}
+			if conflicted {
+				continue
+			}
+		}
+		result = append(result, idx)
+	}
+	return result
+}
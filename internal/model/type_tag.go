@@ -0,0 +1,63 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TypeTagTranslations type tag translations from source dialect to target dialect, keyed by base type(without arguments),
+// "(*)" suffix means arguments(length, precision etc.) of source type are kept
+var TypeTagTranslations = map[string]map[string]map[string]string{
+	"mysql": {
+		"postgres": {
+			"bool": "boolean", "boolean": "boolean", "bit": "bit(*)",
+			"tinyint": "smallint", "smallint": "smallint", "mediumint": "integer", "int": "integer", "integer": "integer", "bigint": "bigint",
+			"float": "real", "double": "double precision", "decimal": "numeric(*)", "numeric": "numeric(*)",
+			"char": "char(*)", "varchar": "varchar(*)",
+			"tinytext": "text", "text": "text", "mediumtext": "text", "longtext": "text",
+			"binary": "bytea", "varbinary": "bytea", "tinyblob": "bytea", "blob": "bytea", "mediumblob": "bytea", "longblob": "bytea",
+			"date": "date", "time": "time(*)", "datetime": "timestamp(*)", "timestamp": "timestamp(*)", "year": "smallint",
+			"json": "json",
+		},
+	},
+	"postgres": {
+		"mysql": {
+			"boolean": "tinyint(1)", "bool": "tinyint(1)",
+			"smallint": "smallint", "int2": "smallint", "integer": "int", "int": "int", "int4": "int", "bigint": "bigint", "int8": "bigint",
+			"real": "float", "float4": "float", "double precision": "double", "float8": "double", "numeric": "decimal(*)", "decimal": "decimal(*)",
+			"character": "char(*)", "char": "char(*)", "character varying": "varchar(*)", "varchar": "varchar(*)", "text": "longtext",
+			"bytea": "longblob", "date": "date", "time": "time(*)", "timestamp": "datetime(*)", "timestamp without time zone": "datetime(*)",
+			"timestamp with time zone": "timestamp(*)", "timestamptz": "timestamp(*)",
+			"json": "json", "jsonb": "json", "uuid": "char(36)",
+		},
+	},
+}
+
+var typeTagRegexp = regexp.MustCompile(`^([a-zA-Z ]+?)\s*(\([^)]*\))?((?:\s+[a-zA-Z]+)*)$`)
+
+// translateTypeTag translate type tag from source dialect to target dialect, ok reports whether translation found
+func translateTypeTag(typeTag, source, target string) (_ string, ok bool) {
+	if source == "" || target == "" || strings.EqualFold(source, target) {
+		return typeTag, true
+	}
+	translations := TypeTagTranslations[strings.ToLower(source)][strings.ToLower(target)]
+
+	matches := typeTagRegexp.FindStringSubmatch(strings.TrimSpace(typeTag))
+	if matches == nil {
+		return typeTag, false
+	}
+	base, args := strings.ToLower(strings.TrimSpace(matches[1])), matches[2]
+	if args == "(1)" && (base == "tinyint" || base == "bit") { // mysql boolean
+		base = "bool"
+	}
+	translated, ok := translations[base+args]
+	if !ok {
+		if translated, ok = translations[base]; !ok {
+			return typeTag, false
+		}
+	}
+	if strings.HasSuffix(translated, "(*)") { // unsigned etc. is dropped
+		return strings.TrimSuffix(translated, "(*)") + args, true
+	}
+	return translated, true
+}
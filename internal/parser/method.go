@@ -6,6 +6,7 @@ import (
 	"go/parser"
 	"go/token"
 	"io/ioutil"
+	"path/filepath"
 	"strings"
 )
 
@@ -19,6 +20,147 @@ func DefaultMethodTableName(structName string) *Method {
 	}
 }
 
+// DefaultMethodAfterFind empty AfterFind hook stub, a place to populate transient fields
+func DefaultMethodAfterFind(structName string) *Method {
+	return &Method{
+		Receiver:   Param{IsPointer: true, Type: structName},
+		MethodName: "AfterFind",
+		Doc:        fmt.Sprint("AfterFind ", structName, "'s hook, populate transient(gorm:\"-\") fields here "),
+		Params:     []Param{{Name: "tx", Package: "gorm", Type: "DB", IsPointer: true}},
+		Result:     []Param{{Type: "error"}},
+		Body:       "{\n\treturn nil\n} ",
+	}
+}
+
+// DefaultMethodBeforeCreate BeforeCreate hook assigning Go-managed defaults, assigns are statements of each field
+func DefaultMethodBeforeCreate(structName, receiver string, assigns []string) *Method {
+	var body strings.Builder
+	body.WriteString("{\n")
+	for _, assign := range assigns {
+		body.WriteString("\t" + assign + "\n")
+	}
+	body.WriteString("\treturn nil\n} ")
+	return &Method{
+		Receiver:   Param{Name: receiver, IsPointer: true, Type: structName},
+		MethodName: "BeforeCreate",
+		Doc:        fmt.Sprint("BeforeCreate ", structName, "'s hook, assign Go-managed defaults to zero fields "),
+		Params:     []Param{{Name: "tx", Package: "gorm", Type: "DB", IsPointer: true}},
+		Result:     []Param{{Type: "error"}},
+		Body:       body.String(),
+	}
+}
+
+// DefaultMethodGetter getter of model field
+func DefaultMethodGetter(structName, receiver, fieldName, fieldType string) *Method {
+	return &Method{
+		Receiver:   Param{Name: receiver, IsPointer: true, Type: structName},
+		MethodName: "Get" + fieldName,
+		Doc:        fmt.Sprint("Get", fieldName, " get ", fieldName, " of ", structName, " "),
+		Result:     []Param{{Type: fieldType}},
+		Body:       fmt.Sprintf("{\n\treturn %s.%s\n} ", receiver, fieldName),
+	}
+}
+
+// DefaultMethodEntityID ID getter implementing entity interface, field is converted if idType differs from fieldType
+func DefaultMethodEntityID(structName, receiver, methodName, fieldName, fieldType, idType string) *Method {
+	value := receiver + "." + fieldName
+	if idType == "" {
+		idType = fieldType
+	} else if idType != fieldType {
+		value = fmt.Sprintf("%s(%s)", idType, value)
+	}
+	return &Method{
+		Receiver:   Param{Name: receiver, IsPointer: true, Type: structName},
+		MethodName: methodName,
+		Doc:        fmt.Sprint(methodName, " get ID of ", structName, " "),
+		Result:     []Param{{Type: idType}},
+		Body:       fmt.Sprintf("{\n\treturn %s\n} ", value),
+	}
+}
+
+// DefaultMethodSoftDeletable marker of model with soft delete field, used by generic code to detect soft delete support
+func DefaultMethodSoftDeletable(structName, fieldName string) *Method {
+	return &Method{
+		Receiver:   Param{IsPointer: true, Type: structName},
+		MethodName: "IsSoftDeletable",
+		Doc:        fmt.Sprint("IsSoftDeletable ", structName, " is soft deleted by ", fieldName, " "),
+		Result:     []Param{{Type: "bool"}},
+		Body:       "{\n\treturn true\n} ",
+	}
+}
+
+// DefaultMethodClone deep copy of model, copies are statements copying reference fields from receiver to cloned
+func DefaultMethodClone(structName, receiver string, copies []string) *Method {
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("{\n\tif %s == nil {\n\t\treturn nil\n\t}\n\tcloned := *%s\n", receiver, receiver))
+	for _, copyStmt := range copies {
+		body.WriteString("\t" + copyStmt + "\n")
+	}
+	body.WriteString("\treturn &cloned\n} ")
+	return &Method{
+		Receiver:   Param{Name: receiver, IsPointer: true, Type: structName},
+		MethodName: "Clone",
+		Doc:        fmt.Sprint("Clone deep copy of ", structName, ", elements of slice and map fields and custom types are shallow copied "),
+		Result:     []Param{{Type: structName, IsPointer: true}},
+		Body:       body.String(),
+	}
+}
+
+// DefaultMethodFunctionalIndexes DDL of functional indexes, which gorm tags of single field can't represent
+func DefaultMethodFunctionalIndexes(structName, tableName string, ddls []string) *Method {
+	var body strings.Builder
+	body.WriteString("{\n\treturn []string{\n")
+	for _, ddl := range ddls {
+		body.WriteString(fmt.Sprintf("\t\t%q,\n", ddl))
+	}
+	body.WriteString("\t}\n} ")
+	return &Method{
+		Receiver:   Param{IsPointer: true, Type: structName},
+		MethodName: "FunctionalIndexes",
+		Doc:        fmt.Sprint("FunctionalIndexes DDL of functional indexes on ", tableName, " not represented by field tags "),
+		Result:     []Param{{Type: "[]string"}},
+		Body:       body.String(),
+	}
+}
+
+// DefaultMethodIndexes index definitions of model, defs are field.IndexDef composite literals
+func DefaultMethodIndexes(structName, tableName string, defs []string) *Method {
+	var body strings.Builder
+	body.WriteString("{\n\treturn []field.IndexDef{\n")
+	for _, def := range defs {
+		body.WriteString("\t\t" + def + ",\n")
+	}
+	body.WriteString("\t}\n} ")
+	return &Method{
+		Receiver:   Param{IsPointer: true, Type: structName},
+		MethodName: "Indexes",
+		Doc:        fmt.Sprint("Indexes index definitions of ", tableName, ", columns are ordered by priority "),
+		Result:     []Param{{Type: "[]field.IndexDef"}},
+		Body:       body.String(),
+	}
+}
+
+// ValidatorName name of package-level validator of tag shared by generated Validate methods,
+// e.g. Validator for validate tag, BindingValidator for binding tag
+func ValidatorName(tagName string) string {
+	if tagName == "validate" {
+		return "Validator"
+	}
+	return strings.ToUpper(tagName[:1]) + tagName[1:] + "Validator"
+}
+
+// DefaultMethodValidate validate struct with package-level go-playground/validator of specified tag,
+// validator caches parsed structs so it is shared instead of created on every call
+func DefaultMethodValidate(structName, receiver, tagName string) *Method {
+	return &Method{
+		Receiver:   Param{Name: receiver, IsPointer: true, Type: structName},
+		MethodName: "Validate",
+		Doc:        fmt.Sprint("Validate validate ", structName, " by ", tagName, " tag "),
+		Result:     []Param{{Type: "error"}},
+		Body:       fmt.Sprintf("{\n\treturn %s.Struct(%s)\n} ", ValidatorName(tagName), receiver),
+	}
+}
+
 // Method Apply to query struct and base struct custom method
 type Method struct {
 	Receiver   Param
@@ -143,3 +285,67 @@ func (m *DIYMethods) LoadMethods() error {
 
 	return nil
 }
+
+// GetDeclaredMethods get methods declared by user in non-generated files of dir, grouped by receiver type
+func GetDeclaredMethods(dir string) (map[string]map[string]bool, error) {
+	methods := make(map[string]map[string]bool)
+	err := walkUserFiles(dir, func(f *ast.File) {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			recvType := fn.Recv.List[0].Type
+			if star, ok := recvType.(*ast.StarExpr); ok {
+				recvType = star.X
+			}
+			ident, ok := recvType.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if methods[ident.Name] == nil {
+				methods[ident.Name] = make(map[string]bool)
+			}
+			methods[ident.Name][fn.Name.Name] = true
+		}
+	})
+	return methods, err
+}
+
+// GetDeclaredTypes get types declared by user in non-generated files of dir
+func GetDeclaredTypes(dir string) (map[string]bool, error) {
+	types := make(map[string]bool)
+	err := walkUserFiles(dir, func(f *ast.File) {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					types[ts.Name.Name] = true
+				}
+			}
+		}
+	})
+	return types, err
+}
+
+// walkUserFiles parse non-generated and non-test go files of dir
+func walkUserFiles(dir string, fn func(*ast.File)) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, filename := range files {
+		if strings.HasSuffix(filename, ".gen.go") || strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("can't parse file %q: %s", filename, err)
+		}
+		fn(f)
+	}
+	return nil
+}
@@ -13,3 +13,35 @@ import(
 	{{range .ImportPkgPaths}}{{.}}` + "\n" + `{{end}}
 )
 `
+
+// Overridable built-in templates by name, replaceable by Config.WithTemplateOverride
+var Overridable = map[string]string{
+	"Header":                      Header,
+	"DefaultQuery":                DefaultQuery,
+	"QueryMethod":                 QueryMethod,
+	"QueryMethodTest":             QueryMethodTest,
+	"TableQueryStruct":            TableQueryStruct,
+	"TableQueryStructWithContext": TableQueryStructWithContext,
+	"TableQueryIface":             TableQueryIface,
+	"CRUDMethod":                  CRUDMethod,
+	"CRUDMethodTest":              CRUDMethodTest,
+	"DIYMethod":                   DIYMethod,
+	"DIYMethodTest":               DIYMethodTest,
+	"DIYMethodTestBasic":          DIYMethodTestBasic,
+	"ModelHeader":                 NotEditMark + ModelHeader,
+	"ModelStruct":                 ModelStruct,
+	"ModelColumns":                ModelColumns,
+	"ModelParams":                 ModelParams,
+	"ModelZeroValues":             ModelZeroValues,
+	"ModelUpdatableColumns":       ModelUpdatableColumns,
+	"ModelEnumDefaults":           ModelEnumDefaults,
+	"ModelInterface":              ModelInterface,
+	"ModelEntityAssertion":        ModelEntityAssertion,
+	"ModelMethod":                 ModelMethod,
+	"ModelRegistry":               ModelRegistry,
+	"ModelValidators":             ModelValidators,
+	"EnumFile":                    EnumFile,
+	"EnumType":                    EnumType,
+	"ProtoFile":                   ProtoFile,
+	"ProtoConverter":              ProtoConverter,
+}
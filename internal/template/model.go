@@ -1,24 +1,35 @@
 package template
 
 // Model used as a variable because it cannot load template file after packed, params still can pass file
-const Model = NotEditMark + `
+const Model = NotEditMark + ModelHeader + ModelStruct
+
+// ModelHeader package and imports of model file
+const ModelHeader = `
 package {{.StructInfo.Package}}
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
 	"gorm.io/datatypes"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 	{{range .ImportPkgPaths}}{{.}} ` + "\n" + `{{end}}
 )
+`
 
-{{if .TableName -}}const TableName{{.ModelStructName}} = "{{.TableName}}"{{- end}}
+// ModelStruct model struct without package and imports
+const ModelStruct = `
+{{if .TableName -}}const TableName{{.ModelStructName}} = "{{.QualifiedTableName}}"{{- end}}
 
-// {{.ModelStructName}} {{.StructComment}}
+{{.StructDocComment}}
 type {{.ModelStructName}} struct {
     {{range .Fields}}
+    {{if .Rendered -}}
+    {{.Rendered}}
+    {{- else -}}
     {{if .MultilineComment -}}
 	/*
 {{.ColumnComment}}
@@ -26,9 +37,241 @@ type {{.ModelStructName}} struct {
 	{{end -}}
     {{.Name}} {{.Type}} ` + "`{{.Tags}}` " +
 	"{{if not .MultilineComment}}{{if .ColumnComment}}// {{.ColumnComment}}{{end}}{{end}}" +
-	`{{end}}
+	`{{end}}{{end}}
+}
+
+`
+
+// ModelColumns typed columns of model, used to build query without generated dao
+const ModelColumns = `
+// {{.ModelStructName}}Columns typed columns of {{.ModelStructName}}
+var {{.ModelStructName}}Columns = struct {
+	{{range .Fields -}}
+	{{if and .ColumnName (not .IsRelation) -}}{{.Name}} field.{{.GenType}}` + "\n" + `{{end}}
+	{{- end}}
+}{
+	{{range .Fields -}}
+	{{if and .ColumnName (not .IsRelation) -}}{{.Name}}: field.New{{.GenType}}("{{$.TableName}}", "{{.ColumnName}}"),` + "\n" + `{{end}}
+	{{- end}}
+}
+`
+
+// ModelParams create/update param structs of model
+const ModelParams = `
+// {{.ModelStructName}}CreateParam params to create {{.ModelStructName}}
+type {{.ModelStructName}}CreateParam struct {
+	{{range .CreateParamFields -}}
+	{{.Name}} {{.Type}} ` + "`{{.Tags}}`" + `{{if and .ColumnComment (not .MultilineComment)}} // {{.ColumnComment}}{{end}}
+	{{end -}}
+}
+
+// {{.ModelStructName}}UpdateParam params to update {{.ModelStructName}}, nil field is not updated
+type {{.ModelStructName}}UpdateParam struct {
+	{{range .UpdateParamFields -}}
+	{{.Name}} {{.Type}} ` + "`{{.Tags}}`" + `{{if and .ColumnComment (not .MultilineComment)}} // {{.ColumnComment}}{{end}}
+	{{end -}}
+}
+`
+
+// ModelZeroValues zero values of model fields
+const ModelZeroValues = `
+{{with .ZeroConsts -}}
+// zero values of {{$.ModelStructName}} fields
+const (
+	{{range . -}}
+	{{.Name}} {{.Type}} = {{.Value}}
+	{{end -}}
+)
+{{end}}
+{{with .ZeroVars -}}
+// zero values of {{$.ModelStructName}} fields
+var (
+	{{range . -}}
+	{{.Name}} {{.Type}}
+	{{end -}}
+)
+{{end}}
+`
+
+// ModelUpdatableColumns updatable column names of model
+const ModelUpdatableColumns = `
+// {{.ModelStructName}}UpdatableColumns updatable columns of {{.ModelStructName}}, used to select columns of partial update
+var {{.ModelStructName}}UpdatableColumns = []string{ {{- range $i, $c := .UpdatableColumns}}{{if $i}}, {{end}}"{{$c}}"{{end -}} }
+`
+
+// ModelEnumDefaults constructor of model initializing enum fields with typed consts of column defaults
+const ModelEnumDefaults = `
+{{with .EnumDefaults -}}
+// New{{$.ModelStructName}} new {{$.ModelStructName}} with enum fields initialized by column defaults
+func New{{$.ModelStructName}}() *{{$.ModelStructName}} {
+	return &{{$.ModelStructName}}{
+		{{range . -}}
+		{{.Field}}: {{.Const}},
+		{{end -}}
+	}
 }
+{{end}}
+`
+
+// ModelInterface getter interface of model, used to mock model in tests
+const ModelInterface = `
+// {{.ModelStructName}}Getter getter interface of {{.ModelStructName}}
+type {{.ModelStructName}}Getter interface {
+	{{range .Getters -}}
+	{{.MethodName}}() {{.GetResultParamInTmpl}}
+	{{end -}}
+}
+
+var _ {{.ModelStructName}}Getter = (*{{.ModelStructName}})(nil)
+`
 
+// ModelEntityAssertion compile-time assertion of entity interface implemented by model
+const ModelEntityAssertion = `
+{{if .EntityInterface -}}
+var _ {{.EntityInterface}} = (*{{.ModelStructName}})(nil)
+{{end}}
+`
+
+// ModelRegistry all generated models of model package, used by AutoMigrate
+const ModelRegistry = NotEditMark + `
+package {{.Package}}
+
+// AllModels pointers to all generated models, e.g. db.AutoMigrate(model.AllModels()...)
+func AllModels() []interface{} {
+	return []interface{}{
+		{{range .Models -}}
+		new({{.}}),
+		{{end -}}
+	}
+}
+`
+
+// ModelValidators package-level validators shared by generated Validate methods, one for each tag
+const ModelValidators = NotEditMark + `
+package {{.Package}}
+
+import "github.com/go-playground/validator/v10"
+
+{{range .Validators}}
+// {{.Name}} validator of {{.Tag}} tag used by Validate methods of models, parsed structs are cached,
+// replace it or register custom validations on it before use
+var {{.Name}} = newTagValidator("{{.Tag}}")
+{{end}}
+
+func newTagValidator(tagName string) *validator.Validate {
+	v := validator.New()
+	v.SetTagName(tagName)
+	return v
+}
+`
+
+// EnumFile header of shared enum types file
+const EnumFile = NotEditMark + `
+package {{.}}
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+`
+
+// EnumType named type of enum column with sql.Scanner/driver.Valuer(if Scanner)
+const EnumType = `
+{{if not .TypeDeclared -}}
+// {{.Name}} enum type
+type {{.Name}} {{if .Integer}}int{{else}}string{{end}}
+
+{{with .Consts -}}
+const (
+	{{range . -}}
+	{{.Name}} {{$.Name}} = {{if $.Integer}}{{.Value}}{{else}}{{printf "%q" .Value}}{{end}}
+	{{end -}}
+)
+{{- end}}
+{{end -}}
+
+{{if and .Scanner (not (index .DeclaredMethods "Scan")) -}}
+// Scan implements sql.Scanner
+{{if .Integer -}}
+func (e *{{.Name}}) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*e = 0
+		return nil
+	case int64:
+		*e = {{.Name}}(v)
+		return nil
+	case []byte:
+		value = string(v)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into {{.Name}}", value)
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into {{.Name}}: %w", s, err)
+	}
+	*e = {{.Name}}(i)
+	return nil
+}
+{{- else -}}
+func (e *{{.Name}}) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*e = ""
+	case string:
+		*e = {{.Name}}(v)
+	case []byte:
+		*e = {{.Name}}(v)
+	default:
+		return fmt.Errorf("cannot scan %T into {{.Name}}", value)
+	}
+	return nil
+}
+{{- end}}
+{{end}}
+
+{{if and .Scanner (not (index .DeclaredMethods "Value")) -}}
+// Value implements driver.Valuer
+func (e {{.Name}}) Value() (driver.Value, error) {
+	{{if .Native -}}
+	return int64(e), nil
+	{{- else if .Integer -}}
+	return strconv.Itoa(int(e)), nil
+	{{- else -}}
+	return string(e), nil
+	{{- end}}
+}
+{{end}}
+
+{{if and .Text (not (index .DeclaredMethods "MarshalText")) -}}
+// MarshalText implements encoding.TextMarshaler
+func (e {{.Name}}) MarshalText() ([]byte, error) {
+	{{if .Integer -}}
+	return []byte(strconv.Itoa(int(e))), nil
+	{{- else -}}
+	return []byte(e), nil
+	{{- end}}
+}
+{{end}}
+
+{{if and .Text (not (index .DeclaredMethods "UnmarshalText")) -}}
+// UnmarshalText implements encoding.TextUnmarshaler
+func (e *{{.Name}}) UnmarshalText(text []byte) error {
+	{{if .Integer -}}
+	i, err := strconv.Atoi(string(text))
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal %q into {{.Name}}: %w", text, err)
+	}
+	*e = {{.Name}}(i)
+	{{- else -}}
+	*e = {{.Name}}(text)
+	{{- end}}
+	return nil
+}
+{{end}}
 `
 
 // ModelMethod model struct DIY method
@@ -0,0 +1,60 @@
+package template
+
+// ProtoFile protobuf message of model
+const ProtoFile = `// Code generated by gorm.io/gen. DO NOT EDIT.
+
+syntax = "proto3";
+
+package {{.Package}};
+{{if .GoPackage}}
+option go_package = "{{.GoPackage}}";
+{{end}}{{if .Message.Imports}}
+{{range .Message.Imports}}import "{{.}}";
+{{end}}{{end}}
+{{range .Message.CommentLines}}// {{.}}
+{{end -}}
+message {{.Message.Name}} {
+{{- range .Message.Fields}}
+{{range .CommentLines}}  // {{.}}
+{{end}}  {{if .Optional}}optional {{end}}{{.Type}} {{.Name}} = {{.Number}};
+{{- end}}
+}
+`
+
+// ProtoConverter conversion helpers between model and protobuf message generated by protoc-gen-go
+const ProtoConverter = NotEditMark + `
+package {{.Package}}
+
+import (
+	"database/sql"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gorm.io/gorm"
+
+	pb "{{.GoPackage}}"
+)
+
+// ToProto convert {{.Model}} to protobuf message
+func (m *{{.Model}}) ToProto() *pb.{{.Message.GoName}} {
+	if m == nil {
+		return nil
+	}
+	p := &pb.{{.Message.GoName}}{}
+	{{range .Message.Fields}}{{.ToProto}}
+	{{end -}}
+	return p
+}
+
+// {{.Model}}FromProto convert protobuf message to {{.Model}}
+func {{.Model}}FromProto(p *pb.{{.Message.GoName}}) *{{.Model}} {
+	if p == nil {
+		return nil
+	}
+	m := &{{.Model}}{}
+	{{range .Message.Fields}}{{.FromProto}}
+	{{end -}}
+	return m
+}
+`
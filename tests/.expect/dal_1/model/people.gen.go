@@ -27,7 +27,7 @@ type Person struct {
 	DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at" json:"deleted_at"`
 	Score          float64        `gorm:"column:score" json:"score"`
 	Number         int32          `gorm:"column:number" json:"number"`
-	Birth          time.Time      `gorm:"column:birth;default:CURRENT_TIMESTAMP" json:"birth"`
+	Birth          time.Time      `gorm:"column:birth;default:(-)" json:"birth"`
 	XMLHTTPRequest string         `gorm:"column:xmlHTTPRequest;default:' '" json:"xmlHTTPRequest"`
 	JStr           string         `gorm:"column:jStr" json:"jStr"`
 	Geo            string         `gorm:"column:geo" json:"geo"`
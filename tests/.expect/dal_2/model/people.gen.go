@@ -27,7 +27,7 @@ type Person struct {
 	DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at" json:"-"`
 	Score          *float64       `gorm:"column:score" json:"-"`
 	Number         *int32         `gorm:"column:number" json:"-"`
-	Birth          *time.Time     `gorm:"column:birth;default:CURRENT_TIMESTAMP" json:"-"`
+	Birth          *time.Time     `gorm:"column:birth;default:(-)" json:"-"`
 	XMLHTTPRequest *string        `gorm:"column:xmlHTTPRequest;default:' '" json:"-"`
 	JStr           *string        `gorm:"column:jStr" json:"-"`
 	Geo            *string        `gorm:"column:geo" json:"-"`
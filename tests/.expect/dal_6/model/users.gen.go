@@ -15,7 +15,7 @@ type User struct {
 	ID           int64      `gorm:"column:id;primaryKey;autoIncrement:true" json:"-"`
 	CreatedAt    *time.Time `gorm:"column:created_at" json:"-"`
 	Name         *string    `gorm:"column:name;index:idx_name,priority:1;index:idx_name_company_id,priority:1;comment:oneline" json:"-"` // oneline
-	Address      *string    `gorm:"column:address" json:"-"`
+	Address      *string    `gorm:"column:address;default:''" json:"-"`
 	RegisterTime *time.Time `gorm:"column:register_time" json:"-"`
 	/*
 		multiline
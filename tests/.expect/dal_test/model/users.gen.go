@@ -15,7 +15,7 @@ type User struct {
 	ID           int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
 	CreatedAt    time.Time `gorm:"column:created_at" json:"created_at"`
 	Name         string    `gorm:"column:name;comment:oneline" json:"name"`
-	Address      string    `gorm:"column:address" json:"address"`
+	Address      string    `gorm:"column:address;default:''" json:"address"`
 	RegisterTime time.Time `gorm:"column:register_time" json:"register_time"`
 	Alive      bool   `gorm:"column:alive;comment:multiline\nline1\nline2" json:"alive"`
 	CompanyID  int64  `gorm:"column:company_id;default:666" json:"company_id"`